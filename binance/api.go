@@ -24,6 +24,7 @@ type api struct {
 	doneSpotTra          chan error
 	clientAssDiv         *resty.Client
 	doneAssDiv           chan error
+	clientBal            *resty.Client
 	basePath             string
 	apiKey               string
 	secretKey            string
@@ -73,6 +74,9 @@ func (b *Binance) NewAPI(apiKey, secretKey string, debug bool) {
 	b.api.clientAssDiv.SetRetryCount(3).SetRetryWaitTime(1 * time.Second)
 	b.api.clientAssDiv.SetDebug(debug)
 	b.api.doneAssDiv = make(chan error)
+	b.api.clientBal = resty.New()
+	b.api.clientBal.SetRetryCount(3)
+	b.api.clientBal.SetDebug(debug)
 	b.api.basePath = "https://api.binance.com/"
 	b.api.apiKey = apiKey
 	b.api.secretKey = secretKey
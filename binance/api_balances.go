@@ -0,0 +1,57 @@
+package binance
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
+	"github.com/shopspring/decimal"
+)
+
+type GetAccountResp struct {
+	Balances []struct {
+		Asset  string `json:"asset"`
+		Free   string `json:"free"`
+		Locked string `json:"locked"`
+	} `json:"balances"`
+}
+
+func (b *Binance) GetAPIBalances() (balances wallet.WalletCurrencies, err error) {
+	const SOURCE = "Binance API Balances :"
+	balances = make(wallet.WalletCurrencies)
+	endpoint := "api/v3/account"
+	queryParams := map[string]string{
+		"recvWindow": "60000",
+		"timestamp":  fmt.Sprintf("%v", time.Now().UTC().UnixNano()/1e6),
+	}
+	b.api.sign(queryParams)
+	resp, err := b.api.clientBal.R().
+		SetHeader("X-MBX-APIKEY", b.api.apiKey).
+		SetQueryParams(queryParams).
+		SetResult(&GetAccountResp{}).
+		SetError(&ErrorResp{}).
+		Get(b.api.basePath + endpoint)
+	if err != nil {
+		return balances, errors.New(SOURCE + " Error Requesting")
+	}
+	if resp.StatusCode() > 300 {
+		return balances, errors.New(SOURCE + " Error StatusCode" + strconv.Itoa(resp.StatusCode()))
+	}
+	for _, bal := range (*resp.Result().(*GetAccountResp)).Balances {
+		free, err := decimal.NewFromString(bal.Free)
+		if err != nil {
+			log.Println(SOURCE, "Error Parsing Free", bal.Free)
+		}
+		locked, err := decimal.NewFromString(bal.Locked)
+		if err != nil {
+			log.Println(SOURCE, "Error Parsing Locked", bal.Locked)
+		}
+		if !free.Add(locked).IsZero() {
+			balances[bal.Asset] = balances[bal.Asset].Add(free).Add(locked)
+		}
+	}
+	return balances, nil
+}
@@ -51,15 +51,7 @@ func (b *Binance) WaitFinish(account string) error {
 			b.Sources["Binance"] = src
 		}
 	} else {
-		b.Sources["Binance"] = source.Source{
-			Crypto:        true,
-			AccountNumber: account,
-			OpeningDate:   b.api.firstTimeUsed,
-			ClosingDate:   b.api.lastTimeUsed,
-			LegalName:     "Binance Europe Services Limited",
-			Address:       "LEVEL G (OFFICE 1/1235), QUANTUM HOUSE,75 ABATE RIGORD STREET, TA' XBIEXXBX 1120\nMalta",
-			URL:           "https://www.binance.com/fr",
-		}
+		b.Sources["Binance"] = source.New("Binance", account, b.api.firstTimeUsed, b.api.lastTimeUsed)
 	}
 	return err
 }
@@ -3,9 +3,9 @@ package binance
 import (
 	"encoding/csv"
 	"io"
-	"log"
 	"time"
 
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
 	"github.com/fiscafacile/CryptoFiscaFacile/source"
 	"github.com/fiscafacile/CryptoFiscaFacile/utils"
 	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
@@ -23,7 +23,12 @@ type csvTX struct {
 	Remark    string
 }
 
-func (b *Binance) ParseCSV(reader io.Reader, extended bool, account string) (err error) {
+var CSVFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"UTC_Time", "Account", "Operation", "Coin", "Change", "Remark"}, Positional: true, Date: "UTC_Time", DateLayouts: []string{"2006-01-02 15:04:05"}, Amounts: []string{"Change"}},
+	{Version: "extended", Columns: []string{"UTC_Time", "Account", "Operation", "Coin", "Change", "Fee", "Remark"}},
+}
+
+func (b *Binance) ParseCSV(reader io.Reader, account string) (err error) {
 	firstTimeUsed := time.Now()
 	lastTimeUsed := time.Date(2009, time.January, 1, 0, 0, 0, 0, time.UTC)
 	const SOURCE = "Binance CSV :"
@@ -32,36 +37,39 @@ func (b *Binance) ParseCSV(reader io.Reader, extended bool, account string) (err
 	if err == nil {
 		alreadyAsked := []string{}
 		loc, _ := time.LoadLocation("Europe/Paris")
-		for _, r := range records {
-			if r[0] != "UTC_Time" {
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVFormats...)
+				if err != nil {
+					return
+				}
+			}
+			if !l.IsHeader(r) {
 				tx := csvTX{}
-				tx.Time, err = time.ParseInLocation("2006-01-02 15:04:05", r[0], loc)
+				tx.Time, err = time.ParseInLocation("2006-01-02 15:04:05", l.Get(r, "UTC_Time"), loc)
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Time", r[0])
+					diag.Add(SOURCE, n+1, "UTC_Time", l.Get(r, "UTC_Time"), "Error Parsing Time")
 				}
 				tx.ID = utils.GetUniqueID(SOURCE + tx.Time.String())
-				tx.Account = r[1]
-				tx.Operation = r[2]
-				tx.Coin = r[3]
-				tx.Change, err = decimal.NewFromString(r[4])
+				tx.Account = l.Get(r, "Account")
+				tx.Operation = l.Get(r, "Operation")
+				tx.Coin = l.Get(r, "Coin")
+				tx.Change, err = decimal.NewFromString(l.Get(r, "Change"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Amount", r[4])
+					diag.Add(SOURCE, n+1, "Change", l.Get(r, "Change"), "Error Parsing Amount")
 				}
-				if extended {
-					if r[5] != "" {
-						tx.Fee, err = decimal.NewFromString(r[5])
-						if err != nil {
-							log.Println(SOURCE, "Error Parsing Fee", r[5])
-						} else {
-							if tx.Fee.IsNegative() {
-								tx.Fee = tx.Fee.Neg()
-							}
+				if l.Get(r, "Fee") != "" {
+					tx.Fee, err = decimal.NewFromString(l.Get(r, "Fee"))
+					if err != nil {
+						diag.Add(SOURCE, n+1, "Fee", l.Get(r, "Fee"), "Error Parsing Fee")
+					} else {
+						if tx.Fee.IsNegative() {
+							tx.Fee = tx.Fee.Neg()
 						}
 					}
-					tx.Remark = r[6]
-				} else {
-					tx.Remark = r[5]
 				}
+				tx.Remark = l.Get(r, "Remark")
 				b.csvTXs = append(b.csvTXs, tx)
 				if tx.Time.Before(firstTimeUsed) {
 					firstTimeUsed = tx.Time
@@ -189,15 +197,7 @@ func (b *Binance) ParseCSV(reader io.Reader, extended bool, account string) (err
 				}
 			}
 		}
-		b.Sources["Binance"] = source.Source{
-			Crypto:        true,
-			AccountNumber: account,
-			OpeningDate:   firstTimeUsed,
-			ClosingDate:   lastTimeUsed,
-			LegalName:     "Binance Europe Services Limited",
-			Address:       "LEVEL G (OFFICE 1/1235), QUANTUM HOUSE,75 ABATE RIGORD STREET, TA' XBIEXXBX 1120\nMalta",
-			URL:           "https://www.binance.com/fr",
-		}
+		b.Sources["Binance"] = source.New("Binance", account, firstTimeUsed, lastTimeUsed)
 	}
 	return
 }
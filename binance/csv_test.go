@@ -30,7 +30,7 @@ func Test_CSVParseExemple(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			b := New()
-			err := b.ParseCSV(strings.NewReader(tt.csv))
+			err := b.ParseCSV(strings.NewReader(tt.csv), "")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Binance.ParseCSV() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -3,11 +3,12 @@ package bitfinex
 import (
 	"encoding/csv"
 	"io"
-	"log"
 	"strings"
 	"time"
 
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
 	"github.com/fiscafacile/CryptoFiscaFacile/source"
+	"github.com/fiscafacile/CryptoFiscaFacile/utils"
 	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
 	"github.com/shopspring/decimal"
 )
@@ -22,6 +23,10 @@ type CsvTX struct {
 	Wallet      string
 }
 
+var CSVFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"#", "DESCRIPTION", "CURRENCY", "AMOUNT", "BALANCE", "DATE", "WALLET"}, Positional: true, Date: "DATE", DateLayouts: []string{"02-01-06 15:04:05"}, Amounts: []string{"AMOUNT", "BALANCE"}},
+}
+
 func (bf *Bitfinex) ParseCSV(reader io.Reader, account string) (err error) {
 	firstTimeUsed := time.Now()
 	lastTimeUsed := time.Date(2009, time.January, 1, 0, 0, 0, 0, time.UTC)
@@ -30,25 +35,32 @@ func (bf *Bitfinex) ParseCSV(reader io.Reader, account string) (err error) {
 	records, err := csvReader.ReadAll()
 	if err == nil {
 		alreadyAsked := []string{}
-		for _, r := range records {
-			if r[0] != "#" {
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVFormats...)
+				if err != nil {
+					return
+				}
+			}
+			if !l.IsHeader(r) {
 				tx := CsvTX{}
-				tx.ID = r[0]
-				tx.Description = r[1]
-				tx.Currency = strings.ReplaceAll(r[2], "BAB", "BCH")
-				tx.Amount, err = decimal.NewFromString(r[3])
+				tx.ID = l.Get(r, "#")
+				tx.Description = l.Get(r, "DESCRIPTION")
+				tx.Currency = strings.ReplaceAll(l.Get(r, "CURRENCY"), "BAB", "BCH")
+				tx.Amount, err = decimal.NewFromString(l.Get(r, "AMOUNT"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Amount", r[3])
+					diag.Add(SOURCE, n+1, "AMOUNT", l.Get(r, "AMOUNT"), "Error Parsing Amount")
 				}
-				tx.Balance, err = decimal.NewFromString(r[4])
+				tx.Balance, err = decimal.NewFromString(l.Get(r, "BALANCE"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Balance", r[4])
+					diag.Add(SOURCE, n+1, "BALANCE", l.Get(r, "BALANCE"), "Error Parsing Balance")
 				}
-				tx.Date, err = time.Parse("02-01-06 15:04:05", r[5])
+				tx.Date, err = time.Parse("02-01-06 15:04:05", l.Get(r, "DATE"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Date", r[5])
+					diag.Add(SOURCE, n+1, "DATE", l.Get(r, "DATE"), "Error Parsing Date")
 				}
-				tx.Wallet = r[6]
+				tx.Wallet = l.Get(r, "WALLET")
 				bf.CsvTXs = append(bf.CsvTXs, tx)
 				if tx.Date.Before(firstTimeUsed) {
 					firstTimeUsed = tx.Date
@@ -62,11 +74,15 @@ func (bf *Bitfinex) ParseCSV(reader io.Reader, account string) (err error) {
 					strings.Contains(tx.Description, "Settlement") {
 					found := false
 					for i, ex := range bf.TXsByCategory["Exchanges"] {
-						// log.Println(strings.Split(tx.Description, " ")[1], strings.Split(ex.Note, " ")[4])
+						exAmount := strings.Split(ex.Note, " ")[4]
+						if strings.Contains(ex.Note, "Trading fees for ") {
+							// the fee line came first
+							exAmount = strings.Split(ex.Note, " ")[6]
+						}
 						if ex.Note == "Bitfinex CSV : "+tx.Description ||
 							(ex.SimilarDate(2*time.Second, tx.Date) &&
-								strings.Split(strings.Split(ex.Note, " ")[4], ".")[0] == strings.Split(strings.Split(tx.Description, " ")[1], ".")[0] &&
-								strings.Split(strings.Split(ex.Note, " ")[4], ".")[1][:1] == strings.Split(strings.Split(tx.Description, " ")[1], ".")[1][:1]) {
+								strings.Split(exAmount, ".")[0] == strings.Split(strings.Split(tx.Description, " ")[1], ".")[0] &&
+								strings.Split(exAmount+".", ".")[1][:1] == strings.Split(strings.Split(tx.Description, " ")[1]+".", ".")[1][:1]) {
 							found = true
 							if ex.Note != "Bitfinex CSV : "+tx.Description {
 								bf.TXsByCategory["Exchanges"][i].Note = "Bitfinex CSV : " + tx.Description
@@ -171,15 +187,7 @@ func (bf *Bitfinex) ParseCSV(reader io.Reader, account string) (err error) {
 				}
 			}
 		}
-		bf.Sources["Bitfinex"] = source.Source{
-			Crypto:        true,
-			AccountNumber: account,
-			OpeningDate:   firstTimeUsed,
-			ClosingDate:   lastTimeUsed,
-			LegalName:     "Bitfinex",
-			Address:       "1308 Bank of America Tower, 13/F\n12 Harcourt Road, Central\nHong Kong",
-			URL:           "https://www.bitfinex.com",
-		}
+		bf.Sources["Bitfinex"] = source.New("Bitfinex", account, firstTimeUsed, lastTimeUsed)
 	}
 	return
 }
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
+	"github.com/shopspring/decimal"
 )
 
 func Test_CSVParseExemple(t *testing.T) {
@@ -23,7 +24,7 @@ func Test_CSVParseExemple(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			bf := New()
-			err := bf.ParseCSV(strings.NewReader(tt.csv))
+			err := bf.ParseCSV(strings.NewReader(tt.csv), "")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Bitfinex.ParseCSV() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -46,11 +47,11 @@ func Test_CSVParseExchangeExemple(t *testing.T) {
 				{
 					Timestamp: time.Date(2017, time.December, 10, 19, 50, 8, 0, time.UTC),
 					Items: map[string]wallet.Currencies{
-						"From": {{Code: "BTC", Amount: 0.16683656}},
-						"To":   {{Code: "XMR", Amount: 10.481}},
-						"Fee":  {{Code: "XMR", Amount: 0.020962}},
+						"From": {{Code: "BTC", Amount: decimal.RequireFromString("0.16683656")}},
+						"To":   {{Code: "XMR", Amount: decimal.RequireFromString("10.481")}},
+						"Fee":  {{Code: "XMR", Amount: decimal.RequireFromString("0.020962")}},
 					},
-					Note: "Exchange 10.481 XMR for BTC @ 0.015918 on wallet exchange",
+					Note: "Bitfinex CSV : Exchange 10.481 XMR for BTC @ 0.015918 on wallet exchange",
 				},
 			},
 		},
@@ -62,11 +63,11 @@ func Test_CSVParseExchangeExemple(t *testing.T) {
 				{
 					Timestamp: time.Date(2017, time.December, 10, 19, 50, 8, 0, time.UTC),
 					Items: map[string]wallet.Currencies{
-						"From": {{Code: "BTC", Amount: 0.16683656}},
-						"To":   {{Code: "XMR", Amount: 10.481}},
-						"Fee":  {{Code: "XMR", Amount: 0.020962}},
+						"From": {{Code: "BTC", Amount: decimal.RequireFromString("0.16683656")}},
+						"To":   {{Code: "XMR", Amount: decimal.RequireFromString("10.481")}},
+						"Fee":  {{Code: "XMR", Amount: decimal.RequireFromString("0.020962")}},
 					},
-					Note: "Exchange 10.481 XMR for BTC @ 0.015918 on wallet exchange",
+					Note: "Bitfinex CSV : Exchange 10.481 XMR for BTC @ 0.015918 on wallet exchange",
 				},
 			},
 		},
@@ -78,11 +79,11 @@ func Test_CSVParseExchangeExemple(t *testing.T) {
 				{
 					Timestamp: time.Date(2017, time.December, 10, 19, 50, 8, 0, time.UTC),
 					Items: map[string]wallet.Currencies{
-						"From": {{Code: "BTC", Amount: 0.16683656}},
-						"To":   {{Code: "XMR", Amount: 10.481}},
-						"Fee":  {{Code: "XMR", Amount: 0.020962}},
+						"From": {{Code: "BTC", Amount: decimal.RequireFromString("0.16683656")}},
+						"To":   {{Code: "XMR", Amount: decimal.RequireFromString("10.481")}},
+						"Fee":  {{Code: "XMR", Amount: decimal.RequireFromString("0.020962")}},
 					},
-					Note: "Exchange 10.481 XMR for BTC @ 0.015918 on wallet exchange",
+					Note: "Bitfinex CSV : Exchange 10.481 XMR for BTC @ 0.015918 on wallet exchange",
 				},
 			},
 		},
@@ -94,11 +95,11 @@ func Test_CSVParseExchangeExemple(t *testing.T) {
 				{
 					Timestamp: time.Date(2017, time.December, 10, 19, 50, 8, 0, time.UTC),
 					Items: map[string]wallet.Currencies{
-						"From": {{Code: "BTC", Amount: 0.16683656}},
-						"To":   {{Code: "XMR", Amount: 10.481}},
-						"Fee":  {{Code: "XMR", Amount: 0.020962}},
+						"From": {{Code: "BTC", Amount: decimal.RequireFromString("0.16683656")}},
+						"To":   {{Code: "XMR", Amount: decimal.RequireFromString("10.481")}},
+						"Fee":  {{Code: "XMR", Amount: decimal.RequireFromString("0.020962")}},
 					},
-					Note: "Exchange 10.481 XMR for BTC @ 0.015918 on wallet exchange",
+					Note: "Bitfinex CSV : Exchange 10.481 XMR for BTC @ 0.015918 on wallet exchange",
 				},
 			},
 		},
@@ -110,11 +111,11 @@ func Test_CSVParseExchangeExemple(t *testing.T) {
 				{
 					Timestamp: time.Date(2017, time.December, 10, 19, 50, 8, 0, time.UTC),
 					Items: map[string]wallet.Currencies{
-						"From": {{Code: "BTC", Amount: 0.16683656}},
-						"To":   {{Code: "XMR", Amount: 10.481}},
-						"Fee":  {{Code: "XMR", Amount: 0.020962}},
+						"From": {{Code: "BTC", Amount: decimal.RequireFromString("0.16683656")}},
+						"To":   {{Code: "XMR", Amount: decimal.RequireFromString("10.481")}},
+						"Fee":  {{Code: "XMR", Amount: decimal.RequireFromString("0.020962")}},
 					},
-					Note: "Exchange 10.481 XMR for BTC @ 0.015918 on wallet exchange",
+					Note: "Bitfinex CSV : Exchange 10.481 XMR for BTC @ 0.015918 on wallet exchange",
 				},
 			},
 		},
@@ -126,11 +127,11 @@ func Test_CSVParseExchangeExemple(t *testing.T) {
 				{
 					Timestamp: time.Date(2017, time.December, 10, 19, 50, 8, 0, time.UTC),
 					Items: map[string]wallet.Currencies{
-						"From": {{Code: "BTC", Amount: 0.16683656}},
-						"To":   {{Code: "XMR", Amount: 10.481}},
-						"Fee":  {{Code: "XMR", Amount: 0.020962}},
+						"From": {{Code: "BTC", Amount: decimal.RequireFromString("0.16683656")}},
+						"To":   {{Code: "XMR", Amount: decimal.RequireFromString("10.481")}},
+						"Fee":  {{Code: "XMR", Amount: decimal.RequireFromString("0.020962")}},
 					},
-					Note: "Exchange 10.481 XMR for BTC @ 0.015918 on wallet exchange",
+					Note: "Bitfinex CSV : Exchange 10.481 XMR for BTC @ 0.015918 on wallet exchange",
 				},
 			},
 		},
@@ -138,18 +139,18 @@ func Test_CSVParseExchangeExemple(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			bf := New()
-			err := bf.ParseCSV(strings.NewReader(tt.csv))
+			err := bf.ParseCSV(strings.NewReader(tt.csv), "")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Bitfinex.ParseCSV() error = %v, wantErr %v", err, tt.wantErr)
 			}
 			if len(bf.TXsByCategory["Exchanges"]) != len(tt.wantExchanges) ||
 				!bf.TXsByCategory["Exchanges"][0].Timestamp.Equal(tt.wantExchanges[0].Timestamp) ||
 				bf.TXsByCategory["Exchanges"][0].Items["From"][0].Code != tt.wantExchanges[0].Items["From"][0].Code ||
-				bf.TXsByCategory["Exchanges"][0].Items["From"][0].Amount != tt.wantExchanges[0].Items["From"][0].Amount ||
+				!bf.TXsByCategory["Exchanges"][0].Items["From"][0].Amount.Equal(tt.wantExchanges[0].Items["From"][0].Amount) ||
 				bf.TXsByCategory["Exchanges"][0].Items["To"][0].Code != tt.wantExchanges[0].Items["To"][0].Code ||
-				bf.TXsByCategory["Exchanges"][0].Items["To"][0].Amount != tt.wantExchanges[0].Items["To"][0].Amount ||
+				!bf.TXsByCategory["Exchanges"][0].Items["To"][0].Amount.Equal(tt.wantExchanges[0].Items["To"][0].Amount) ||
 				bf.TXsByCategory["Exchanges"][0].Items["Fee"][0].Code != tt.wantExchanges[0].Items["Fee"][0].Code ||
-				bf.TXsByCategory["Exchanges"][0].Items["Fee"][0].Amount != tt.wantExchanges[0].Items["Fee"][0].Amount ||
+				!bf.TXsByCategory["Exchanges"][0].Items["Fee"][0].Amount.Equal(tt.wantExchanges[0].Items["Fee"][0].Amount) ||
 				bf.TXsByCategory["Exchanges"][0].Note != tt.wantExchanges[0].Note {
 				t.Errorf("Bitfinex.ParseCSV() bf.TXsByCategory[\"Exchanges\"] = %v, wantExchanges %v", bf.TXsByCategory["Exchanges"], tt.wantExchanges)
 			}
@@ -19,6 +19,7 @@ type api struct {
 	doneCryptoTrans   chan error
 	clientUserTrans   *resty.Client
 	doneUserTrans     chan error
+	clientBal         *resty.Client
 	basePath          string
 	apiKey            string
 	secretKey         string
@@ -47,6 +48,9 @@ func (bs *Bitstamp) NewAPI(apiKey, secretKey string, debug bool) {
 	bs.api.clientUserTrans.SetRetryCount(3)
 	bs.api.clientUserTrans.SetDebug(debug)
 	bs.api.doneUserTrans = make(chan error)
+	bs.api.clientBal = resty.New()
+	bs.api.clientBal.SetRetryCount(3)
+	bs.api.clientBal.SetDebug(debug)
 	bs.api.basePath = "https://www.bitstamp.net/api/v2/"
 	bs.api.apiKey = apiKey
 	bs.api.secretKey = secretKey
@@ -0,0 +1,41 @@
+package bitstamp
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
+	"github.com/shopspring/decimal"
+)
+
+func (bs *Bitstamp) GetAPIBalances() (balances wallet.WalletCurrencies, err error) {
+	const SOURCE = "Bitstamp API Balances :"
+	balances = make(wallet.WalletCurrencies)
+	url := bs.api.basePath + "balance/"
+	req := bs.api.clientBal.R()
+	bs.api.sign(req, "POST", url)
+	resp, err := req.SetResult(&map[string]string{}).
+		SetError(&ErrorResp{}).
+		Post(url)
+	if err != nil {
+		return balances, errors.New(SOURCE + " Error Requesting")
+	}
+	if resp.StatusCode() > 300 {
+		return balances, errors.New(SOURCE + " Error StatusCode" + strconv.Itoa(resp.StatusCode()))
+	}
+	for k, v := range *resp.Result().(*map[string]string) {
+		if strings.HasSuffix(k, "_balance") {
+			bal, err := decimal.NewFromString(v)
+			if err != nil {
+				log.Println(SOURCE, "Error Parsing Balance", k, v)
+				continue
+			}
+			if !bal.IsZero() {
+				balances[strings.ToUpper(strings.TrimSuffix(k, "_balance"))] = bal
+			}
+		}
+	}
+	return balances, nil
+}
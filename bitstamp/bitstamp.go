@@ -50,15 +50,7 @@ func (bs *Bitstamp) WaitFinish(account string) error {
 			bs.Sources["Bitstamp"] = src
 		}
 	} else {
-		bs.Sources["Bitstamp"] = source.Source{
-			Crypto:        true,
-			AccountNumber: account,
-			OpeningDate:   bs.api.firstTimeUsed,
-			ClosingDate:   bs.api.lastTimeUsed,
-			LegalName:     "Bitstamp Ltd",
-			Address:       "5 New Street Square,\nLondon EC4A 3TW,\nRoyaume-Uni",
-			URL:           "https://bitstamp.com",
-		}
+		bs.Sources["Bitstamp"] = source.New("Bitstamp", account, bs.api.firstTimeUsed, bs.api.lastTimeUsed)
 	}
 	return err
 }
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/fiscafacile/CryptoFiscaFacile/category"
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
 	"github.com/fiscafacile/CryptoFiscaFacile/source"
 	"github.com/fiscafacile/CryptoFiscaFacile/utils"
 	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
@@ -29,6 +30,10 @@ type csvTX struct {
 	SubType   string
 }
 
+var CSVFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"Type", "Datetime", "Account", "Amount", "Value", "Rate", "Fee", "Sub Type"}, Positional: true, Date: "Datetime", DateLayouts: []string{"Jan. 02, 2006, 03:04 PM"}},
+}
+
 func (bs *Bitstamp) ParseCSV(reader io.Reader, cat category.Category, native, account string) (err error) {
 	firstTimeUsed := time.Now()
 	lastTimeUsed := time.Date(2019, time.November, 14, 0, 0, 0, 0, time.UTC)
@@ -37,40 +42,47 @@ func (bs *Bitstamp) ParseCSV(reader io.Reader, cat category.Category, native, ac
 	records, err := csvReader.ReadAll()
 	if err == nil {
 		alreadyAsked := []string{}
-		for _, r := range records {
-			if r[0] != "Type" {
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVFormats...)
+				if err != nil {
+					return
+				}
+			}
+			if !l.IsHeader(r) {
 				tx := csvTX{}
-				tx.Type = r[0]
-				tx.DateTime, err = time.Parse("Jan. 02, 2006, 03:04 PM", r[1])
+				tx.Type = l.Get(r, "Type")
+				tx.DateTime, err = time.Parse("Jan. 02, 2006, 03:04 PM", l.Get(r, "Datetime"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Date", r[1])
+					diag.Add(SOURCE, n+1, "Datetime", l.Get(r, "Datetime"), "Error Parsing Date")
 				}
 				tx.ID = utils.GetUniqueID(SOURCE + tx.DateTime.String())
-				tx.Account = r[2]
-				curr := strings.Split(r[3], " ")
+				tx.Account = l.Get(r, "Account")
+				curr := strings.Split(l.Get(r, "Amount"), " ")
 				tx.Amount, err = decimal.NewFromString(curr[0])
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Amount", curr[0])
+					diag.Add(SOURCE, n+1, "Amount", curr[0], "Error Parsing Amount")
 				}
 				tx.Symbol = curr[1]
-				if r[4] != "" {
-					toCurr := strings.Split(r[4], " ")
+				if l.Get(r, "Value") != "" {
+					toCurr := strings.Split(l.Get(r, "Value"), " ")
 					tx.ToAmount, err = decimal.NewFromString(toCurr[0])
 					if err != nil {
-						log.Println(SOURCE, "Error Parsing ToAmount", toCurr[0])
+						diag.Add(SOURCE, n+1, "Value", toCurr[0], "Error Parsing ToAmount")
 					}
 					tx.ToSymbol = toCurr[1]
 				}
-				tx.Rate = r[5]
-				if r[6] != "" {
-					fee := strings.Split(r[6], " ")
+				tx.Rate = l.Get(r, "Rate")
+				if l.Get(r, "Fee") != "" {
+					fee := strings.Split(l.Get(r, "Fee"), " ")
 					tx.Fee, err = decimal.NewFromString(fee[0])
 					if err != nil {
-						log.Println(SOURCE, "Error Parsing Fee", fee[0])
+						diag.Add(SOURCE, n+1, "Fee", fee[0], "Error Parsing Fee")
 					}
 					tx.FeeSymbol = fee[1]
 				}
-				tx.SubType = r[7]
+				tx.SubType = l.Get(r, "Sub Type")
 				bs.csvTXs = append(bs.csvTXs, tx)
 				// Fill TXsByCategory
 				t := wallet.TX{Timestamp: tx.DateTime, ID: tx.ID, Note: SOURCE + " " + tx.Type + " " + tx.SubType}
@@ -129,15 +141,7 @@ func (bs *Bitstamp) ParseCSV(reader io.Reader, cat category.Category, native, ac
 			}
 		}
 		if _, ok := bs.Sources["Bitstamp"]; !ok {
-			bs.Sources["Bitstamp"] = source.Source{
-				Crypto:        true,
-				AccountNumber: account,
-				OpeningDate:   firstTimeUsed,
-				ClosingDate:   lastTimeUsed,
-				LegalName:     "Bitstamp Ltd",
-				Address:       "5 New Street Square,\nLondon EC4A 3TW,\nRoyaume-Uni",
-				URL:           "https://bitstamp.com",
-			}
+			bs.Sources["Bitstamp"] = source.New("Bitstamp", account, firstTimeUsed, lastTimeUsed)
 		}
 	}
 	return
@@ -19,6 +19,7 @@ type api struct {
 	doneWithdrawals   chan error
 	clientTrades      *resty.Client
 	doneTrades        chan error
+	clientBal         *resty.Client
 	basePath          string
 	apiKey            string
 	secretKey         string
@@ -45,6 +46,9 @@ func (btrx *Bittrex) NewAPI(apiKey, secretKey string, debug bool) {
 	btrx.api.clientTrades.SetRetryCount(3)
 	btrx.api.clientTrades.SetDebug(debug)
 	btrx.api.doneTrades = make(chan error)
+	btrx.api.clientBal = resty.New()
+	btrx.api.clientBal.SetRetryCount(3)
+	btrx.api.clientBal.SetDebug(debug)
 	btrx.api.basePath = "https://api.bittrex.com/v3/"
 	btrx.api.apiKey = apiKey
 	btrx.api.secretKey = secretKey
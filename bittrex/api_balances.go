@@ -0,0 +1,52 @@
+package bittrex
+
+import (
+	"errors"
+	"log"
+	"strconv"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
+	"github.com/shopspring/decimal"
+)
+
+type GetBalancesResponse []struct {
+	CurrencySymbol string `json:"currencySymbol"`
+	Total          string `json:"total"`
+	Available      string `json:"available"`
+}
+
+func (btrx *Bittrex) GetAPIBalances() (balances wallet.WalletCurrencies, err error) {
+	const SOURCE = "Bittrex API Balances :"
+	balances = make(wallet.WalletCurrencies)
+	hash := btrx.api.hash("")
+	ressource := "balances"
+	timestamp, signature := btrx.api.sign("", ressource, "GET", hash, "")
+	resp, err := btrx.api.clientBal.R().
+		SetHeaders(map[string]string{
+			"Accept":           "application/json",
+			"Content-Type":     "application/json",
+			"Api-Content-Hash": hash,
+			"Api-Key":          btrx.api.apiKey,
+			"Api-Signature":    signature,
+			"Api-Timestamp":    timestamp,
+		}).
+		SetResult(&GetBalancesResponse{}).
+		Get(btrx.api.basePath + ressource)
+	if err != nil {
+		return balances, errors.New(SOURCE + " Error Requesting")
+	}
+	if resp.StatusCode() > 300 {
+		return balances, errors.New(SOURCE + " Error StatusCode" + strconv.Itoa(resp.StatusCode()))
+	}
+	for _, bal := range *resp.Result().(*GetBalancesResponse) {
+		total, err := decimal.NewFromString(bal.Total)
+		if err != nil {
+			log.Println(SOURCE, "Error Parsing Total", bal.Total)
+			continue
+		}
+		if !total.IsZero() {
+			balances[bal.CurrencySymbol] = balances[bal.CurrencySymbol].Add(total)
+		}
+	}
+	return balances, nil
+}
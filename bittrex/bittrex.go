@@ -49,15 +49,7 @@ func (btrx *Bittrex) WaitFinish(account string) error {
 			btrx.Sources["Bittrex"] = src
 		}
 	} else {
-		btrx.Sources["Bittrex"] = source.Source{
-			Crypto:        true,
-			AccountNumber: account,
-			OpeningDate:   btrx.api.firstTimeUsed,
-			ClosingDate:   btrx.api.lastTimeUsed,
-			LegalName:     "Bittrex International GmbH",
-			Address:       "Dr. Grass-Strasse 12, 9490 Vaduz,\nPrincipality of Liechtenstein",
-			URL:           "https://global.bittrex.com",
-		}
+		btrx.Sources["Bittrex"] = source.New("Bittrex", account, btrx.api.firstTimeUsed, btrx.api.lastTimeUsed)
 	}
 	return err
 }
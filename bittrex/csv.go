@@ -3,12 +3,13 @@ package bittrex
 import (
 	"encoding/csv"
 	"io"
-	"log"
 	"strings"
 	"time"
 
 	"github.com/fiscafacile/CryptoFiscaFacile/category"
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
 	"github.com/fiscafacile/CryptoFiscaFacile/source"
+	"github.com/fiscafacile/CryptoFiscaFacile/utils"
 	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
 	"github.com/shopspring/decimal"
 )
@@ -26,6 +27,10 @@ type csvTX struct {
 	Remark      string
 }
 
+var CSVFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"Uuid", "Exchange", "TimeStamp", "OrderType", "Limit", "Quantity", "QuantityRemaining", "Commission", "Price", "PricePerUnit", "IsConditional", "Condition", "ConditionTarget", "ImmediateOrCancel", "Closed", "TimeInForceTypeId", "TimeInForce"}, Positional: true, Date: "Closed", DateLayouts: []string{"1/2/2006 3:04:05 PM"}, Amounts: []string{"Quantity", "QuantityRemaining", "Commission", "Price"}},
+}
+
 func (btrx *Bittrex) ParseCSV(reader io.Reader, cat category.Category, account string) (err error) {
 	firstTimeUsed := time.Now()
 	lastTimeUsed := time.Date(2009, time.January, 1, 0, 0, 0, 0, time.UTC)
@@ -44,32 +49,39 @@ func (btrx *Bittrex) ParseCSV(reader io.Reader, cat category.Category, account s
 	)
 	if err == nil {
 		alreadyAsked := []string{}
-		for _, r := range records {
-			if r[0] != "Uuid" {
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVFormats...)
+				if err != nil {
+					return
+				}
+			}
+			if !l.IsHeader(r) {
 				tx := csvTX{}
-				tx.ID = r[0]
-				symbolSlice := strings.Split(r[1], "-")
-				tx.Time, err = time.Parse("1/2/2006 3:04:05 PM", r[14])
+				tx.ID = l.Get(r, "Uuid")
+				symbolSlice := strings.Split(l.Get(r, "Exchange"), "-")
+				tx.Time, err = time.Parse("1/2/2006 3:04:05 PM", l.Get(r, "Closed"))
 				if err != nil {
-					log.Println("Error Parsing Time : ", r[14])
+					diag.Add(SOURCE, n+1, "Closed", l.Get(r, "Closed"), "Error Parsing Time")
 				}
-				tx.Operation = opeRplcr.Replace(r[3])
-				quantity, err := decimal.NewFromString(r[5])
+				tx.Operation = opeRplcr.Replace(l.Get(r, "OrderType"))
+				quantity, err := decimal.NewFromString(l.Get(r, "Quantity"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing quantity", r[5])
+					diag.Add(SOURCE, n+1, "Quantity", l.Get(r, "Quantity"), "Error Parsing quantity")
 				}
-				quantityRemaining, err := decimal.NewFromString(r[6])
+				quantityRemaining, err := decimal.NewFromString(l.Get(r, "QuantityRemaining"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing quantityRemaining", r[6])
+					diag.Add(SOURCE, n+1, "QuantityRemaining", l.Get(r, "QuantityRemaining"), "Error Parsing quantityRemaining")
 				}
-				tx.Fee, err = decimal.NewFromString(r[7])
+				tx.Fee, err = decimal.NewFromString(l.Get(r, "Commission"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Fee", r[7])
+					diag.Add(SOURCE, n+1, "Commission", l.Get(r, "Commission"), "Error Parsing Fee")
 				}
 				tx.FeeCurrency = symRplcr.Replace(symbolSlice[0])
-				price, err := decimal.NewFromString(r[8])
+				price, err := decimal.NewFromString(l.Get(r, "Price"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing price", r[8])
+					diag.Add(SOURCE, n+1, "Price", l.Get(r, "Price"), "Error Parsing price")
 				}
 				if tx.Time.Before(firstTimeUsed) {
 					firstTimeUsed = tx.Time
@@ -130,15 +142,7 @@ func (btrx *Bittrex) ParseCSV(reader io.Reader, cat category.Category, account s
 			btrx.Sources["Bittrex"] = src
 		}
 	} else {
-		btrx.Sources["Bittrex"] = source.Source{
-			Crypto:        true,
-			AccountNumber: account,
-			OpeningDate:   firstTimeUsed,
-			ClosingDate:   lastTimeUsed,
-			LegalName:     "Bittrex International GmbH",
-			Address:       "Dr. Grass-Strasse 12, 9490 Vaduz,\nPrincipality of Liechtenstein",
-			URL:           "https://global.bittrex.com",
-		}
+		btrx.Sources["Bittrex"] = source.New("Bittrex", account, firstTimeUsed, lastTimeUsed)
 	}
 	return
 }
@@ -1,6 +1,7 @@
 package bittrex
 
 import (
+	"github.com/fiscafacile/CryptoFiscaFacile/category"
 	"strings"
 	"testing"
 )
@@ -40,7 +41,7 @@ func TestBittrex_ParseCSV(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			btrx := New()
-			err := btrx.ParseCSV(strings.NewReader(tt.csv))
+			err := btrx.ParseCSV(strings.NewReader(tt.csv), *category.New(), "")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Bittrex.ParseCSV() error = %v, wantErr %v", err, tt.wantErr)
 			}
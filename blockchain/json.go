@@ -3,9 +3,9 @@ package blockchain
 import (
 	"encoding/json"
 	"io"
-	"log"
 	"time"
 
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
 	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
 	"github.com/shopspring/decimal"
 )
@@ -35,7 +35,7 @@ func (bc *BlockChain) ParseTXsJSON(reader io.Reader, currency string) (err error
 		for _, tx := range txs {
 			date, err := time.Parse("Jan 2, 2006 15:04:05 PM", tx.Date)
 			if err != nil {
-				log.Println("BlockChain JSON :", err)
+				diag.Add("BlockChain JSON :", 0, "date", tx.Date, "Error Parsing Date")
 			}
 			t := wallet.TX{Timestamp: date, Note: "BlockChain JSON : " + tx.TxID}
 			t.Items = make(map[string]wallet.Currencies)
@@ -72,39 +72,89 @@ func (txs apiTXs) SortByHeight(asc bool) {
 	}
 }
 
+// Esplora returns confirmed TXs by pages of 25, most recent first
+const chainPageSize = 25
+
+type cachedAddressTXs struct {
+	Txs apiTXs `json:"txs"`
+}
+
+func (blkst *Blockstream) get(path string, v interface{}) (err error) {
+	resp, err := resty.R().SetHeaders(map[string]string{
+		"Accept": "application/json",
+	}).Get(blkst.basePath + path)
+	if err != nil || resp.StatusCode() != http.StatusOK {
+		time.Sleep(6 * time.Second)
+		resp, err = resty.R().SetHeaders(map[string]string{
+			"Accept": "application/json",
+		}).Get(blkst.basePath + path)
+	}
+	if err != nil || resp.StatusCode() != http.StatusOK {
+		return errors.New("Error Getting " + path)
+	}
+	return json.Unmarshal(resp.Body(), v)
+}
+
 func (blkst *Blockstream) GetAddressTXs(add string) (txs apiTXs, err error) {
 	const SOURCE = "Blockstream API :"
 	useCache := true
-	db, err := scribble.New("./Cache", nil)
+	db, err := scribble.New(blkst.cacheDir, nil)
 	if err != nil {
 		useCache = false
 	}
+	var cached cachedAddressTXs
 	if useCache {
-		err = db.Read("BlockStream/address/txs", add, &txs)
-	}
-	if !useCache || err != nil {
-		resp, err := resty.R().SetHeaders(map[string]string{
-			"Accept": "application/json",
-		}).Get("https://blockstream.info/api/address/" + add + "/txs")
-		if err != nil || resp.StatusCode() != http.StatusOK {
-			time.Sleep(6 * time.Second)
-			resp, err = resty.R().SetHeaders(map[string]string{
-				"Accept": "application/json",
-			}).Get("https://blockstream.info/api/address/" + add + "/txs")
+		err = db.Read("BlockStream/"+blkst.coin+"/address/chain", add, &cached)
+		if err != nil {
+			cached = cachedAddressTXs{}
 		}
-		if err != nil || resp.StatusCode() != http.StatusOK {
-			return txs, errors.New(SOURCE + " Error Getting BTC TX for " + add)
+	}
+	known := make(map[string]bool)
+	for _, tx := range cached.Txs {
+		known[tx.Txid] = true
+	}
+	// fetch newest pages until a cached TX is met or history is exhausted
+	var fresh apiTXs
+	lastSeen := ""
+	for {
+		var page apiTXs
+		path := "address/" + add + "/txs/chain"
+		if lastSeen != "" {
+			path += "/" + lastSeen
 		}
-		err = json.Unmarshal(resp.Body(), &txs)
+		err = blkst.get(path, &page)
 		if err != nil {
-			return txs, errors.New(SOURCE + " Error Unmarshaling BTC TX for " + add)
+			return txs, errors.New(SOURCE + " Error Getting " + blkst.coin + " TX for " + add)
 		}
-		if useCache {
-			err = db.Write("BlockStream/address/txs", add, txs)
-			if err != nil {
-				return txs, errors.New(SOURCE + " Error Caching " + add)
+		reachedCache := false
+		for _, tx := range page {
+			if known[tx.Txid] {
+				reachedCache = true
+				break
+			}
+			if tx.Status.Confirmed {
+				fresh = append(fresh, tx)
 			}
 		}
+		if reachedCache || len(page) < chainPageSize {
+			break
+		}
+		lastSeen = page[len(page)-1].Txid
+	}
+	txs = append(fresh, cached.Txs...)
+	if useCache && len(fresh) > 0 {
+		err = db.Write("BlockStream/"+blkst.coin+"/address/chain", add, cachedAddressTXs{Txs: txs})
+		if err != nil {
+			return txs, errors.New(SOURCE + " Error Caching " + add)
+		}
+	}
+	// unconfirmed TXs are never cached, they will be picked up once mined
+	var mempool apiTXs
+	err = blkst.get("address/"+add+"/txs/mempool", &mempool)
+	if err != nil {
+		log.Println(SOURCE, "Error Getting mempool TX for", add)
+	} else if len(mempool) > 0 {
+		log.Println(SOURCE, add, "has", len(mempool), "unconfirmed TX ignored until mined")
 	}
 	return txs, nil
 }
@@ -121,18 +171,33 @@ func (blkst *Blockstream) GetAddressBalanceAtDate(add string, date time.Time) (b
 		}
 		for _, vin := range tx.Vin {
 			if add == vin.Prevout.ScriptpubkeyAddress {
-				bal = bal.Sub(decimal.New(int64(vin.Prevout.Value), -8))
+				bal = bal.Sub(decimal.New(int64(vin.Prevout.Value), -blkst.decimals))
 			}
 		}
 		for _, vout := range tx.Vout {
 			if add == vout.ScriptpubkeyAddress {
-				bal = bal.Add(decimal.New(int64(vout.Value), -8))
+				bal = bal.Add(decimal.New(int64(vout.Value), -blkst.decimals))
 			}
 		}
 	}
 	return
 }
 
+func (blkst *Blockstream) addAPITXs(apiTXs apiTXs) {
+	for _, tx := range apiTXs {
+		found := false
+		for _, have := range blkst.apiTXs {
+			if tx.Txid == have.Txid {
+				found = true
+				break
+			}
+		}
+		if !found {
+			blkst.apiTXs = append(blkst.apiTXs, tx)
+		}
+	}
+}
+
 func (blkst *Blockstream) GetAllTXs(b *btc.BTC, cat category.Category) {
 	const SOURCE = "Blockstream API :"
 	for _, btc := range b.Addresses {
@@ -140,18 +205,12 @@ func (blkst *Blockstream) GetAllTXs(b *btc.BTC, cat category.Category) {
 		if err != nil {
 			log.Println(err)
 		}
-		for _, tx := range apiTXs {
-			found := false
-			for _, have := range blkst.apiTXs {
-				if tx.Txid == have.Txid {
-					found = true
-					break
-				}
-			}
-			if !found {
-				blkst.apiTXs = append(blkst.apiTXs, tx)
-			}
-		}
+		blkst.addAPITXs(apiTXs)
+	}
+	err := blkst.discoverXPubs(b)
+	if err != nil {
+		blkst.done <- err
+		return
 	}
 	alreadyAsked := []string{}
 	for i, tx := range blkst.apiTXs {
@@ -192,62 +251,15 @@ func (blkst *Blockstream) GetAllTXs(b *btc.BTC, cat category.Category) {
 			if valueIn+valueOut == 0 {
 				alreadyAsked = wallet.AskForHelp(SOURCE+" zero Value TX", tx, alreadyAsked)
 			}
+			own := btc.OwnTX{Timestamp: time.Unix(int64(tx.Status.BlockTime), 0), ID: tx.Txid, Code: blkst.coin, Note: "Blockstream API : " + strconv.Itoa(tx.Status.BlockHeight)}
+			own.Fee = decimal.New(int64(tx.Fee), -blkst.decimals)
+			own.Sent = decimal.New(int64(-valueIn), -blkst.decimals)
+			own.Received = decimal.New(int64(valueOut), -blkst.decimals)
+			own.External = dest != ""
 			if isInVinPrevVout {
-				t := wallet.TX{Timestamp: time.Unix(int64(tx.Status.BlockTime), 0), ID: tx.Txid, Note: "Blockstream API : " + strconv.Itoa(tx.Status.BlockHeight) + dest + missing}
-				t.Items = make(map[string]wallet.Currencies)
-				t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: "BTC", Amount: decimal.New(int64(tx.Fee), -8)})
-				if isInVout && dest == "" {
-					t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: "BTC", Amount: decimal.New(int64(-valueIn-tx.Fee), -8)})
-					t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: "BTC", Amount: decimal.New(int64(valueOut), -8)})
-					b.TXsByCategory["Transfers"] = append(b.TXsByCategory["Transfers"], t)
-				} else if is, desc, val, curr := cat.IsTxCashOut(tx.Txid); is {
-					t.Note += " crypto_payment " + desc
-					from := wallet.Currency{Code: "BTC", Amount: decimal.New(int64(-valueOut-valueIn-tx.Fee), -8)}
-					t.Items["From"] = append(t.Items["From"], from)
-					if val.IsZero() {
-						rate, err := from.GetExchangeRate(t.Timestamp, "EUR")
-						if err == nil {
-							t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: "EUR", Amount: from.Amount.Mul(rate)})
-						}
-					} else {
-						t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: curr, Amount: val})
-					}
-					b.TXsByCategory["CashOut"] = append(b.TXsByCategory["CashOut"], t)
-				} else if is, desc, val, curr := cat.IsTxExchange(tx.Txid); is {
-					t.Note += " crypto_exchange " + desc
-					t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: "BTC", Amount: decimal.New(int64(-valueOut-valueIn-tx.Fee), -8)})
-					t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: curr, Amount: val})
-					b.TXsByCategory["Exchanges"] = append(b.TXsByCategory["Exchanges"], t)
-				} else if is, desc := cat.IsTxGift(tx.Txid); is {
-					t.Note += " gift " + desc
-					t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: "BTC", Amount: decimal.New(int64(-valueOut-valueIn-tx.Fee), -8)})
-					b.TXsByCategory["Gifts"] = append(b.TXsByCategory["Gifts"], t)
-				} else {
-					t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: "BTC", Amount: decimal.New(int64(-valueOut-valueIn-tx.Fee), -8)})
-					b.TXsByCategory["Withdrawals"] = append(b.TXsByCategory["Withdrawals"], t)
-				}
-				blkst.apiTXs[i].used = true
-			} else if isInVout {
-				t := wallet.TX{Timestamp: time.Unix(int64(tx.Status.BlockTime), 0), ID: tx.Txid, Note: "Blockstream API : " + strconv.Itoa(tx.Status.BlockHeight)}
-				t.Items = make(map[string]wallet.Currencies)
-				t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: "BTC", Amount: decimal.New(int64(tx.Fee), -8)})
-				if is, desc, val := cat.HasCustody(tx.Txid); is {
-					t.Note += " crypto_custody " + desc
-					t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: "BTC", Amount: decimal.New(int64(valueOut), -8).Sub(val)})
-				} else {
-					t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: "BTC", Amount: decimal.New(int64(valueOut), -8)})
-				}
-				if is, desc, val, curr := cat.IsTxCashIn(tx.Txid); is {
-					t.Note += " crypto_purchase " + desc
-					t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: curr, Amount: val})
-					b.TXsByCategory["CashIn"] = append(b.TXsByCategory["CashIn"], t)
-				} else if is, desc, val, curr := cat.IsTxExchange(tx.Txid); is {
-					t.Note += " crypto_exchange " + desc
-					t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: curr, Amount: val})
-					b.TXsByCategory["Exchanges"] = append(b.TXsByCategory["Exchanges"], t)
-				} else {
-					b.TXsByCategory["Deposits"] = append(b.TXsByCategory["Deposits"], t)
-				}
+				own.Note += dest + missing
+			}
+			if (isInVinPrevVout || isInVout) && b.Categorize(own, cat) {
 				blkst.apiTXs[i].used = true
 			} else {
 				alreadyAsked = wallet.AskForHelp(SOURCE, tx, alreadyAsked)
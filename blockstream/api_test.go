@@ -1,6 +1,11 @@
 package blockstream
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -54,3 +59,79 @@ func TestAPI_TXsSortByHeight(t *testing.T) {
 		})
 	}
 }
+
+func fakeEsplora(t *testing.T, chain apiTXs, mempool apiTXs, calls *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/address/"), "/")
+		var resp apiTXs
+		if len(parts) >= 3 && parts[1] == "txs" && parts[2] == "mempool" {
+			resp = mempool
+		} else if len(parts) >= 3 && parts[1] == "txs" && parts[2] == "chain" {
+			start := 0
+			if len(parts) == 4 {
+				for i, tx := range chain {
+					if tx.Txid == parts[3] {
+						start = i + 1
+					}
+				}
+			}
+			end := start + chainPageSize
+			if end > len(chain) {
+				end = len(chain)
+			}
+			resp = chain[start:end]
+		} else {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func makeChain(from, to int) (txs apiTXs) {
+	for h := to; h >= from; h-- {
+		txs = append(txs, apiTX{Txid: "tx" + strconv.Itoa(h), Status: apiTXStatus{Confirmed: true, BlockHeight: h}})
+	}
+	return
+}
+
+func TestAPI_GetAddressTXsPagination(t *testing.T) {
+	calls := 0
+	chain := makeChain(1, 60)
+	mempool := apiTXs{apiTX{Txid: "pending"}}
+	srv := fakeEsplora(t, chain, mempool, &calls)
+	defer srv.Close()
+	blkst := New()
+	blkst.basePath = srv.URL + "/"
+	blkst.cacheDir = t.TempDir()
+	txs, err := blkst.GetAddressTXs("addr")
+	if err != nil {
+		t.Fatalf("GetAddressTXs() error = %v", err)
+	}
+	if len(txs) != 60 {
+		t.Errorf("GetAddressTXs() got %d TXs, want 60", len(txs))
+	}
+	for _, tx := range txs {
+		if tx.Txid == "pending" {
+			t.Errorf("GetAddressTXs() returned unconfirmed TX")
+		}
+	}
+	if calls != 4 {
+		t.Errorf("GetAddressTXs() made %d calls, want 3 pages + mempool", calls)
+	}
+	// two new blocks : only the first page must be fetched again
+	calls = 0
+	srv.Close()
+	srv = fakeEsplora(t, makeChain(1, 62), nil, &calls)
+	blkst.basePath = srv.URL + "/"
+	txs, err = blkst.GetAddressTXs("addr")
+	if err != nil {
+		t.Fatalf("GetAddressTXs() error = %v", err)
+	}
+	if len(txs) != 62 || txs[0].Txid != "tx62" || txs[61].Txid != "tx1" {
+		t.Errorf("GetAddressTXs() got %d TXs after refresh, want 62 in order", len(txs))
+	}
+	if calls != 2 {
+		t.Errorf("GetAddressTXs() made %d calls after refresh, want 1 page + mempool", calls)
+	}
+}
@@ -1,14 +1,52 @@
 package blockstream
 
+import (
+	"errors"
+	"strings"
+)
+
+// known Esplora compatible explorers, any other can be given in config
+var esploraEndpoints = map[string]string{
+	"BTC": "https://blockstream.info/api/",
+	"LTC": "https://litecoinspace.org/api/",
+}
+
 type Blockstream struct {
-	apiTXs []apiTX
-	done   chan error
+	apiTXs   []apiTX
+	done     chan error
+	coin     string
+	decimals int32
+	basePath string
+	cacheDir string
 }
 
 func New() *Blockstream {
+	blkst, _ := NewEsplora("BTC", "", 0)
+	return blkst
+}
+
+// NewEsplora targets an Esplora API (testnet, self-hosted electrs or another UTXO chain)
+func NewEsplora(coin, basePath string, decimals int) (*Blockstream, error) {
+	const SOURCE = "Blockstream API :"
+	if basePath == "" {
+		basePath = esploraEndpoints[coin]
+	}
+	if basePath == "" {
+		return nil, errors.New(SOURCE + " No Esplora endpoint for " + coin)
+	}
+	if !strings.HasSuffix(basePath, "/") {
+		basePath += "/"
+	}
+	if decimals == 0 {
+		decimals = 8
+	}
 	blkst := &Blockstream{}
 	blkst.done = make(chan error)
-	return blkst
+	blkst.coin = coin
+	blkst.decimals = int32(decimals)
+	blkst.basePath = basePath
+	blkst.cacheDir = "./Cache"
+	return blkst, nil
 }
 
 func (blkst *Blockstream) WaitFinish() error {
@@ -0,0 +1,59 @@
+package blockstream
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/btc"
+	"github.com/fiscafacile/CryptoFiscaFacile/category"
+	"github.com/shopspring/decimal"
+)
+
+func TestNewEsplora(t *testing.T) {
+	blkst, err := NewEsplora("LTC", "", 0)
+	if err != nil || blkst.basePath != "https://litecoinspace.org/api/" || blkst.decimals != 8 {
+		t.Errorf("NewEsplora(LTC) = %v, %v", blkst, err)
+	}
+	blkst, err = NewEsplora("BTC", "http://localhost:3000", 0)
+	if err != nil || blkst.basePath != "http://localhost:3000/" {
+		t.Errorf("NewEsplora(BTC, self-hosted) = %v, %v", blkst, err)
+	}
+	_, err = NewEsplora("DOGE", "", 0)
+	if err == nil {
+		t.Errorf("NewEsplora(DOGE) without endpoint should fail")
+	}
+}
+
+func TestGetAllTXs_OtherCoin(t *testing.T) {
+	var deposit apiTX
+	err := json.Unmarshal([]byte(`{"txid":"dogetx","vout":[{"scriptpubkey_address":"DAddress","value":150000000}],"status":{"confirmed":true,"block_height":1,"block_time":1600000000}}`), &deposit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp apiTXs
+		if r.URL.Path == "/address/DAddress/txs/chain" {
+			resp = apiTXs{deposit}
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+	blkst, err := NewEsplora("DOGE", srv.URL, 8)
+	if err != nil {
+		t.Fatalf("NewEsplora() error = %v", err)
+	}
+	blkst.cacheDir = t.TempDir()
+	b := btc.New()
+	b.AddListAddresses([]string{"DAddress"})
+	go blkst.GetAllTXs(b, *category.New())
+	blkst.WaitFinish()
+	if len(b.TXsByCategory["Deposits"]) != 1 {
+		t.Fatalf("GetAllTXs() got %v, want 1 Deposit", b.TXsByCategory)
+	}
+	got := b.TXsByCategory["Deposits"][0].Items["To"][0]
+	if got.Code != "DOGE" || !got.Amount.Equal(decimal.New(15, -1)) {
+		t.Errorf("GetAllTXs() Deposit = %v %v, want 1.5 DOGE", got.Amount, got.Code)
+	}
+}
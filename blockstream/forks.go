@@ -0,0 +1,38 @@
+package blockstream
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/btc"
+	"github.com/fiscafacile/CryptoFiscaFacile/forks"
+)
+
+// DetectForks credits each address holding coins of this chain at the time of the Forks,
+// a Fork of a Fork is credited from the Parent coins known at its own time
+func (blkst *Blockstream) DetectForks(b *btc.BTC, list []forks.Fork) {
+	for _, f := range list {
+		if f.IsForkOf(blkst.coin) {
+			f.DetectFromTXs(b.TXsByCategory, "Blockstream API :")
+			continue
+		}
+		if f.Parent != blkst.coin {
+			continue
+		}
+		w := b.TXsByCategory.GetWallets(f.Time, false, false)
+		w.Println(f.Parent+" (at time of "+f.Ticker+" Fork)", f.Parent)
+		fmt.Println("Addresses :")
+		for _, a := range b.Addresses {
+			bal, err := blkst.GetAddressBalanceAtDate(a.Address, f.Time)
+			if err != nil {
+				log.Println(err)
+				break
+			}
+			if !bal.IsZero() {
+				t := f.TX(a.Address, bal, "Blockstream API :")
+				fmt.Println("  ", a.Address, "balance", t.Items["To"][0].Amount, f.Ticker)
+				b.TXsByCategory[f.Category] = append(b.TXsByCategory[f.Category], t)
+			}
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package blockstream
+
+import (
+	"errors"
+	"log"
+	"strconv"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/btc"
+)
+
+// discoverXPubs derives receive and change addresses of each XPub until
+// GapLimit consecutive addresses without history are found, an incomplete scan is an error
+func (blkst *Blockstream) discoverXPubs(b *btc.BTC) error {
+	const SOURCE = "Blockstream API :"
+	for _, x := range b.XPubs {
+		found := 0
+		for _, chain := range []uint32{0, 1} {
+			gap := 0
+			for index := uint32(0); gap < b.GapLimit; index++ {
+				add, err := x.DeriveAddress(chain, index)
+				if err != nil {
+					return errors.New(SOURCE + " Error Deriving " + x.Key + " " + err.Error())
+				}
+				apiTXs, err := blkst.GetAddressTXs(add)
+				if err != nil {
+					return err
+				}
+				if len(apiTXs) == 0 {
+					gap++
+					continue
+				}
+				gap = 0
+				found++
+				if !b.OwnAddress(add) {
+					b.Addresses = append(b.Addresses, btc.Address{Address: add, Description: x.Key[:8] + "... m/" + strconv.Itoa(int(chain)) + "/" + strconv.Itoa(int(index))})
+				}
+				blkst.addAPITXs(apiTXs)
+			}
+		}
+		log.Println(SOURCE, "found", found, "used addresses for", x.Key)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package blockstream
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/btc"
+	"github.com/fiscafacile/CryptoFiscaFacile/category"
+)
+
+func TestDiscoverXPubs(t *testing.T) {
+	history := map[string]apiTXs{
+		"bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu": {apiTX{Txid: "tx1", Status: apiTXStatus{Confirmed: true}}},
+		"bc1qnjg0jd8228aq7egyzacy8cys3knf9xvrerkf9g": {apiTX{Txid: "tx2", Status: apiTXStatus{Confirmed: true}}},
+	}
+	scanned := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/address/"), "/")
+		var resp apiTXs
+		if parts[2] == "chain" {
+			scanned++
+			resp = history[parts[0]]
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+	blkst := New()
+	blkst.basePath = srv.URL + "/"
+	blkst.cacheDir = t.TempDir()
+	b := btc.New()
+	b.GapLimit = 2
+	err := b.AddListXPubs([]string{"zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs"})
+	if err != nil {
+		t.Fatalf("AddListXPubs() error = %v", err)
+	}
+	err = blkst.discoverXPubs(b)
+	if err != nil {
+		t.Fatalf("discoverXPubs() error = %v", err)
+	}
+	if len(b.Addresses) != 2 || !b.OwnAddress("bc1qnjg0jd8228aq7egyzacy8cys3knf9xvrerkf9g") {
+		t.Errorf("discoverXPubs() addresses = %v, want the 2 used ones", b.Addresses)
+	}
+	if len(blkst.apiTXs) != 2 {
+		t.Errorf("discoverXPubs() got %d TXs, want 2", len(blkst.apiTXs))
+	}
+	// 2 used + 2 gap on receive chain, 2 gap on change chain
+	if scanned != 6 {
+		t.Errorf("discoverXPubs() scanned %d addresses, want 6", scanned)
+	}
+}
+
+func TestGetAllTXs_XPubError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("not json"))
+	}))
+	defer srv.Close()
+	blkst := New()
+	blkst.basePath = srv.URL + "/"
+	blkst.cacheDir = t.TempDir()
+	b := btc.New()
+	err := b.AddListXPubs([]string{"zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs"})
+	if err != nil {
+		t.Fatalf("AddListXPubs() error = %v", err)
+	}
+	go blkst.GetAllTXs(b, *category.New())
+	if err := blkst.WaitFinish(); err == nil {
+		t.Errorf("WaitFinish() should report the failed XPub scan")
+	}
+}
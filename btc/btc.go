@@ -11,12 +11,15 @@ type Address struct {
 
 type BTC struct {
 	Addresses     []Address
+	XPubs         []XPub
+	GapLimit      int
 	TXsByCategory wallet.TXsByCategory
 }
 
 func New() *BTC {
 	btc := &BTC{}
 	btc.TXsByCategory = make(map[string]wallet.TXs)
+	btc.GapLimit = 20
 	return btc
 }
 
@@ -26,6 +29,17 @@ func (btc *BTC) AddListAddresses(list []string) {
 	}
 }
 
+func (btc *BTC) AddListXPubs(list []string) error {
+	for _, key := range list {
+		x, err := ParseXPub(key)
+		if err != nil {
+			return err
+		}
+		btc.XPubs = append(btc.XPubs, x)
+	}
+	return nil
+}
+
 func (btc BTC) OwnAddress(add string) bool {
 	for _, a := range btc.Addresses {
 		if a.Address == add {
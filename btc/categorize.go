@@ -0,0 +1,88 @@
+package btc
+
+import (
+	"time"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/category"
+	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
+	"github.com/shopspring/decimal"
+)
+
+// OwnTX is a TX seen from the wallet : Sent is the total of own inputs (Fee
+// included) and Received the total of own outputs
+type OwnTX struct {
+	Timestamp time.Time
+	ID        string
+	Code      string
+	Note      string
+	Sent      decimal.Decimal
+	Received  decimal.Decimal
+	Fee       decimal.Decimal
+	External  bool // at least one output goes to a foreign address
+}
+
+// Categorize fills TXsByCategory, returns false when the TX does not concern the wallet
+func (btc *BTC) Categorize(tx OwnTX, cat category.Category) bool {
+	t := wallet.TX{Timestamp: tx.Timestamp, ID: tx.ID, Note: tx.Note}
+	t.Items = make(map[string]wallet.Currencies)
+	if !tx.Sent.IsZero() {
+		if !tx.Fee.IsZero() {
+			t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: tx.Code, Amount: tx.Fee})
+		}
+		spent := tx.Sent.Sub(tx.Received).Sub(tx.Fee)
+		if !tx.External {
+			t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: tx.Code, Amount: tx.Sent.Sub(tx.Fee)})
+			t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: tx.Code, Amount: tx.Received})
+			btc.TXsByCategory["Transfers"] = append(btc.TXsByCategory["Transfers"], t)
+		} else if is, desc, val, curr := cat.IsTxCashOut(tx.ID); is {
+			t.Note += " crypto_payment " + desc
+			from := wallet.Currency{Code: tx.Code, Amount: spent}
+			t.Items["From"] = append(t.Items["From"], from)
+			if val.IsZero() {
+				rate, err := from.GetExchangeRate(t.Timestamp, "EUR")
+				if err == nil {
+					t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: "EUR", Amount: from.Amount.Mul(rate)})
+				}
+			} else {
+				t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: curr, Amount: val})
+			}
+			btc.TXsByCategory["CashOut"] = append(btc.TXsByCategory["CashOut"], t)
+		} else if is, desc, val, curr := cat.IsTxExchange(tx.ID); is {
+			t.Note += " crypto_exchange " + desc
+			t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: tx.Code, Amount: spent})
+			t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: curr, Amount: val})
+			btc.TXsByCategory["Exchanges"] = append(btc.TXsByCategory["Exchanges"], t)
+		} else if is, desc := cat.IsTxGift(tx.ID); is {
+			t.Note += " gift " + desc
+			t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: tx.Code, Amount: spent})
+			btc.TXsByCategory["Gifts"] = append(btc.TXsByCategory["Gifts"], t)
+		} else {
+			t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: tx.Code, Amount: spent})
+			btc.TXsByCategory["Withdrawals"] = append(btc.TXsByCategory["Withdrawals"], t)
+		}
+		return true
+	} else if !tx.Received.IsZero() {
+		if !tx.Fee.IsZero() {
+			t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: tx.Code, Amount: tx.Fee})
+		}
+		if is, desc, val := cat.HasCustody(tx.ID); is {
+			t.Note += " crypto_custody " + desc
+			t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: tx.Code, Amount: tx.Received.Sub(val)})
+		} else {
+			t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: tx.Code, Amount: tx.Received})
+		}
+		if is, desc, val, curr := cat.IsTxCashIn(tx.ID); is {
+			t.Note += " crypto_purchase " + desc
+			t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: curr, Amount: val})
+			btc.TXsByCategory["CashIn"] = append(btc.TXsByCategory["CashIn"], t)
+		} else if is, desc, val, curr := cat.IsTxExchange(tx.ID); is {
+			t.Note += " crypto_exchange " + desc
+			t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: curr, Amount: val})
+			btc.TXsByCategory["Exchanges"] = append(btc.TXsByCategory["Exchanges"], t)
+		} else {
+			btc.TXsByCategory["Deposits"] = append(btc.TXsByCategory["Deposits"], t)
+		}
+		return true
+	}
+	return false
+}
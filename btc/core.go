@@ -0,0 +1,187 @@
+package btc
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/category"
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
+	"github.com/fiscafacile/CryptoFiscaFacile/utils"
+	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
+	"github.com/shopspring/decimal"
+)
+
+// output of bitcoin-cli listtransactions "*" 100000 0 true
+type coreTX struct {
+	Address       string          `json:"address"`
+	Category      string          `json:"category"`
+	Amount        decimal.Decimal `json:"amount"`
+	Fee           decimal.Decimal `json:"fee"`
+	Confirmations int             `json:"confirmations"`
+	Blocktime     int64           `json:"blocktime"`
+	Time          int64           `json:"time"`
+	Txid          string          `json:"txid"`
+	Label         string          `json:"label"`
+	Abandoned     bool            `json:"abandoned"`
+}
+
+var CSVCoreFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"Confirmed", "Date", "Type", "Label", "Address", "Amount (BTC)", "ID"}},
+}
+
+// the GUI export suffixes the txid with the output index when a TX has several lines
+var coreIDSuffix = regexp.MustCompile(`-[0-9]+$`)
+
+type walletLines struct {
+	ids   []string
+	lines map[string][]walletLine
+}
+
+type walletLine struct {
+	timestamp time.Time
+	kind      string // send, receive or mined
+	amount    decimal.Decimal
+	fee       decimal.Decimal
+	label     string
+}
+
+func (wl *walletLines) add(id string, l walletLine) {
+	if wl.lines == nil {
+		wl.lines = make(map[string][]walletLine)
+	}
+	if _, ok := wl.lines[id]; !ok {
+		wl.ids = append(wl.ids, id)
+	}
+	wl.lines[id] = append(wl.lines[id], l)
+}
+
+// categorize merges the lines of each TX, amounts are positive
+func (wl walletLines) categorize(btc *BTC, src string, cat category.Category) {
+	alreadyAsked := []string{}
+	for _, id := range wl.ids {
+		own := OwnTX{ID: id, Code: "BTC", Note: src}
+		var mined decimal.Decimal
+		labels := []string{}
+		for _, l := range wl.lines[id] {
+			own.Timestamp = l.timestamp
+			if l.label != "" {
+				labels = append(labels, l.label)
+			}
+			switch l.kind {
+			case "send":
+				own.Sent = own.Sent.Add(l.amount)
+				if l.fee.GreaterThan(own.Fee) {
+					own.Fee = l.fee
+				}
+			case "receive":
+				own.Received = own.Received.Add(l.amount)
+			case "mined":
+				mined = mined.Add(l.amount)
+			}
+		}
+		if len(labels) > 0 {
+			own.Note += " " + strings.Join(labels, " ")
+		}
+		if !mined.IsZero() {
+			t := wallet.TX{Timestamp: own.Timestamp, ID: id, Note: own.Note}
+			t.Items = make(map[string]wallet.Currencies)
+			t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: "BTC", Amount: mined})
+			btc.TXsByCategory["Minings"] = append(btc.TXsByCategory["Minings"], t)
+			continue
+		}
+		// sends are given without fee, a send to an own address comes back as a receive
+		own.External = own.Sent.GreaterThan(own.Received)
+		if !own.Sent.IsZero() || !own.Fee.IsZero() {
+			own.Sent = own.Sent.Add(own.Fee)
+		}
+		if !btc.Categorize(own, cat) {
+			alreadyAsked = wallet.AskForHelp(src, wl.lines[id], alreadyAsked)
+		}
+	}
+}
+
+func (btc *BTC) ParseCoreJSON(reader io.Reader, cat category.Category) (err error) {
+	const SOURCE = "Bitcoin Core JSON :"
+	var txs []coreTX
+	err = json.NewDecoder(reader).Decode(&txs)
+	if err != nil {
+		return
+	}
+	var wl walletLines
+	for _, tx := range txs {
+		// unconfirmed, conflicted or abandoned TXs never happened on chain
+		if tx.Confirmations <= 0 || tx.Abandoned {
+			continue
+		}
+		l := walletLine{timestamp: time.Unix(tx.Time, 0), amount: tx.Amount.Abs(), fee: tx.Fee.Abs(), label: tx.Label}
+		if tx.Blocktime != 0 {
+			l.timestamp = time.Unix(tx.Blocktime, 0)
+		}
+		switch tx.Category {
+		case "send":
+			l.kind = "send"
+		case "receive":
+			l.kind = "receive"
+		case "generate", "immature":
+			l.kind = "mined"
+		default:
+			continue
+		}
+		wl.add(tx.Txid, l)
+	}
+	wl.categorize(btc, SOURCE, cat)
+	return
+}
+
+func (btc *BTC) ParseCoreCSV(reader io.Reader, cat category.Category) (err error) {
+	const SOURCE = "Bitcoin Core CSV :"
+	csvReader := csv.NewReader(reader)
+	records, err := csvReader.ReadAll()
+	if err == nil {
+		var wl walletLines
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVCoreFormats...)
+				if err != nil {
+					return
+				}
+			} else if !l.IsHeader(r) && l.Get(r, "Confirmed") == "true" {
+				var err error // problems are reported through diag
+				line := walletLine{label: l.Get(r, "Label")}
+				line.timestamp, err = time.Parse("2006-01-02T15:04:05", l.Get(r, "Date"))
+				if err != nil {
+					diag.Add(SOURCE, n+1, "Date", l.Get(r, "Date"), "Error Parsing Timestamp")
+				}
+				amount, err := decimal.NewFromString(l.Get(r, "Amount (BTC)"))
+				if err != nil {
+					diag.Add(SOURCE, n+1, "Amount (BTC)", l.Get(r, "Amount (BTC)"), "Error Parsing Amount")
+				}
+				line.amount = amount.Abs()
+				switch l.Get(r, "Type") {
+				case "Mined":
+					line.kind = "mined"
+				case "Payment to yourself":
+					// only the fee is given
+					line.kind = "send"
+					line.fee = line.amount
+					line.amount = decimal.Zero
+				default:
+					// the fee is included in the amount of sends
+					if amount.IsNegative() {
+						line.kind = "send"
+					} else {
+						line.kind = "receive"
+					}
+				}
+				wl.add(coreIDSuffix.ReplaceAllString(l.Get(r, "ID"), ""), line)
+			}
+		}
+		wl.categorize(btc, SOURCE, cat)
+	}
+	return
+}
@@ -0,0 +1,76 @@
+package btc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/category"
+	"github.com/shopspring/decimal"
+)
+
+func TestBTC_ParseCoreJSON(t *testing.T) {
+	json := `[
+{"address":"bc1qin","category":"receive","amount":0.5,"confirmations":10,"blocktime":1600000000,"time":1599999000,"txid":"aaa","label":"salaire"},
+{"address":"bc1qout","category":"send","amount":-0.2,"fee":-0.0001,"confirmations":9,"blocktime":1600001000,"txid":"bbb"},
+{"address":"bc1qself","category":"send","amount":-0.1,"fee":-0.0002,"confirmations":8,"blocktime":1600002000,"txid":"ccc"},
+{"address":"bc1qself","category":"receive","amount":0.1,"confirmations":8,"blocktime":1600002000,"txid":"ccc"},
+{"address":"bc1qpending","category":"receive","amount":1,"confirmations":0,"time":1600003000,"txid":"ddd"},
+{"address":"bc1qout","category":"send","amount":-1,"fee":-0.0001,"confirmations":-3,"time":1600004000,"txid":"eee","abandoned":true},
+{"address":"bc1qmine","category":"generate","amount":6.25,"confirmations":200,"blocktime":1600005000,"txid":"fff"}
+]`
+	btc := New()
+	err := btc.ParseCoreJSON(strings.NewReader(json), *category.New())
+	if err != nil {
+		t.Fatalf("ParseCoreJSON() error = %v", err)
+	}
+	checkCategory(t, btc, "Deposits", "aaa", "To", "0.5")
+	checkCategory(t, btc, "Withdrawals", "bbb", "From", "0.2")
+	checkCategory(t, btc, "Withdrawals", "bbb", "Fee", "0.0001")
+	checkCategory(t, btc, "Transfers", "ccc", "To", "0.1")
+	checkCategory(t, btc, "Transfers", "ccc", "Fee", "0.0002")
+	checkCategory(t, btc, "Minings", "fff", "To", "6.25")
+	if d := btc.TXsByCategory["Deposits"]; len(d) == 1 && len(d[0].Items["Fee"]) != 0 {
+		t.Errorf("ParseCoreJSON() Deposits aaa Fee = %v, want none", d[0].Items["Fee"])
+	}
+	total := 0
+	for _, txs := range btc.TXsByCategory {
+		total += len(txs)
+	}
+	if total != 4 {
+		t.Errorf("ParseCoreJSON() got %d TXs, want 4 (unconfirmed and abandoned ignored)", total)
+	}
+}
+
+func TestBTC_ParseCoreCSV(t *testing.T) {
+	csv := `"Confirmed","Date","Type","Label","Address","Amount (BTC)","ID"
+"true","2021-03-04T12:34:56","Received with","","bc1qin","0.50000000","aaa"
+"true","2021-03-05T12:00:00","Sent to","","bc1qout","-0.20010000","bbb-000"
+"true","2021-03-05T12:00:00","Sent to","","bc1qout2","-0.10000000","bbb-001"
+"true","2021-03-06T12:00:00","Payment to yourself","","","-0.00020000","ccc"
+"false","2021-03-07T12:00:00","Received with","","bc1qin","1.00000000","ddd"
+`
+	btc := New()
+	err := btc.ParseCoreCSV(strings.NewReader(csv), *category.New())
+	if err != nil {
+		t.Fatalf("ParseCoreCSV() error = %v", err)
+	}
+	checkCategory(t, btc, "Deposits", "aaa", "To", "0.5")
+	checkCategory(t, btc, "Withdrawals", "bbb", "From", "0.3001")
+	checkCategory(t, btc, "Transfers", "ccc", "Fee", "0.0002")
+	if len(btc.TXsByCategory["Deposits"]) != 1 {
+		t.Errorf("ParseCoreCSV() should ignore unconfirmed TXs")
+	}
+}
+
+func checkCategory(t *testing.T, btc *BTC, categ, id, item, want string) {
+	t.Helper()
+	for _, tx := range btc.TXsByCategory[categ] {
+		if tx.ID == id {
+			if len(tx.Items[item]) != 1 || !tx.Items[item][0].Amount.Equal(decimal.RequireFromString(want)) {
+				t.Errorf("%s %s %s = %v, want %s", categ, id, item, tx.Items[item], want)
+			}
+			return
+		}
+	}
+	t.Errorf("%s not found in %s", id, categ)
+}
@@ -3,17 +3,31 @@ package btc
 import (
 	"encoding/csv"
 	"io"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/utils"
 )
 
+var CSVFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"Address", "Description"}, Positional: true},
+}
+
 func (btc *BTC) ParseCSVAddresses(reader io.Reader) (err error) {
+	const SOURCE = "BTC Addresses CSV :"
 	csvReader := csv.NewReader(reader)
 	records, err := csvReader.ReadAll()
 	if err == nil {
-		for _, r := range records {
-			if r[0] != "Address" {
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVFormats...)
+				if err != nil {
+					return
+				}
+			}
+			if !l.IsHeader(r) {
 				a := Address{}
-				a.Address = r[0]
-				a.Description = r[1]
+				a.Address = l.Get(r, "Address")
+				a.Description = l.Get(r, "Description")
 				btc.Addresses = append(btc.Addresses, a)
 			}
 		}
@@ -0,0 +1,68 @@
+package btc
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/category"
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
+	"github.com/fiscafacile/CryptoFiscaFacile/utils"
+	"github.com/shopspring/decimal"
+)
+
+var CSVElectrumFormats = []utils.CSVFormat{
+	{Version: "3", Columns: []string{"transaction_hash", "label", "confirmations", "value", "timestamp"}},
+	{Version: "4", Columns: []string{"transaction_hash", "label", "confirmations", "value", "fiat_value", "fee", "fiat_fee", "timestamp"}},
+}
+
+func (btc *BTC) ParseElectrumCSV(reader io.Reader, cat category.Category) (err error) {
+	const SOURCE = "Electrum CSV :"
+	csvReader := csv.NewReader(reader)
+	records, err := csvReader.ReadAll()
+	if err == nil {
+		var wl walletLines
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVElectrumFormats...)
+				if err != nil {
+					return
+				}
+			} else if !l.IsHeader(r) && l.Get(r, "confirmations") != "0" {
+				var err error // problems are reported through diag
+				line := walletLine{label: l.Get(r, "label")}
+				line.timestamp, err = time.Parse("2006-01-02 15:04:05", l.Get(r, "timestamp"))
+				if err != nil {
+					line.timestamp, err = time.Parse("2006-01-02 15:04", l.Get(r, "timestamp"))
+					if err != nil {
+						diag.Add(SOURCE, n+1, "timestamp", l.Get(r, "timestamp"), "Error Parsing Timestamp")
+					}
+				}
+				// value is the change of the wallet balance, fee included
+				value, err := decimal.NewFromString(strings.TrimPrefix(l.Get(r, "value"), "+"))
+				if err != nil {
+					diag.Add(SOURCE, n+1, "value", l.Get(r, "value"), "Error Parsing Value")
+				}
+				if l.Get(r, "fee") != "" {
+					line.fee, err = decimal.NewFromString(l.Get(r, "fee"))
+					if err != nil {
+						diag.Add(SOURCE, n+1, "fee", l.Get(r, "fee"), "Error Parsing Fee")
+					}
+				}
+				if value.IsNegative() {
+					line.kind = "send"
+					line.amount = value.Neg().Sub(line.fee)
+				} else {
+					line.kind = "receive"
+					line.amount = value
+					line.fee = decimal.Zero
+				}
+				wl.add(l.Get(r, "transaction_hash"), line)
+			}
+		}
+		wl.categorize(btc, SOURCE, cat)
+	}
+	return
+}
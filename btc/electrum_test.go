@@ -0,0 +1,37 @@
+package btc
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/category"
+)
+
+func TestBTC_ParseElectrumCSV(t *testing.T) {
+	csv := `transaction_hash,label,confirmations,value,fiat_value,fee,fiat_fee,timestamp
+aaa,achat,120,0.5,,,,2021-03-04 12:34:56
+bbb,,100,-0.2001,,0.0001,,2021-03-05 12:00:00
+ccc,,90,-0.0002,,0.0002,,2021-03-06 12:00:00
+ddd,,0,1.0,,,,2021-03-07 12:00:00
+eee,,80,0.1,,,,2021-03-08 12:00:00
+`
+	cat := category.New()
+	cat.ParseCSVCategory(strings.NewReader("TxID,Type,Description,Value,Currency\neee,IN,achat P2P,3000,EUR\n"))
+	btc := New()
+	err := btc.ParseElectrumCSV(strings.NewReader(csv), *cat)
+	if err != nil {
+		t.Fatalf("ParseElectrumCSV() error = %v", err)
+	}
+	checkCategory(t, btc, "Deposits", "aaa", "To", "0.5")
+	checkCategory(t, btc, "Withdrawals", "bbb", "From", "0.2")
+	checkCategory(t, btc, "Withdrawals", "bbb", "Fee", "0.0001")
+	checkCategory(t, btc, "Transfers", "ccc", "Fee", "0.0002")
+	checkCategory(t, btc, "CashIn", "eee", "From", "3000")
+	if len(btc.TXsByCategory["Deposits"]) != 1 {
+		t.Errorf("ParseElectrumCSV() should ignore unconfirmed TXs")
+	}
+	if got := btc.TXsByCategory["Deposits"][0].Timestamp; !got.Equal(time.Date(2021, time.March, 4, 12, 34, 56, 0, time.UTC)) {
+		t.Errorf("ParseElectrumCSV() Timestamp = %v", got)
+	}
+}
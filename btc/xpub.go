@@ -0,0 +1,115 @@
+package btc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+
+	"github.com/anaskhan96/base58check"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"golang.org/x/crypto/ripemd160"
+)
+
+type xpubVersion struct {
+	script string // p2pkh, p2sh-p2wpkh or p2wpkh
+	net    *chaincfg.Params
+}
+
+// SLIP-0132 version bytes : xpub for BIP44, ypub for BIP49, zpub for BIP84
+var xpubVersions = map[string]xpubVersion{
+	"0488b21e": {script: "p2pkh", net: &chaincfg.MainNetParams},
+	"049d7cb2": {script: "p2sh-p2wpkh", net: &chaincfg.MainNetParams},
+	"04b24746": {script: "p2wpkh", net: &chaincfg.MainNetParams},
+	"043587cf": {script: "p2pkh", net: &chaincfg.TestNet3Params},
+	"044a5262": {script: "p2sh-p2wpkh", net: &chaincfg.TestNet3Params},
+	"045f1cf6": {script: "p2wpkh", net: &chaincfg.TestNet3Params},
+}
+
+type XPub struct {
+	Key     string
+	version xpubVersion
+	ext     *hdkeychain.ExtendedKey
+}
+
+func ParseXPub(key string) (x XPub, err error) {
+	const SOURCE = "BTC XPub :"
+	decoded, err := base58check.Decode(key)
+	if err != nil {
+		return x, errors.New(SOURCE + " Error Decoding " + key)
+	}
+	raw, err := hex.DecodeString(decoded)
+	if err != nil || len(raw) != 78 {
+		return x, errors.New(SOURCE + " Invalid Length " + key)
+	}
+	var ok bool
+	x.version, ok = xpubVersions[hex.EncodeToString(raw[:4])]
+	if !ok {
+		return x, errors.New(SOURCE + " Unknown Version " + key)
+	}
+	x.Key = key
+	x.ext, err = hdkeychain.NewKeyFromString(key)
+	if err != nil {
+		return x, errors.New(SOURCE + " " + err.Error() + " " + key)
+	}
+	if _, err = x.ext.ECPubKey(); err != nil {
+		return x, errors.New(SOURCE + " " + err.Error() + " " + key)
+	}
+	return x, nil
+}
+
+// Child derives the non hardened child public key (BIP32 CKDpub)
+func (x XPub) Child(index uint32) (child XPub, err error) {
+	if index >= hdkeychain.HardenedKeyStart {
+		return child, errors.New("Cannot derive hardened child from a public key")
+	}
+	ext, err := x.ext.Child(index)
+	if err != nil {
+		return
+	}
+	return XPub{Key: x.Key, version: x.version, ext: ext}, nil
+}
+
+func hash160(data []byte) []byte {
+	sha := sha256.Sum256(data)
+	h := ripemd160.New()
+	h.Write(sha[:])
+	return h.Sum(nil)
+}
+
+// Address encodes the public key with the script type of the extended key
+func (x XPub) Address() (string, error) {
+	pub, err := x.ext.ECPubKey()
+	if err != nil {
+		return "", err
+	}
+	h := hash160(pub.SerializeCompressed())
+	var add btcutil.Address
+	switch x.version.script {
+	case "p2pkh":
+		add, err = btcutil.NewAddressPubKeyHash(h, x.version.net)
+	case "p2sh-p2wpkh":
+		script := append([]byte{0x00, 0x14}, h...)
+		add, err = btcutil.NewAddressScriptHash(script, x.version.net)
+	default:
+		add, err = btcutil.NewAddressWitnessPubKeyHash(h, x.version.net)
+	}
+	if err != nil {
+		return "", err
+	}
+	return add.EncodeAddress(), nil
+}
+
+// DeriveAddress returns the address at chain (0 receive, 1 change) and index
+func (x XPub) DeriveAddress(chain, index uint32) (string, error) {
+	c, err := x.Child(chain)
+	if err != nil {
+		return "", err
+	}
+	c, err = c.Child(index)
+	if err != nil {
+		return "", err
+	}
+	return c.Address()
+}
@@ -0,0 +1,76 @@
+package btc
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestHash160(t *testing.T) {
+	// compressed public key of the secp256k1 generator point
+	pub, _ := hex.DecodeString("0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798")
+	if got := hex.EncodeToString(hash160(pub)); got != "751e76e8199196d454941c45d1b3a323f1433bd6" {
+		t.Errorf("hash160() = %s, want 751e76e8199196d454941c45d1b3a323f1433bd6", got)
+	}
+}
+
+func TestXPub_DeriveAddress(t *testing.T) {
+	// "abandon abandon ... about" test mnemonic, account 0 of BIP44, BIP49 and BIP84
+	tests := []struct {
+		name  string
+		xpub  string
+		chain uint32
+		index uint32
+		want  string
+	}{
+		{
+			name: "BIP44 first receive",
+			xpub: "xpub6BosfCnifzxcFwrSzQiqu2DBVTshkCXacvNsWGYJVVhhawA7d4R5WSWGFNbi8Aw6ZRc1brxMyWMzG3DSSSSoekkudhUd9yLb6qx39T9nMdj",
+			want: "1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA",
+		},
+		{
+			name: "BIP49 first receive",
+			xpub: "ypub6Ww3ibxVfGzLrAH1PNcjyAWenMTbbAosGNB6VvmSEgytSER9azLDWCxoJwW7Ke7icmizBMXrzBx9979FfaHxHcrArf3zbeJJJUZPf663zsP",
+			want: "37VucYSaXLCAsxYyAPfbSi9eh4iEcbShgf",
+		},
+		{
+			name: "BIP84 first receive",
+			xpub: "zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs",
+			want: "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu",
+		},
+		{
+			name:  "BIP84 second receive",
+			xpub:  "zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs",
+			index: 1,
+			want:  "bc1qnjg0jd8228aq7egyzacy8cys3knf9xvrerkf9g",
+		},
+		{
+			name:  "BIP84 first change",
+			xpub:  "zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs",
+			chain: 1,
+			want:  "bc1q8c6fshw2dlwun7ekn9qwf37cu2rn755upcp6el",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, err := ParseXPub(tt.xpub)
+			if err != nil {
+				t.Fatalf("ParseXPub() error = %v", err)
+			}
+			got, err := x.DeriveAddress(tt.chain, tt.index)
+			if err != nil {
+				t.Fatalf("DeriveAddress() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DeriveAddress() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseXPub_Invalid(t *testing.T) {
+	for _, key := range []string{"", "notAnXPub", "1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA"} {
+		if _, err := ParseXPub(key); err == nil {
+			t.Errorf("ParseXPub(%q) should fail", key)
+		}
+	}
+}
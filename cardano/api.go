@@ -0,0 +1,375 @@
+package cardano
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/category"
+	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
+	"github.com/go-resty/resty/v2"
+	"github.com/nanobox-io/golang-scribble"
+	"github.com/shopspring/decimal"
+)
+
+const pageSize = 100
+
+type api struct {
+	client         *resty.Client
+	basePath       string
+	projectID      string
+	cacheDir       string
+	timeBetweenReq time.Duration
+	txsByCategory  wallet.TXsByCategory
+}
+
+// NewAPI uses Blockfrost, or any compatible server given by basePath
+func (ada *Cardano) NewAPI(basePath, projectID string, debug bool) {
+	ada.api.txsByCategory = make(map[string]wallet.TXs)
+	ada.api.client = resty.New()
+	ada.api.client.SetRetryCount(3).SetRetryWaitTime(1 * time.Second)
+	ada.api.client.SetDebug(debug)
+	if basePath == "" {
+		basePath = "https://cardano-mainnet.blockfrost.io/api/v0"
+	}
+	ada.api.basePath = strings.TrimSuffix(basePath, "/")
+	ada.api.projectID = projectID
+	ada.api.cacheDir = "./Cache"
+	ada.api.timeBetweenReq = 100 * time.Millisecond
+}
+
+type amount struct {
+	Unit     string `json:"unit"`
+	Quantity string `json:"quantity"`
+}
+
+type utxo struct {
+	Address    string   `json:"address"`
+	Amount     []amount `json:"amount"`
+	Collateral bool     `json:"collateral"`
+}
+
+func (u utxo) lovelace() (l decimal.Decimal) {
+	for _, a := range u.Amount {
+		if a.Unit == "lovelace" {
+			q, err := decimal.NewFromString(a.Quantity)
+			if err == nil {
+				l = l.Add(q)
+			}
+		}
+	}
+	return
+}
+
+type txUTXOs struct {
+	Hash    string `json:"hash"`
+	Inputs  []utxo `json:"inputs"`
+	Outputs []utxo `json:"outputs"`
+}
+
+type txDetails struct {
+	Hash            string `json:"hash"`
+	BlockHeight     int    `json:"block_height"`
+	BlockTime       int64  `json:"block_time"`
+	Fees            string `json:"fees"`
+	Deposit         string `json:"deposit"`
+	WithdrawalCount int    `json:"withdrawal_count"`
+}
+
+type addressTX struct {
+	TxHash      string `json:"tx_hash"`
+	TxIndex     int    `json:"tx_index"`
+	BlockHeight int    `json:"block_height"`
+	BlockTime   int64  `json:"block_time"`
+}
+
+type withdrawal struct {
+	Address string `json:"address"`
+	Amount  string `json:"amount"`
+}
+
+type reward struct {
+	Epoch  int    `json:"epoch"`
+	Amount string `json:"amount"`
+	PoolID string `json:"pool_id"`
+	Type   string `json:"type"`
+}
+
+type epoch struct {
+	Epoch     int   `json:"epoch"`
+	StartTime int64 `json:"start_time"`
+	EndTime   int64 `json:"end_time"`
+}
+
+var errNotFound = errors.New("Not Found")
+
+func (api *api) get(path string, params map[string]string, result interface{}) error {
+	resp, err := api.client.R().
+		SetQueryParams(params).
+		SetHeader("Accept", "application/json").
+		SetHeader("project_id", api.projectID).
+		SetResult(result).
+		Get(api.basePath + path)
+	time.Sleep(api.timeBetweenReq)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() == http.StatusNotFound {
+		return errNotFound
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return errors.New(resp.Status())
+	}
+	return nil
+}
+
+// getCached is used for data that cannot change anymore once on chain
+func (api *api) getCached(collection, key, path string, result interface{}) error {
+	useCache := true
+	db, err := scribble.New(api.cacheDir, nil)
+	if err != nil {
+		useCache = false
+	}
+	if useCache && db.Read(collection, key, result) == nil {
+		return nil
+	}
+	err = api.get(path, nil, result)
+	if err != nil {
+		return err
+	}
+	if useCache {
+		return db.Write(collection, key, result)
+	}
+	return nil
+}
+
+func (api *api) getStakeAddresses(stake string) (addresses []string, err error) {
+	const SOURCE = "Blockfrost API Account Addresses :"
+	for page := 1; ; page++ {
+		var list []struct {
+			Address string `json:"address"`
+		}
+		err = api.get("/accounts/"+stake+"/addresses", map[string]string{"page": strconv.Itoa(page), "count": strconv.Itoa(pageSize)}, &list)
+		if err == errNotFound {
+			return addresses, nil
+		}
+		if err != nil {
+			return addresses, errors.New(SOURCE + " Error Requesting " + stake)
+		}
+		for _, a := range list {
+			addresses = append(addresses, a.Address)
+		}
+		if len(list) < pageSize {
+			return addresses, nil
+		}
+	}
+}
+
+func (api *api) getAddressTXs(address string) (txs []addressTX, err error) {
+	const SOURCE = "Blockfrost API Address Transactions :"
+	for page := 1; ; page++ {
+		var list []addressTX
+		err = api.get("/addresses/"+address+"/transactions", map[string]string{"page": strconv.Itoa(page), "count": strconv.Itoa(pageSize), "order": "asc"}, &list)
+		if err == errNotFound {
+			return txs, nil // never used
+		}
+		if err != nil {
+			return txs, errors.New(SOURCE + " Error Requesting " + address)
+		}
+		txs = append(txs, list...)
+		if len(list) < pageSize {
+			return txs, nil
+		}
+	}
+}
+
+func (api *api) getRewards(stake string) (rewards []reward, err error) {
+	const SOURCE = "Blockfrost API Account Rewards :"
+	for page := 1; ; page++ {
+		var list []reward
+		err = api.get("/accounts/"+stake+"/rewards", map[string]string{"page": strconv.Itoa(page), "count": strconv.Itoa(pageSize)}, &list)
+		if err == errNotFound {
+			return rewards, nil
+		}
+		if err != nil {
+			return rewards, errors.New(SOURCE + " Error Requesting " + stake)
+		}
+		rewards = append(rewards, list...)
+		if len(list) < pageSize {
+			return rewards, nil
+		}
+	}
+}
+
+func (api *api) getAllTXs(addresses, stakes []string, cat category.Category) (err error) {
+	const SOURCE = "Blockfrost API :"
+	own := make(map[string]bool)
+	for _, a := range addresses {
+		own[a] = true
+	}
+	ownStake := make(map[string]bool)
+	for _, s := range stakes {
+		ownStake[s] = true
+		list, err := api.getStakeAddresses(s)
+		if err != nil {
+			return err
+		}
+		for _, a := range list {
+			own[a] = true
+		}
+	}
+	var txs []addressTX
+	seen := make(map[string]bool)
+	for a := range own {
+		list, err := api.getAddressTXs(a)
+		if err != nil {
+			return err
+		}
+		for _, tx := range list {
+			if !seen[tx.TxHash] {
+				seen[tx.TxHash] = true
+				txs = append(txs, tx)
+			}
+		}
+	}
+	sort.SliceStable(txs, func(i, j int) bool {
+		if txs[i].BlockTime != txs[j].BlockTime {
+			return txs[i].BlockTime < txs[j].BlockTime
+		}
+		if txs[i].BlockHeight != txs[j].BlockHeight {
+			return txs[i].BlockHeight < txs[j].BlockHeight
+		}
+		return txs[i].TxIndex < txs[j].TxIndex
+	})
+	alreadyAsked := []string{}
+	for _, tx := range txs {
+		var details txDetails
+		err = api.getCached("Blockfrost/txs", tx.TxHash, "/txs/"+tx.TxHash, &details)
+		if err != nil {
+			return errors.New(SOURCE + " Error Requesting TX " + tx.TxHash)
+		}
+		var utxos txUTXOs
+		err = api.getCached("Blockfrost/txs/utxos", tx.TxHash, "/txs/"+tx.TxHash+"/utxos", &utxos)
+		if err != nil {
+			return errors.New(SOURCE + " Error Requesting UTXOs " + tx.TxHash)
+		}
+		var sent, received, withdrawn decimal.Decimal
+		external := false
+		for _, in := range utxos.Inputs {
+			if own[in.Address] && !in.Collateral {
+				sent = sent.Add(in.lovelace())
+			}
+		}
+		for _, out := range utxos.Outputs {
+			if out.Collateral {
+				continue
+			}
+			if own[out.Address] {
+				received = received.Add(out.lovelace())
+			} else {
+				external = true
+			}
+		}
+		if details.WithdrawalCount > 0 {
+			var withdrawals []withdrawal
+			err = api.getCached("Blockfrost/txs/withdrawals", tx.TxHash, "/txs/"+tx.TxHash+"/withdrawals", &withdrawals)
+			if err != nil {
+				return errors.New(SOURCE + " Error Requesting Withdrawals " + tx.TxHash)
+			}
+			for _, w := range withdrawals {
+				if ownStake[w.Address] {
+					amount, _ := decimal.NewFromString(w.Amount)
+					withdrawn = withdrawn.Add(amount)
+				}
+			}
+		}
+		fee, _ := decimal.NewFromString(details.Fees)
+		// the stake key registration deposit is still ours, it is refunded (negative) at deregistration
+		deposit := decimal.Zero
+		if details.Deposit != "" {
+			deposit, err = decimal.NewFromString(details.Deposit)
+			if err != nil {
+				return errors.New(SOURCE + " Error Parsing Deposit " + details.Deposit)
+			}
+		}
+		t := wallet.TX{Timestamp: time.Unix(details.BlockTime, 0), ID: tx.TxHash, Note: SOURCE + " " + strconv.Itoa(details.BlockHeight)}
+		if deposit.IsPositive() {
+			t.Note += " stake deposit " + deposit.Shift(-6).String()
+		} else if deposit.IsNegative() {
+			t.Note += " stake deposit refund " + deposit.Neg().Shift(-6).String()
+		}
+		t.Items = make(map[string]wallet.Currencies)
+		if !sent.IsZero() {
+			t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: "ADA", Amount: fee.Shift(-6)})
+			// rewards withdrawn from the stake account and refunded deposit come back to the wallet
+			out := sent.Add(withdrawn).Sub(received).Sub(fee).Sub(deposit)
+			if external && out.IsPositive() {
+				t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: "ADA", Amount: out.Shift(-6)})
+				if is, desc, val, curr := cat.IsTxCashOut(tx.TxHash); is {
+					t.Note += " crypto_payment " + desc
+					t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: curr, Amount: val})
+					api.txsByCategory["CashOut"] = append(api.txsByCategory["CashOut"], t)
+				} else {
+					api.txsByCategory["Withdrawals"] = append(api.txsByCategory["Withdrawals"], t)
+				}
+			} else {
+				from := sent.Add(withdrawn).Sub(fee)
+				to := received
+				if deposit.IsPositive() {
+					to = to.Add(deposit)
+				} else {
+					from = from.Sub(deposit)
+				}
+				t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: "ADA", Amount: from.Shift(-6)})
+				t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: "ADA", Amount: to.Shift(-6)})
+				api.txsByCategory["Transfers"] = append(api.txsByCategory["Transfers"], t)
+			}
+		} else if !received.IsZero() {
+			t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: "ADA", Amount: received.Shift(-6)})
+			if is, desc, val, curr := cat.IsTxCashIn(tx.TxHash); is {
+				t.Note += " crypto_purchase " + desc
+				t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: curr, Amount: val})
+				api.txsByCategory["CashIn"] = append(api.txsByCategory["CashIn"], t)
+			} else {
+				api.txsByCategory["Deposits"] = append(api.txsByCategory["Deposits"], t)
+			}
+		} else {
+			alreadyAsked = wallet.AskForHelp(SOURCE+" "+tx.TxHash, utxos, alreadyAsked)
+		}
+	}
+	return api.getAllRewards(stakes)
+}
+
+// getAllRewards adds staking rewards, those earned in epoch N are spendable
+// from the start of epoch N+2
+func (api *api) getAllRewards(stakes []string) (err error) {
+	const SOURCE = "Blockfrost API :"
+	for _, s := range stakes {
+		rewards, err := api.getRewards(s)
+		if err != nil {
+			return err
+		}
+		for _, r := range rewards {
+			var e epoch
+			err = api.getCached("Blockfrost/epochs", strconv.Itoa(r.Epoch+2), "/epochs/"+strconv.Itoa(r.Epoch+2), &e)
+			if err == errNotFound {
+				continue // not distributed yet
+			}
+			if err != nil {
+				return errors.New(SOURCE + " Error Requesting Epoch " + strconv.Itoa(r.Epoch+2))
+			}
+			amount, err := decimal.NewFromString(r.Amount)
+			if err != nil {
+				return errors.New(SOURCE + " Error Parsing Reward " + r.Amount)
+			}
+			t := wallet.TX{Timestamp: time.Unix(e.StartTime, 0), ID: "ada-reward-" + s + "-" + strconv.Itoa(r.Epoch), Note: SOURCE + " " + r.Type + " reward epoch " + strconv.Itoa(r.Epoch) + " " + r.PoolID}
+			t.Items = make(map[string]wallet.Currencies)
+			t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: "ADA", Amount: amount.Shift(-6)})
+			api.txsByCategory["Minings"] = append(api.txsByCategory["Minings"], t)
+		}
+	}
+	return nil
+}
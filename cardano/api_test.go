@@ -0,0 +1,82 @@
+package cardano
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/category"
+	"github.com/shopspring/decimal"
+)
+
+// fakeBlockfrost answers like Blockfrost for a wallet with 2 addresses behind a stake key
+func fakeBlockfrost(t *testing.T) *httptest.Server {
+	responses := map[string]string{
+		"/accounts/stake1me/addresses":   `[{"address":"addr1a"},{"address":"addr1b"}]`,
+		"/addresses/addr1a/transactions": `[{"tx_hash":"tx1","block_time":1600000000},{"tx_hash":"tx2","block_time":1600001000}]`,
+		"/addresses/addr1b/transactions": `[{"tx_hash":"tx2","block_time":1600001000},{"tx_hash":"tx4","tx_index":1,"block_height":3,"block_time":1600002000},{"tx_hash":"tx3","block_height":3,"block_time":1600002000}]`,
+		"/txs/tx1":                       `{"hash":"tx1","block_height":1,"block_time":1600000000,"fees":"170000","withdrawal_count":0}`,
+		"/txs/tx1/utxos":                 `{"hash":"tx1","inputs":[{"address":"addr1ext","amount":[{"unit":"lovelace","quantity":"20000000"}]}],"outputs":[{"address":"addr1a","amount":[{"unit":"lovelace","quantity":"10000000"},{"unit":"abcd","quantity":"5"}]},{"address":"addr1ext","amount":[{"unit":"lovelace","quantity":"9830000"}]}]}`,
+		"/txs/tx2":                       `{"hash":"tx2","block_height":2,"block_time":1600001000,"fees":"170000","withdrawal_count":1}`,
+		"/txs/tx2/utxos":                 `{"hash":"tx2","inputs":[{"address":"addr1a","amount":[{"unit":"lovelace","quantity":"10000000"}]}],"outputs":[{"address":"addr1ext","amount":[{"unit":"lovelace","quantity":"3000000"}]},{"address":"addr1b","amount":[{"unit":"lovelace","quantity":"7330000"}]}]}`,
+		"/txs/tx3":                       `{"hash":"tx3","block_height":3,"block_time":1600002000,"fees":"170000","deposit":"2000000","withdrawal_count":0}`,
+		"/txs/tx3/utxos":                 `{"hash":"tx3","inputs":[{"address":"addr1b","amount":[{"unit":"lovelace","quantity":"7330000"}]}],"outputs":[{"address":"addr1a","amount":[{"unit":"lovelace","quantity":"5160000"}]}]}`,
+		"/txs/tx4":                       `{"hash":"tx4","block_height":3,"block_time":1600002000,"fees":"170000","deposit":"-2000000","withdrawal_count":0}`,
+		"/txs/tx4/utxos":                 `{"hash":"tx4","inputs":[{"address":"addr1a","amount":[{"unit":"lovelace","quantity":"5160000"}]}],"outputs":[{"address":"addr1b","amount":[{"unit":"lovelace","quantity":"6990000"}]}]}`,
+		"/txs/tx2/withdrawals":           `[{"address":"stake1me","amount":"500000"}]`,
+		"/accounts/stake1me/rewards":     `[{"epoch":300,"amount":"1000000","pool_id":"pool1x","type":"member"},{"epoch":400,"amount":"2000000","pool_id":"pool1x","type":"member"}]`,
+		"/epochs/302":                    `{"epoch":302,"start_time":1636000000,"end_time":1636432000}`,
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("project_id") != "key" {
+			t.Errorf("missing project_id on %s", r.URL.Path)
+		}
+		resp, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"status_code":404,"error":"Not Found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(resp))
+	}))
+}
+
+func TestCardano_GetAPITXs(t *testing.T) {
+	srv := fakeBlockfrost(t)
+	defer srv.Close()
+	ada := New()
+	ada.AddListAddresses([]string{"stake1me"})
+	ada.NewAPI(srv.URL, "key", false)
+	ada.api.cacheDir = t.TempDir()
+	ada.api.timeBetweenReq = 0
+	go ada.GetAPITXs(*category.New())
+	err := ada.WaitFinish()
+	if err != nil {
+		t.Fatalf("GetAPITXs() error = %v", err)
+	}
+	check := func(categ, item, want string) {
+		t.Helper()
+		if len(ada.TXsByCategory[categ]) != 1 || len(ada.TXsByCategory[categ][0].Items[item]) != 1 ||
+			!ada.TXsByCategory[categ][0].Items[item][0].Amount.Equal(decimal.RequireFromString(want)) {
+			t.Errorf("%s %s = %v, want %s ADA", categ, item, ada.TXsByCategory[categ], want)
+		}
+	}
+	check("Deposits", "To", "10")
+	// 10 spent + 0.5 withdrawn rewards - 7.33 change - 0.17 fee
+	check("Withdrawals", "From", "3")
+	check("Withdrawals", "Fee", "0.17")
+	// the reward of epoch 400 is not distributed yet
+	check("Minings", "To", "1")
+	// the registration deposit stays in the wallet and its refund comes back to it
+	tr := ada.TXsByCategory["Transfers"]
+	if len(tr) != 2 || tr[0].ID != "tx3" || !strings.Contains(tr[1].Note, "stake deposit refund 2") {
+		t.Fatalf("Transfers = %v, want tx3 then tx4", tr)
+	}
+	for _, tx := range tr {
+		if !tx.Items["From"][0].Amount.Equal(tx.Items["To"][0].Amount) {
+			t.Errorf("Transfer %s From %v != To %v", tx.ID, tx.Items["From"], tx.Items["To"])
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package cardano
+
+import (
+	"strings"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/category"
+	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
+)
+
+type address struct {
+	address     string
+	description string
+}
+
+type Cardano struct {
+	api           api
+	addresses     []address
+	stakes        []address
+	done          chan error
+	TXsByCategory wallet.TXsByCategory
+}
+
+func New() *Cardano {
+	ada := &Cardano{}
+	ada.done = make(chan error)
+	ada.TXsByCategory = make(map[string]wallet.TXs)
+	return ada
+}
+
+func (ada *Cardano) add(a address) {
+	// a stake key covers all the payment addresses of a wallet
+	if strings.HasPrefix(a.address, "stake") {
+		ada.stakes = append(ada.stakes, a)
+	} else {
+		ada.addresses = append(ada.addresses, a)
+	}
+}
+
+func (ada *Cardano) AddListAddresses(list []string) {
+	for _, add := range list {
+		ada.add(address{address: add})
+	}
+}
+
+func (ada *Cardano) GetAPITXs(cat category.Category) {
+	addresses := []string{}
+	for _, a := range ada.addresses {
+		addresses = append(addresses, a.address)
+	}
+	stakes := []string{}
+	for _, s := range ada.stakes {
+		stakes = append(stakes, s.address)
+	}
+	err := ada.api.getAllTXs(addresses, stakes, cat)
+	if err != nil {
+		ada.done <- err
+		return
+	}
+	ada.TXsByCategory.Add(ada.api.txsByCategory)
+	ada.done <- nil
+}
+
+func (ada *Cardano) WaitFinish() error {
+	return <-ada.done
+}
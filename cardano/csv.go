@@ -0,0 +1,32 @@
+package cardano
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/utils"
+)
+
+var CSVFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"Address", "Description"}},
+}
+
+func (ada *Cardano) ParseCSVAddresses(reader io.Reader) (err error) {
+	const SOURCE = "Cardano Addresses CSV :"
+	csvReader := csv.NewReader(reader)
+	records, err := csvReader.ReadAll()
+	if err == nil {
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVFormats...)
+				if err != nil {
+					return
+				}
+			} else if !l.IsHeader(r) {
+				ada.add(address{address: l.Get(r, "Address"), description: l.Get(r, "Description")})
+			}
+		}
+	}
+	return
+}
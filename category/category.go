@@ -5,18 +5,20 @@ import (
 )
 
 type Category struct {
-	csvCategories []csvCategorie
+	csvCategories map[string][]csvCategorie
+	rules         []Rule
+	overrides     map[string][]Override
 }
 
 func New() *Category {
-	cat := &Category{}
+	cat := &Category{csvCategories: make(map[string][]csvCategorie)}
 	return cat
 }
 
 func (cat Category) IsTxCashOut(txid string) (is bool, desc string, val decimal.Decimal, curr string) {
 	is = false
-	for _, a := range cat.csvCategories {
-		if a.txID == txid && a.kind == "OUT" {
+	for _, a := range cat.csvCategories[txid] {
+		if a.kind == "OUT" {
 			is = true
 			desc = a.description
 			val = a.value
@@ -29,8 +31,8 @@ func (cat Category) IsTxCashOut(txid string) (is bool, desc string, val decimal.
 
 func (cat Category) IsTxCashIn(txid string) (is bool, desc string, val decimal.Decimal, curr string) {
 	is = false
-	for _, a := range cat.csvCategories {
-		if a.txID == txid && a.kind == "IN" {
+	for _, a := range cat.csvCategories[txid] {
+		if a.kind == "IN" {
 			is = true
 			desc = a.description
 			val = a.value
@@ -43,8 +45,8 @@ func (cat Category) IsTxCashIn(txid string) (is bool, desc string, val decimal.D
 
 func (cat Category) IsTxExchange(txid string) (is bool, desc string, val decimal.Decimal, curr string) {
 	is = false
-	for _, a := range cat.csvCategories {
-		if a.txID == txid && a.kind == "EXC" {
+	for _, a := range cat.csvCategories[txid] {
+		if a.kind == "EXC" {
 			is = true
 			desc = a.description
 			val = a.value
@@ -57,8 +59,8 @@ func (cat Category) IsTxExchange(txid string) (is bool, desc string, val decimal
 
 func (cat Category) HasCustody(txid string) (is bool, desc string, val decimal.Decimal) {
 	is = false
-	for _, a := range cat.csvCategories {
-		if a.txID == txid && a.kind == "CUS" {
+	for _, a := range cat.csvCategories[txid] {
+		if a.kind == "CUS" {
 			is = true
 			desc = a.description
 			val = a.value
@@ -70,8 +72,8 @@ func (cat Category) HasCustody(txid string) (is bool, desc string, val decimal.D
 
 func (cat Category) IsTxGift(txid string) (is bool, desc string) {
 	is = false
-	for _, a := range cat.csvCategories {
-		if a.txID == txid && a.kind == "GIFT" {
+	for _, a := range cat.csvCategories[txid] {
+		if a.kind == "GIFT" {
 			is = true
 			desc = a.description
 			return
@@ -82,8 +84,8 @@ func (cat Category) IsTxGift(txid string) (is bool, desc string) {
 
 func (cat Category) IsTxAirDrop(txid string) (is bool, desc string) {
 	is = false
-	for _, a := range cat.csvCategories {
-		if a.txID == txid && a.kind == "AIR" {
+	for _, a := range cat.csvCategories[txid] {
+		if a.kind == "AIR" {
 			is = true
 			desc = a.description
 			return
@@ -94,8 +96,8 @@ func (cat Category) IsTxAirDrop(txid string) (is bool, desc string) {
 
 func (cat Category) IsTxInterest(txid string) (is bool, desc string) {
 	is = false
-	for _, a := range cat.csvCategories {
-		if a.txID == txid && a.kind == "INT" {
+	for _, a := range cat.csvCategories[txid] {
+		if a.kind == "INT" {
 			is = true
 			desc = a.description
 			return
@@ -106,8 +108,8 @@ func (cat Category) IsTxInterest(txid string) (is bool, desc string) {
 
 func (cat Category) IsTxShit(txid string) (is bool, desc string, val decimal.Decimal, curr string) {
 	is = false
-	for _, a := range cat.csvCategories {
-		if a.txID == txid && a.kind == "SHIT" {
+	for _, a := range cat.csvCategories[txid] {
+		if a.kind == "SHIT" {
 			is = true
 			desc = a.description
 			val = a.value
@@ -120,8 +122,8 @@ func (cat Category) IsTxShit(txid string) (is bool, desc string, val decimal.Dec
 
 func (cat Category) IsTxTokenSale(txid string) (is bool, buy string) {
 	is = false
-	for _, a := range cat.csvCategories {
-		if a.txID == txid && a.kind == "TOK" {
+	for _, a := range cat.csvCategories[txid] {
+		if a.kind == "TOK" {
 			is = true
 			buy = a.description
 			return
@@ -132,8 +134,8 @@ func (cat Category) IsTxTokenSale(txid string) (is bool, buy string) {
 
 func (cat Category) IsTxFee(txid string) (is bool, fee string) {
 	is = false
-	for _, a := range cat.csvCategories {
-		if a.txID == txid && a.kind == "FEE" {
+	for _, a := range cat.csvCategories[txid] {
+		if a.kind == "FEE" {
 			is = true
 			fee = a.description
 			return
@@ -144,8 +146,8 @@ func (cat Category) IsTxFee(txid string) (is bool, fee string) {
 
 func (cat Category) IsTxTransfer(txid string) (is bool, transid string) {
 	is = false
-	for _, a := range cat.csvCategories {
-		if a.txID == txid && a.kind == "TRANS" {
+	for _, a := range cat.csvCategories[txid] {
+		if a.kind == "TRANS" {
 			is = true
 			transid = a.description
 			return
@@ -2,12 +2,18 @@ package category
 
 import (
 	"encoding/csv"
+	"errors"
 	"io"
-	"log"
 
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
+	"github.com/fiscafacile/CryptoFiscaFacile/utils"
 	"github.com/shopspring/decimal"
 )
 
+var CSVFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"TxID", "Type", "Description", "Value", "Currency"}, Positional: true},
+}
+
 type csvCategorie struct {
 	txID        string
 	kind        string
@@ -16,26 +22,35 @@ type csvCategorie struct {
 	currency    string
 }
 
-func (cat *Category) ParseCSVCategory(reader io.Reader) {
+func (cat *Category) ParseCSVCategory(reader io.Reader) (err error) {
 	const SOURCE = "TXs Categorie CSV :"
 	csvReader := csv.NewReader(reader)
 	records, err := csvReader.ReadAll()
-	if err == nil {
-		for _, r := range records {
-			if r[0] != "TxID" {
-				a := csvCategorie{}
-				a.txID = r[0]
-				a.kind = r[1]
-				a.description = r[2]
-				if r[3] != "" {
-					a.value, err = decimal.NewFromString(r[3])
-					if err != nil {
-						log.Println(SOURCE, "Error Parsing Value", r[3])
-					}
+	if err != nil {
+		return errors.New(SOURCE + " " + err.Error())
+	}
+	var l utils.CSVLayout
+	for n, r := range records {
+		if n == 0 {
+			l, err = utils.DetectCSVLayout(SOURCE, r, CSVFormats...)
+			if err != nil {
+				return
+			}
+		}
+		if !l.IsHeader(r) {
+			a := csvCategorie{}
+			a.txID = l.Get(r, "TxID")
+			a.kind = l.Get(r, "Type")
+			a.description = l.Get(r, "Description")
+			if l.Get(r, "Value") != "" {
+				a.value, err = decimal.NewFromString(l.Get(r, "Value"))
+				if err != nil {
+					diag.Add(SOURCE, n+1, "Value", l.Get(r, "Value"), "Error Parsing Value")
 				}
-				a.currency = r[4]
-				cat.csvCategories = append(cat.csvCategories, a)
 			}
+			a.currency = l.Get(r, "Currency")
+			cat.csvCategories[a.txID] = append(cat.csvCategories[a.txID], a)
 		}
 	}
+	return nil
 }
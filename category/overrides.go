@@ -0,0 +1,144 @@
+package category
+
+import (
+	"errors"
+	"io"
+
+	"github.com/shopspring/decimal"
+	"gopkg.in/yaml.v3"
+)
+
+type yamlLeg struct {
+	Code   string `yaml:"code"`
+	Amount string `yaml:"amount"`
+}
+
+type yamlPart struct {
+	Category string               `yaml:"category"`
+	Note     string               `yaml:"note"`
+	Items    map[string][]yamlLeg `yaml:"items"`
+}
+
+type yamlOverride struct {
+	ID       string               `yaml:"id"`
+	Source   string               `yaml:"source"`
+	Category string               `yaml:"category"`
+	Note     string               `yaml:"note"`
+	Delete   bool                 `yaml:"delete"`
+	Unlink   bool                 `yaml:"unlink"`
+	Items    map[string][]yamlLeg `yaml:"items"`
+	Split    []yamlPart           `yaml:"split"`
+}
+
+type yamlOverrides struct {
+	Overrides []yamlOverride `yaml:"overrides"`
+}
+
+type Leg struct {
+	Code   string
+	Amount decimal.Decimal
+}
+
+type Part struct {
+	Category string
+	Note     string
+	Items    map[string][]Leg
+}
+
+type Override struct {
+	ID       string
+	Source   string
+	Category string
+	Note     string
+	Delete   bool
+	Unlink   bool
+	Items    map[string][]Leg
+	Split    []Part
+}
+
+func parseLegs(src, id string, items map[string][]yamlLeg) (legs map[string][]Leg, err error) {
+	if items == nil {
+		return
+	}
+	legs = make(map[string][]Leg)
+	for k, v := range items {
+		if k != "From" && k != "To" && k != "Fee" && k != "Lost" {
+			return nil, errors.New(src + " Override " + id + " Unknown Item " + k)
+		}
+		legs[k] = []Leg{}
+		for _, l := range v {
+			amount, err := decimal.NewFromString(l.Amount)
+			if err != nil {
+				return nil, errors.New(src + " Override " + id + " Error Parsing Amount " + l.Amount)
+			}
+			legs[k] = append(legs[k], Leg{Code: l.Code, Amount: amount})
+		}
+	}
+	return
+}
+
+func (cat *Category) ParseYAMLOverrides(reader io.Reader) (err error) {
+	const SOURCE = "TXs Overrides YAML :"
+	var yo yamlOverrides
+	err = yaml.NewDecoder(reader).Decode(&yo)
+	if err != nil {
+		return errors.New(SOURCE + " " + err.Error())
+	}
+	if cat.overrides == nil {
+		cat.overrides = make(map[string][]Override)
+	}
+	for _, o := range yo.Overrides {
+		if o.ID == "" {
+			return errors.New(SOURCE + " Override without ID")
+		}
+		ov := Override{
+			ID:       o.ID,
+			Source:   o.Source,
+			Category: o.Category,
+			Note:     o.Note,
+			Delete:   o.Delete,
+			Unlink:   o.Unlink,
+		}
+		ov.Items, err = parseLegs(SOURCE, o.ID, o.Items)
+		if err != nil {
+			return
+		}
+		for _, p := range o.Split {
+			part := Part{Category: p.Category, Note: p.Note}
+			part.Items, err = parseLegs(SOURCE, o.ID, p.Items)
+			if err != nil {
+				return
+			}
+			ov.Split = append(ov.Split, part)
+		}
+		for _, prev := range cat.overrides[o.ID] {
+			if prev.Source == o.Source {
+				return errors.New(SOURCE + " Override " + o.ID + " Duplicated")
+			}
+		}
+		cat.overrides[o.ID] = append(cat.overrides[o.ID], ov)
+	}
+	return
+}
+
+// GetOverride returns the override of this Source for the TX, or else the one without Source
+func (cat Category) GetOverride(src, txid string) (o Override, ok bool) {
+	for _, ov := range cat.overrides[txid] {
+		if ov.Source == src {
+			return ov, true
+		}
+		if ov.Source == "" {
+			o, ok = ov, true
+		}
+	}
+	return
+}
+
+func (cat Category) Overrides() map[string][]Override {
+	return cat.overrides
+}
+
+func (cat Category) IsTxUnlinked(src, txid string) bool {
+	o, ok := cat.GetOverride(src, txid)
+	return ok && o.Unlink
+}
@@ -0,0 +1,161 @@
+package category
+
+import (
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gopkg.in/yaml.v3"
+)
+
+type yamlMatch struct {
+	Source    string `yaml:"source"`
+	Category  string `yaml:"category"`
+	Note      string `yaml:"note"`
+	Address   string `yaml:"address"`
+	Asset     string `yaml:"asset"`
+	MinAmount string `yaml:"min-amount"`
+	MaxAmount string `yaml:"max-amount"`
+	After     string `yaml:"after"`
+	Before    string `yaml:"before"`
+}
+
+type yamlRule struct {
+	Name        string    `yaml:"name"`
+	Match       yamlMatch `yaml:"match"`
+	Category    string    `yaml:"category"`
+	Description string    `yaml:"description"`
+	Value       string    `yaml:"value"`
+	Currency    string    `yaml:"currency"`
+	Fees        []string  `yaml:"fees"`
+}
+
+type yamlRules struct {
+	Rules []yamlRule `yaml:"rules"`
+}
+
+type Rule struct {
+	Name        string
+	Source      string
+	FromCateg   string
+	Note        *regexp.Regexp
+	Address     string
+	Asset       string
+	MinAmount   decimal.Decimal
+	MaxAmount   decimal.Decimal
+	After       time.Time
+	Before      time.Time
+	Category    string
+	Description string
+	Value       decimal.Decimal
+	Currency    string
+	Fees        []string
+}
+
+func (cat *Category) ParseYAMLRules(reader io.Reader, loc *time.Location) (err error) {
+	const SOURCE = "TXs Rules YAML :"
+	var yr yamlRules
+	err = yaml.NewDecoder(reader).Decode(&yr)
+	if err != nil {
+		return errors.New(SOURCE + " " + err.Error())
+	}
+	for i, r := range yr.Rules {
+		rule := Rule{
+			Name:        r.Name,
+			Source:      r.Match.Source,
+			FromCateg:   r.Match.Category,
+			Address:     strings.ToLower(r.Match.Address),
+			Asset:       r.Match.Asset,
+			Category:    r.Category,
+			Description: r.Description,
+			Currency:    r.Currency,
+			Fees:        r.Fees,
+		}
+		if rule.Name == "" {
+			rule.Name = "#" + strconv.Itoa(i+1)
+		}
+		if r.Match.Note != "" {
+			rule.Note, err = regexp.Compile(r.Match.Note)
+			if err != nil {
+				return errors.New(SOURCE + " Rule " + rule.Name + " Error Parsing Note " + err.Error())
+			}
+		}
+		if r.Match.MinAmount != "" {
+			rule.MinAmount, err = decimal.NewFromString(r.Match.MinAmount)
+			if err != nil {
+				return errors.New(SOURCE + " Rule " + rule.Name + " Error Parsing MinAmount " + r.Match.MinAmount)
+			}
+		}
+		if r.Match.MaxAmount != "" {
+			rule.MaxAmount, err = decimal.NewFromString(r.Match.MaxAmount)
+			if err != nil {
+				return errors.New(SOURCE + " Rule " + rule.Name + " Error Parsing MaxAmount " + r.Match.MaxAmount)
+			}
+		}
+		if r.Match.After != "" {
+			rule.After, err = time.ParseInLocation("2006-01-02", r.Match.After, loc)
+			if err != nil {
+				return errors.New(SOURCE + " Rule " + rule.Name + " Error Parsing After " + r.Match.After)
+			}
+		}
+		if r.Match.Before != "" {
+			rule.Before, err = time.ParseInLocation("2006-01-02", r.Match.Before, loc)
+			if err != nil {
+				return errors.New(SOURCE + " Rule " + rule.Name + " Error Parsing Before " + r.Match.Before)
+			}
+		}
+		if r.Value != "" {
+			rule.Value, err = decimal.NewFromString(r.Value)
+			if err != nil {
+				return errors.New(SOURCE + " Rule " + rule.Name + " Error Parsing Value " + r.Value)
+			}
+		}
+		cat.rules = append(cat.rules, rule)
+	}
+	return
+}
+
+func (cat Category) Rules() []Rule {
+	return cat.rules
+}
+
+// Matches checks the TX wide conditions, the counterparty Address is looked for in the Note as Sources store it there
+func (r Rule) Matches(src, categ, note string, date time.Time) bool {
+	if r.Source != "" && r.Source != src {
+		return false
+	}
+	if r.FromCateg != "" && r.FromCateg != categ {
+		return false
+	}
+	if r.Note != nil && !r.Note.MatchString(note) {
+		return false
+	}
+	if r.Address != "" && !strings.Contains(strings.ToLower(note), r.Address) {
+		return false
+	}
+	if !r.After.IsZero() && date.Before(r.After) {
+		return false
+	}
+	if !r.Before.IsZero() && !date.Before(r.Before) {
+		return false
+	}
+	return true
+}
+
+// MatchesAsset checks the conditions on one of the TX legs
+func (r Rule) MatchesAsset(code string, amount decimal.Decimal) bool {
+	if r.Asset != "" && r.Asset != code {
+		return false
+	}
+	if !r.MinAmount.IsZero() && amount.LessThan(r.MinAmount) {
+		return false
+	}
+	if !r.MaxAmount.IsZero() && amount.GreaterThan(r.MaxAmount) {
+		return false
+	}
+	return true
+}
@@ -462,7 +462,7 @@ func (c2086 Cerfa2086) Println(native string) {
 	}
 }
 
-func (c2086 Cerfa2086) ToXlsx(filename, native string) {
+func (c2086 Cerfa2086) ToXlsx(filename, native string) error {
 	f := excelize.NewFile()
 	if len(c2086.pta.Acquisitions) > 0 {
 		sheet := "Prix Total Acquisition PEPS"
@@ -586,7 +586,5 @@ func (c2086 Cerfa2086) ToXlsx(filename, native string) {
 		f.SetCellValue(sheet, next, "Pour rappel, vous avez un total de "+c2086.airdrops[year][native].Add(c2086.commercialRebates[year][native]).Neg().RoundBank(0).String()+" "+native+" non imposable (airdrops fortuits + remises commerciales).")
 	}
 	f.DeleteSheet("Sheet1")
-	if err := f.SaveAs(filename); err != nil {
-		log.Fatal(err)
-	}
+	return f.SaveAs(filename)
 }
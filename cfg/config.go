@@ -3,6 +3,7 @@ package cfg
 import (
 	"log"
 	"os"
+	"strings"
 
 	"github.com/spf13/pflag"
 	"gopkg.in/yaml.v3"
@@ -11,13 +12,29 @@ import (
 type API struct {
 	Key    string `yaml:"key"`
 	Secret string `yaml:"secret"`
+	URL    string `yaml:"url"`
 }
 
 // Blockchains
+type TokenLists struct {
+	Allow []string `yaml:"allow"`
+	Deny  []string `yaml:"deny"`
+}
+
 type BlockchainConfig struct {
-	Addresses []string `yaml:"addresses"`
-	CSV       []string `yaml:"csv"`
-	JSON      string   `yaml:"json"`
+	Addresses  []string   `yaml:"addresses"`
+	CSV        []string   `yaml:"csv"`
+	JSON       string     `yaml:"json"`
+	XPubs      []string   `yaml:"xpubs"`
+	GapLimit   int        `yaml:"gap-limit"`
+	Esplora    string     `yaml:"esplora"`
+	Decimals   int        `yaml:"decimals"`
+	Etherscan  API        `yaml:"etherscan"`
+	Native     string     `yaml:"native"`
+	RPC        string     `yaml:"rpc"`
+	RPCDump    string     `yaml:"rpc-dump"`
+	Tokens     TokenLists `yaml:"tokens"`
+	SpamFilter bool       `yaml:"spam-filter"`
 }
 
 type Blockchains struct {
@@ -25,6 +42,9 @@ type Blockchains struct {
 	BTC BlockchainConfig `yaml:"BTC"`
 	BTG BlockchainConfig `yaml:"BTG"`
 	ETH BlockchainConfig `yaml:"ETH"`
+	// any other chain : UTXO (LTC, DOGE...) scanned through its Esplora API
+	// or EVM (BSC, POLYGON...) scanned through its Etherscan-family explorer
+	Others map[string]BlockchainConfig `yaml:",inline"`
 }
 
 // Exchanges
@@ -72,50 +92,85 @@ type FiscalYear struct {
 
 // Options
 type Options struct {
-	Bcd             bool       `yaml:"bcd"`
-	Bch             bool       `yaml:"bch"`
-	BinanceExtended bool       `yaml:"binance-extended"`
-	Btg             bool       `yaml:"btg"`
-	CashInBNC       FiscalYear `yaml:"cashin-bnc"`
-	Check           bool       `yaml:"check"`
-	CurrencyFilter  string     `yaml:"curr-filter"`
-	Date            string     `yaml:"date"`
-	Debug           bool       `yaml:"debug"`
-	Display2086     bool       `yaml:"display-2086"`
-	Exact           bool       `yaml:"exact"`
-	Export2086      bool       `yaml:"export-2086"`
-	Export3916      bool       `yaml:"export-3916"`
-	ExportStock     bool       `yaml:"export-stock"`
-	Lbtc            bool       `yaml:"lbtc"`
-	Location        string     `yaml:"location"`
-	LogFile         string     `yaml:"log"`
-	Native          string     `yaml:"native"`
-	Stats           bool       `yaml:"stats"`
-	TxsCategory     string     `yaml:"txs-categ"`
-	TxsDisplay      string     `yaml:"txs-display"`
+	Bcd                bool       `yaml:"bcd"`              // deprecated, see Forks
+	Bch                bool       `yaml:"bch"`              // deprecated, see Forks
+	BinanceExtended    bool       `yaml:"binance-extended"` // deprecated, the format is detected from the CSV header
+	Btg                bool       `yaml:"btg"`              // deprecated, see Forks
+	CashInBNC          FiscalYear `yaml:"cashin-bnc"`
+	Check              bool       `yaml:"check"`
+	CurrencyFilter     string     `yaml:"curr-filter"`
+	Date               string     `yaml:"date"`
+	Debug              bool       `yaml:"debug"`
+	Display2086        bool       `yaml:"display-2086"`
+	Exact              bool       `yaml:"exact"`
+	InputsDir          string     `yaml:"inputs-dir"`
+	Lbtc               bool       `yaml:"lbtc"` // deprecated, see Forks
+	Export2086         bool       `yaml:"export-2086"`
+	Export3916         bool       `yaml:"export-3916"`
+	ExportCoinTracking bool       `yaml:"export-cointracking"`
+	ExportKoinly       bool       `yaml:"export-koinly"`
+	ExportLint         bool       `yaml:"export-lint"`
+	ExportLocations    bool       `yaml:"export-locations"`
+	ExportStock        bool       `yaml:"export-stock"`
+	ExportTXs          bool       `yaml:"export-txs"`
+	ExportWaltio       bool       `yaml:"export-waltio"`
+	Forks              []string   `yaml:"forks"`
+	Location           string     `yaml:"location"`
+	LogFile            string     `yaml:"log"`
+	Native             string     `yaml:"native"`
+	Reconcile          bool       `yaml:"reconcile"`
+	Stats              bool       `yaml:"stats"`
+	Strict             bool       `yaml:"strict"`
+	TxsCategory        string     `yaml:"txs-categ"`
+	TxsDisplay         string     `yaml:"txs-display"`
+	TxsImport          []string   `yaml:"txs-import"`
+	TxsOverrides       string     `yaml:"txs-overrides"`
+	TxsRules           string     `yaml:"txs-rules"`
+}
+
+// Lint
+type Suppression struct {
+	Rule     string `yaml:"rule"`
+	TX       string `yaml:"tx"`
+	Source   string `yaml:"source"`
+	Location string `yaml:"location"`
+	Reason   string `yaml:"reason"`
+}
+
+type Lint struct {
+	MinSeverity  string        `yaml:"min-severity"`
+	Suppressions []Suppression `yaml:"suppressions"`
 }
 
 // Tools
 type Tools struct {
-	CoinAPI   API `yaml:"coinapi"`
-	CoinLayer API `yaml:"coinlayer"`
-	EtherScan API `yaml:"etherscan"`
+	Blockfrost API `yaml:"blockfrost"`
+	CoinAPI    API `yaml:"coinapi"`
+	CoinLayer  API `yaml:"coinlayer"`
+	EtherScan  API `yaml:"etherscan"`
 }
 
 // Wallets
 type WalletConfig struct {
-	CSV CSV `yaml:"csv"`
+	CSV  CSV      `yaml:"csv"`
+	JSON []string `yaml:"json"`
 }
 
 type Wallets struct {
-	LedgerLive WalletConfig `yaml:"ledgerlive"`
-	Monero     WalletConfig `yaml:"monero"`
-	MyCelium   WalletConfig `yaml:"mycelium"`
+	BitcoinCore  WalletConfig `yaml:"bitcoin-core"`
+	CoinTracking WalletConfig `yaml:"cointracking"`
+	Electrum     WalletConfig `yaml:"electrum"`
+	Koinly       WalletConfig `yaml:"koinly"`
+	LedgerLive   WalletConfig `yaml:"ledgerlive"`
+	Manual       WalletConfig `yaml:"manual"`
+	Monero       WalletConfig `yaml:"monero"`
+	MyCelium     WalletConfig `yaml:"mycelium"`
 }
 
 type Config struct {
 	Blockchains Blockchains `yaml:"blockchains"`
 	Exchanges   Exchanges   `yaml:"exchanges"`
+	Lint        Lint        `yaml:"lint"`
 	Options     Options     `yaml:"options"`
 	Tools       Tools       `yaml:"tools"`
 	Wallets     Wallets     `yaml:"wallets"`
@@ -174,28 +229,46 @@ func LoadConfig() (*Config, error) {
 	pflag.BoolVar(&config.Options.CashInBNC.Y2020, "cashin-bnc-2020", config.Options.CashInBNC.Y2020, "Convert AirDrops/CommercialRebates/Interests/Minings/Referrals into CashIn for 2020's Txs in 2086")
 	pflag.BoolVar(&config.Options.CashInBNC.Y2021, "cashin-bnc-2021", config.Options.CashInBNC.Y2021, "Convert AirDrops/CommercialRebates/Interests/Minings/Referrals into CashIn for 2021's Txs in 2086")
 	pflag.BoolVarP(&config.Options.Check, "check", "c", config.Options.Check, "Check and Display consistency")
+	pflag.BoolVar(&config.Options.ExportLint, "lint", config.Options.ExportLint, "Export consistency issues to lint.json and lint.xlsx")
+	pflag.StringVar(&config.Lint.MinSeverity, "lint-severity", config.Lint.MinSeverity, "Minimum severity of consistency issues : info|warning|error")
+	pflag.BoolVar(&config.Options.Reconcile, "reconcile", config.Options.Reconcile, "Compare live balances from Exchanges APIs with the ones computed from TXs")
 	pflag.StringVarP(&config.Options.CurrencyFilter, "currency-filter", "f", config.Options.CurrencyFilter, "Currencies to be filtered in Transactions Display (comma separated list)")
+	pflag.BoolVar(&config.Options.Strict, "strict", config.Options.Strict, "Abort when a value of a Source file cannot be parsed")
 	pflag.StringVar(&config.Options.LogFile, "log", config.Options.LogFile, "Log file")
 	pflag.BoolVar(&config.Options.Debug, "exact", config.Options.Debug, "Display exact amount (no rounding)")
 	pflag.StringVarP(&config.Options.TxsDisplay, "txs-display", "t", config.Options.TxsDisplay, "Display Transactions By Category : Exchanges|Deposits|Withdrawals|CashIn|CashOut|etc")
 	// Sources
+	pflag.StringVar(&config.Options.InputsDir, "inputs-dir", config.Options.InputsDir, "Directory to scan for Sources files, each file is recognised by its content")
 	pflag.StringVar(&config.Options.TxsCategory, "txs-categ", config.Options.TxsCategory, "Transactions Categories CSV file")
+	pflag.StringSliceVar(&config.Options.TxsImport, "txs-import", config.Options.TxsImport, "Normalized Transactions CSV or JSON file from --txs-export")
+	pflag.StringVar(&config.Options.TxsOverrides, "txs-overrides", config.Options.TxsOverrides, "Transactions Overrides YAML file")
+	pflag.StringVar(&config.Options.TxsRules, "txs-rules", config.Options.TxsRules, "Transactions Categorization Rules YAML file")
 	pflag.StringVar(&config.Tools.CoinAPI.Key, "coinapi-key", config.Tools.CoinAPI.Key, "CoinAPI Key (https://www.coinapi.io/pricing?apikey)")
 	pflag.StringVar(&config.Tools.CoinLayer.Key, "coinlayer-key", config.Tools.CoinLayer.Key, "CoinLayer Key (https://coinlayer.com/product)")
 	pflag.StringSliceVar(&config.Blockchains.BTC.CSV, "btc-addresses-csv", config.Blockchains.BTC.CSV, "Bitcoin Addresses CSV files")
 	pflag.StringSliceVar(&config.Blockchains.BTC.Addresses, "btc-address", config.Blockchains.BTC.Addresses, "Bitcoin Address")
-	pflag.BoolVar(&config.Options.Bcd, "bcd", config.Options.Bcd, "Detect Bitcoin Diamond Fork")
-	pflag.BoolVar(&config.Options.Bch, "bch", config.Options.Bch, "Detect Bitcoin Cash Fork")
-	pflag.BoolVar(&config.Options.Btg, "btg", config.Options.Btg, "Detect Bitcoin Gold Fork")
-	pflag.BoolVar(&config.Options.Lbtc, "lbtc", config.Options.Lbtc, "Detect Lightning Bitcoin Fork")
+	pflag.StringSliceVar(&config.Blockchains.BTC.XPubs, "btc-xpub", config.Blockchains.BTC.XPubs, "Bitcoin Extended Public Key (xpub, ypub or zpub)")
+	pflag.StringVar(&config.Blockchains.BTC.Esplora, "btc-esplora", config.Blockchains.BTC.Esplora, "Bitcoin Esplora API URL (testnet or self-hosted electrs)")
+	pflag.IntVar(&config.Blockchains.BTC.GapLimit, "btc-gap-limit", config.Blockchains.BTC.GapLimit, "Number of consecutive unused addresses before stopping XPub scan")
+	pflag.StringSliceVar(&config.Options.Forks, "forks", config.Options.Forks, "Forks to detect (comma separated list of tickers : BCH,BSV,BTG,BCD,LBTC,ETC,ETHW)")
 	pflag.StringVar(&config.Blockchains.BTG.JSON, "btg-txs", config.Blockchains.BTG.JSON, "Bitcoin Gold Transactions JSON file")
 	pflag.StringSliceVar(&config.Blockchains.ETH.CSV, "eth-addresses-csv", config.Blockchains.ETH.CSV, "Ethereum Addresses CSV file")
 	pflag.StringSliceVar(&config.Blockchains.ETH.Addresses, "eth-address", config.Blockchains.ETH.Addresses, "Ethereum Address")
+	pflag.StringVar(&config.Blockchains.ETH.RPC, "eth-rpc", config.Blockchains.ETH.RPC, "Ethereum Node JSON-RPC URL (Erigon or Nethermind with trace_filter, not Geth) to use instead of Etherscan")
+	pflag.StringSliceVar(&config.Blockchains.ETH.Tokens.Allow, "eth-token-allow", config.Blockchains.ETH.Tokens.Allow, "Ethereum Token Contract Address never filtered as spam")
+	pflag.StringSliceVar(&config.Blockchains.ETH.Tokens.Deny, "eth-token-deny", config.Blockchains.ETH.Tokens.Deny, "Ethereum Token Contract Address always filtered as spam")
+	pflag.BoolVar(&config.Blockchains.ETH.SpamFilter, "eth-spam-filter", config.Blockchains.ETH.SpamFilter, "Filter Ethereum spam Tokens (zero-value transfers, received only and no market price)")
+	pflag.StringVar(&config.Blockchains.ETH.RPCDump, "eth-rpc-dump", config.Blockchains.ETH.RPCDump, "Ethereum Node JSON-RPC Dump file (written when --eth-rpc is given, read offline otherwise)")
+	pflag.StringSliceVar(&config.Blockchains.ADA.CSV, "ada-addresses-csv", config.Blockchains.ADA.CSV, "Cardano Addresses CSV file")
+	pflag.StringSliceVar(&config.Blockchains.ADA.Addresses, "ada-address", config.Blockchains.ADA.Addresses, "Cardano Address or Stake Key")
+	pflag.StringVar(&config.Tools.Blockfrost.Key, "blockfrost-key", config.Tools.Blockfrost.Key, "Blockfrost Project ID (https://blockfrost.io)")
+	pflag.StringVar(&config.Tools.Blockfrost.URL, "blockfrost-url", config.Tools.Blockfrost.URL, "Blockfrost compatible API URL")
 	pflag.StringVar(&config.Tools.EtherScan.Key, "etherscan-apikey", config.Tools.EtherScan.Key, "Etherscan API Key (https://etherscan.io/myapikey)")
 	pflag.StringVar(&config.Exchanges.Binance.API.Key, "binance-api-key", config.Exchanges.Binance.API.Key, "Binance API key")
 	pflag.StringVar(&config.Exchanges.Binance.API.Secret, "binance-api-secret", config.Exchanges.Binance.API.Secret, "Binance API secret")
 	pflag.StringSliceVar(&config.Exchanges.Binance.CSV.All, "binance", config.Exchanges.Binance.CSV.All, "Binance CSV file")
 	pflag.BoolVar(&config.Options.BinanceExtended, "binance-extended", config.Options.BinanceExtended, "Use Binance CSV file extended format")
+	pflag.CommandLine.MarkDeprecated("binance-extended", "the format is detected from the CSV header")
 	pflag.StringSliceVar(&config.Exchanges.Bitfinex.CSV.All, "bitfinex", config.Exchanges.Bitfinex.CSV.All, "Bitfinex CSV file")
 	pflag.StringVar(&config.Exchanges.Bitstamp.API.Key, "bitstamp-api-key", config.Exchanges.Bitstamp.API.Key, "Bitstamp API key")
 	pflag.StringVar(&config.Exchanges.Bitstamp.API.Secret, "bitstamp-api-secret", config.Exchanges.Bitstamp.API.Secret, "Bitstamp API secret")
@@ -221,9 +294,16 @@ func LoadConfig() (*Config, error) {
 	pflag.StringVar(&config.Exchanges.Kraken.API.Key, "kraken-api-key", config.Exchanges.Kraken.API.Key, "Kraken API key")
 	pflag.StringVar(&config.Exchanges.Kraken.API.Secret, "kraken-api-secret", config.Exchanges.Kraken.API.Secret, "Kraken API secret")
 	pflag.StringSliceVar(&config.Exchanges.Kraken.CSV.All, "kraken", config.Exchanges.Kraken.CSV.All, "Kraken CSV file")
+	pflag.StringSliceVar(&config.Wallets.BitcoinCore.CSV.All, "bitcoin-core-csv", config.Wallets.BitcoinCore.CSV.All, "Bitcoin Core Transactions export CSV file")
+	pflag.StringSliceVar(&config.Wallets.BitcoinCore.JSON, "bitcoin-core-json", config.Wallets.BitcoinCore.JSON, "Bitcoin Core listtransactions JSON file")
+	pflag.StringSliceVar(&config.Wallets.Electrum.CSV.All, "electrum-csv", config.Wallets.Electrum.CSV.All, "Electrum History CSV file")
 	pflag.StringSliceVar(&config.Wallets.LedgerLive.CSV.All, "ledgerlive", config.Wallets.LedgerLive.CSV.All, "LedgerLive CSV file")
 	pflag.StringSliceVar(&config.Exchanges.LocalBitcoins.CSV.Trades, "lb-trade", config.Exchanges.LocalBitcoins.CSV.Trades, "Local Bitcoin Trade CSV file")
 	pflag.StringSliceVar(&config.Exchanges.LocalBitcoins.CSV.Transfers, "lb-transfer", config.Exchanges.LocalBitcoins.CSV.Transfers, "Local Bitcoin Transfer CSV file")
+	pflag.StringSliceVar(&config.Wallets.Koinly.CSV.All, "koinly-csv", config.Wallets.Koinly.CSV.All, "Koinly Transactions export CSV file")
+	pflag.StringSliceVar(&config.Wallets.CoinTracking.CSV.All, "cointracking-csv", config.Wallets.CoinTracking.CSV.All, "CoinTracking Trade List CSV file")
+	pflag.StringSliceVar(&config.Wallets.Manual.CSV.All, "manual", config.Wallets.Manual.CSV.All, "Manual/OTC Transactions CSV file")
+	pflag.StringSliceVar(&config.Wallets.Manual.JSON, "manual-json", config.Wallets.Manual.JSON, "Manual/OTC Transactions JSON file")
 	pflag.StringSliceVar(&config.Wallets.Monero.CSV.All, "monero", config.Wallets.Monero.CSV.All, "Monero CSV file")
 	pflag.StringSliceVar(&config.Wallets.MyCelium.CSV.All, "mycelium", config.Wallets.MyCelium.CSV.All, "MyCelium CSV file")
 	pflag.StringSliceVar(&config.Exchanges.Poloniex.CSV.Trades, "poloniex-trades", config.Exchanges.Poloniex.CSV.Trades, "Poloniex Trades CSV file")
@@ -237,6 +317,50 @@ func LoadConfig() (*Config, error) {
 	pflag.BoolVar(&config.Options.Export2086, "2086", config.Options.Export2086, "Export Cerfa 2086 to 2086.xlsx")
 	pflag.BoolVar(&config.Options.Export3916, "3916", config.Options.Export3916, "Export Cerfa 3916 to 3916.xlsx")
 	pflag.BoolVar(&config.Options.ExportStock, "stock", config.Options.ExportStock, "Export stock balances to stock.xlsx")
+	pflag.BoolVar(&config.Options.ExportKoinly, "koinly", config.Options.ExportKoinly, "Export all Transactions to koinly.csv (Koinly universal format)")
+	pflag.BoolVar(&config.Options.ExportCoinTracking, "cointracking", config.Options.ExportCoinTracking, "Export all Transactions to cointracking.csv (CoinTracking custom format)")
+	pflag.BoolVar(&config.Options.ExportWaltio, "waltio", config.Options.ExportWaltio, "Export all Transactions to waltio.csv (Waltio template)")
+	pflag.BoolVar(&config.Options.ExportTXs, "txs-export", config.Options.ExportTXs, "Export all normalized Transactions to txs.json and txs.csv")
+	pflag.BoolVar(&config.Options.ExportLocations, "locations", config.Options.ExportLocations, "Export balances per exchange/wallet to locations.xlsx and locations.csv")
+	pflag.BoolVar(&config.Options.Bcd, "bcd", config.Options.Bcd, "Detect Bitcoin Diamond Fork")
+	pflag.BoolVar(&config.Options.Bch, "bch", config.Options.Bch, "Detect Bitcoin Cash Fork")
+	pflag.BoolVar(&config.Options.Btg, "btg", config.Options.Btg, "Detect Bitcoin Gold Fork")
+	pflag.BoolVar(&config.Options.Lbtc, "lbtc", config.Options.Lbtc, "Detect Lightning Bitcoin Fork")
+	for _, name := range []string{"bcd", "bch", "btg", "lbtc"} {
+		pflag.CommandLine.MarkDeprecated(name, "use --forks "+strings.ToUpper(name))
+	}
 	pflag.Parse()
+	if config.Options.BinanceExtended && !pflag.CommandLine.Changed("binance-extended") {
+		log.Println("Config : option binance-extended is deprecated, the format is detected from the CSV header")
+	}
+	config.Options.mapDeprecatedForks()
 	return config, nil
 }
+
+// mapDeprecatedForks adds the Forks asked with the options replaced by forks
+func (o *Options) mapDeprecatedForks() {
+	for _, d := range []struct {
+		key    string
+		ticker string
+		asked  bool
+	}{
+		{"bcd", "BCD", o.Bcd},
+		{"bch", "BCH", o.Bch},
+		{"btg", "BTG", o.Btg},
+		{"lbtc", "LBTC", o.Lbtc},
+	} {
+		if !d.asked {
+			continue
+		}
+		log.Println("Config : option", d.key, "is deprecated, use forks: ["+d.ticker+"] instead")
+		found := false
+		for _, f := range o.Forks {
+			if strings.ToUpper(f) == d.ticker {
+				found = true
+			}
+		}
+		if !found {
+			o.Forks = append(o.Forks, d.ticker)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+package cfg
+
+import "testing"
+
+func TestOptions_mapDeprecatedForks(t *testing.T) {
+	o := Options{Bch: true, Btg: true, Forks: []string{"bch", "ETC"}}
+	o.mapDeprecatedForks()
+	if len(o.Forks) != 3 || o.Forks[2] != "BTG" {
+		t.Errorf("mapDeprecatedForks() Forks = %v, want bch, ETC and BTG", o.Forks)
+	}
+}
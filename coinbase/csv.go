@@ -5,13 +5,14 @@ import (
 	"encoding/csv"
 	"encoding/hex"
 	"io"
-	"log"
 	"os"
 	"strings"
 	"time"
 
 	"github.com/fiscafacile/CryptoFiscaFacile/category"
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
 	"github.com/fiscafacile/CryptoFiscaFacile/source"
+	"github.com/fiscafacile/CryptoFiscaFacile/utils"
 	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
 	"github.com/shopspring/decimal"
 )
@@ -29,6 +30,13 @@ type CsvTX struct {
 	Notes     string
 }
 
+// the fiat currency of the account is part of the column names
+func CSVFormats(fiat string) []utils.CSVFormat {
+	return []utils.CSVFormat{
+		{Version: "original", Columns: []string{"Timestamp", "Transaction Type", "Asset", "Quantity Transacted", fiat + " Spot Price at Transaction", fiat + " Subtotal", fiat + " Total (inclusive of fees)", fiat + " Fees", "Notes"}},
+	}
+}
+
 func (cb *Coinbase) ParseCSV(reader io.ReadSeeker, cat category.Category, account string) (err error) {
 	firstTimeUsed := time.Now()
 	lastTimeUsed := time.Date(2009, time.January, 1, 0, 0, 0, 0, time.UTC)
@@ -57,46 +65,56 @@ func (cb *Coinbase) ParseCSV(reader io.ReadSeeker, cat category.Category, accoun
 	if err == nil {
 		alreadyAsked := []string{}
 		var fiat string
-		for _, r := range records {
-			if r[0] == "Timestamp" {
-				fiat = strings.Split(r[4], " ")[0]
-			} else {
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				for _, h := range r {
+					if strings.HasSuffix(h, " Spot Price at Transaction") {
+						fiat = strings.Split(h, " ")[0]
+					}
+				}
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVFormats(fiat)...)
+				if err != nil {
+					return
+				}
+			}
+			if !l.IsHeader(r) {
 				tx := CsvTX{}
-				tx.Timestamp, err = time.Parse("2006-01-02T15:04:05Z", r[0])
+				tx.Timestamp, err = time.Parse("2006-01-02T15:04:05Z", l.Get(r, "Timestamp"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Timestamp : ", r[0])
+					diag.Add(SOURCE, n+1, "Timestamp", l.Get(r, "Timestamp"), "Error Parsing Timestamp")
 				}
 				hash := sha256.Sum256([]byte(SOURCE + tx.Timestamp.String()))
 				tx.ID = hex.EncodeToString(hash[:])
-				tx.Type = r[1]
-				tx.Asset = ReplaceAssets(r[2])
-				tx.Quantity, err = decimal.NewFromString(r[3])
+				tx.Type = l.Get(r, "Transaction Type")
+				tx.Asset = ReplaceAssets(l.Get(r, "Asset"))
+				tx.Quantity, err = decimal.NewFromString(l.Get(r, "Quantity Transacted"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Quantity : ", r[3])
+					diag.Add(SOURCE, n+1, "Quantity Transacted", l.Get(r, "Quantity Transacted"), "Error Parsing Quantity")
 				}
-				tx.SpotPrice, err = decimal.NewFromString(r[4])
+				tx.SpotPrice, err = decimal.NewFromString(l.Get(r, fiat+" Spot Price at Transaction"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing SpotPrice : ", r[4])
+					diag.Add(SOURCE, n+1, fiat+" Spot Price at Transaction", l.Get(r, fiat+" Spot Price at Transaction"), "Error Parsing SpotPrice")
 				}
-				if r[5] != "" {
-					tx.Subtotal, err = decimal.NewFromString(r[5])
+				if l.Get(r, fiat+" Subtotal") != "" {
+					tx.Subtotal, err = decimal.NewFromString(l.Get(r, fiat+" Subtotal"))
 					if err != nil {
-						log.Println(SOURCE, "Error Parsing Subtotal : ", r[5])
+						diag.Add(SOURCE, n+1, fiat+" Subtotal", l.Get(r, fiat+" Subtotal"), "Error Parsing Subtotal")
 					}
 				}
-				if r[6] != "" {
-					tx.Total, err = decimal.NewFromString(r[6])
+				if l.Get(r, fiat+" Total (inclusive of fees)") != "" {
+					tx.Total, err = decimal.NewFromString(l.Get(r, fiat+" Total (inclusive of fees)"))
 					if err != nil {
-						log.Println(SOURCE, "Error Parsing Total : ", r[6])
+						diag.Add(SOURCE, n+1, fiat+" Total (inclusive of fees)", l.Get(r, fiat+" Total (inclusive of fees)"), "Error Parsing Total")
 					}
 				}
-				if r[7] != "" {
-					tx.Fees, err = decimal.NewFromString(r[7])
+				if l.Get(r, fiat+" Fees") != "" {
+					tx.Fees, err = decimal.NewFromString(l.Get(r, fiat+" Fees"))
 					if err != nil {
-						log.Println(SOURCE, "Error Parsing Fees : ", r[7])
+						diag.Add(SOURCE, n+1, fiat+" Fees", l.Get(r, fiat+" Fees"), "Error Parsing Fees")
 					}
 				}
-				tx.Notes = r[8]
+				tx.Notes = l.Get(r, "Notes")
 				cb.CsvTXs = append(cb.CsvTXs, tx)
 				if tx.Timestamp.Before(firstTimeUsed) {
 					firstTimeUsed = tx.Timestamp
@@ -166,14 +184,6 @@ func (cb *Coinbase) ParseCSV(reader io.ReadSeeker, cat category.Category, accoun
 			}
 		}
 	}
-	cb.Sources["Coinbase"] = source.Source{
-		Crypto:        true,
-		AccountNumber: account,
-		OpeningDate:   firstTimeUsed,
-		ClosingDate:   lastTimeUsed,
-		LegalName:     "Coinbase Europe Limited",
-		Address:       "70 Sir John Rogerson’s Quay,\nDublin D02 R296\nIrlande",
-		URL:           "https://www.coinbase.com",
-	}
+	cb.Sources["Coinbase"] = source.New("Coinbase", account, firstTimeUsed, lastTimeUsed)
 	return
 }
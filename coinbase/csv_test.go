@@ -1,6 +1,7 @@
 package coinbase
 
 import (
+	"github.com/fiscafacile/CryptoFiscaFacile/category"
 	"strings"
 	"testing"
 )
@@ -35,7 +36,7 @@ func Test_CSVParseExemple(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			cb := New()
-			err := cb.ParseCSV(strings.NewReader(tt.csv))
+			err := cb.ParseCSV(strings.NewReader(tt.csv), *category.New(), "")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Coinbase.ParseCSV() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -3,10 +3,11 @@ package coinbasepro
 import (
 	"encoding/csv"
 	"io"
-	"log"
 	"time"
 
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
 	"github.com/fiscafacile/CryptoFiscaFacile/source"
+	"github.com/fiscafacile/CryptoFiscaFacile/utils"
 	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
 	"github.com/shopspring/decimal"
 )
@@ -23,6 +24,10 @@ type CsvAccountTX struct {
 	OrderID           string
 }
 
+var CSVAccountFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"portfolio", "type", "time", "amount", "balance", "amount/balance unit", "transfer id", "trade id", "order id"}, Positional: true, Date: "time", DateLayouts: []string{"2006-01-02T15:04:05.999Z"}, Amounts: []string{"amount", "balance"}},
+}
+
 func (cbp *CoinbasePro) ParseAccountCSV(reader io.ReadSeeker, account string) (err error) {
 	firstTimeUsed := time.Now()
 	lastTimeUsed := time.Date(2009, time.January, 1, 0, 0, 0, 0, time.UTC)
@@ -31,27 +36,34 @@ func (cbp *CoinbasePro) ParseAccountCSV(reader io.ReadSeeker, account string) (e
 	records, err := csvReader.ReadAll()
 	if err == nil {
 		alreadyAsked := []string{}
-		for _, r := range records {
-			if r[0] != "portfolio" {
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVAccountFormats...)
+				if err != nil {
+					return
+				}
+			}
+			if !l.IsHeader(r) {
 				tx := CsvAccountTX{}
-				tx.Portfolio = r[0]
-				tx.Type = r[1]
-				tx.Time, err = time.Parse("2006-01-02T15:04:05.999Z", r[2])
+				tx.Portfolio = l.Get(r, "portfolio")
+				tx.Type = l.Get(r, "type")
+				tx.Time, err = time.Parse("2006-01-02T15:04:05.999Z", l.Get(r, "time"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Time : ", r[2])
+					diag.Add(SOURCE, n+1, "time", l.Get(r, "time"), "Error Parsing Time")
 				}
-				tx.Amount, err = decimal.NewFromString(r[3])
+				tx.Amount, err = decimal.NewFromString(l.Get(r, "amount"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Amount : ", r[3])
+					diag.Add(SOURCE, n+1, "amount", l.Get(r, "amount"), "Error Parsing Amount")
 				}
-				tx.Balance, err = decimal.NewFromString(r[4])
+				tx.Balance, err = decimal.NewFromString(l.Get(r, "balance"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Balance : ", r[4])
+					diag.Add(SOURCE, n+1, "balance", l.Get(r, "balance"), "Error Parsing Balance")
 				}
-				tx.AmountBalanceUnit = r[5]
-				tx.TransferID = r[6]
-				tx.TradeID = r[7]
-				tx.OrderID = r[8]
+				tx.AmountBalanceUnit = l.Get(r, "amount/balance unit")
+				tx.TransferID = l.Get(r, "transfer id")
+				tx.TradeID = l.Get(r, "trade id")
+				tx.OrderID = l.Get(r, "order id")
 				cbp.CsvAccountTXs = append(cbp.CsvAccountTXs, tx)
 				if tx.Time.Before(firstTimeUsed) {
 					firstTimeUsed = tx.Time
@@ -119,15 +131,7 @@ func (cbp *CoinbasePro) ParseAccountCSV(reader io.ReadSeeker, account string) (e
 			cbp.Sources["CoinbasePro"] = src
 		}
 	} else {
-		cbp.Sources["CoinbasePro"] = source.Source{
-			Crypto:        true,
-			AccountNumber: account,
-			OpeningDate:   firstTimeUsed,
-			ClosingDate:   lastTimeUsed,
-			LegalName:     "Coinbase Europe Limited",
-			Address:       "70 Sir John Rogerson’s Quay,\nDublin D02 R296\nIrlande",
-			URL:           "https://pro.coinbase.com",
-		}
+		cbp.Sources["CoinbasePro"] = source.New("CoinbasePro", account, firstTimeUsed, lastTimeUsed)
 	}
 	return
 }
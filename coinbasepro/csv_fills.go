@@ -3,11 +3,12 @@ package coinbasepro
 import (
 	"encoding/csv"
 	"io"
-	"log"
 	"strings"
 	"time"
 
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
 	"github.com/fiscafacile/CryptoFiscaFacile/source"
+	"github.com/fiscafacile/CryptoFiscaFacile/utils"
 	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
 	"github.com/shopspring/decimal"
 )
@@ -27,6 +28,10 @@ type CsvFillsTX struct {
 	PriceFeeTotalUnit string
 }
 
+var CSVFillsFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"portfolio", "trade id", "product", "side", "created at", "size", "size unit", "price", "fee", "total", "price/fee/total unit"}, Positional: true, Date: "created at", DateLayouts: []string{"2006-01-02T15:04:05.999Z"}, Amounts: []string{"size", "price", "fee", "total"}},
+}
+
 func (cbp *CoinbasePro) ParseFillsCSV(reader io.ReadSeeker, account string) (err error) {
 	firstTimeUsed := time.Now()
 	lastTimeUsed := time.Date(2009, time.January, 1, 0, 0, 0, 0, time.UTC)
@@ -35,41 +40,48 @@ func (cbp *CoinbasePro) ParseFillsCSV(reader io.ReadSeeker, account string) (err
 	records, err := csvReader.ReadAll()
 	if err == nil {
 		alreadyAsked := []string{}
-		for _, r := range records {
-			if r[0] != "portfolio" {
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVFillsFormats...)
+				if err != nil {
+					return
+				}
+			}
+			if !l.IsHeader(r) {
 				tx := CsvFillsTX{}
-				tx.Portfolio = r[0]
-				tx.TradeID = r[1]
-				products := strings.Split(r[2], "-")
+				tx.Portfolio = l.Get(r, "portfolio")
+				tx.TradeID = l.Get(r, "trade id")
+				products := strings.Split(l.Get(r, "product"), "-")
 				tx.ProductLeft = products[0]
 				tx.ProductRight = products[1]
-				tx.Side = r[3]
-				tx.CreatedAt, err = time.Parse("2006-01-02T15:04:05.999Z", r[4])
+				tx.Side = l.Get(r, "side")
+				tx.CreatedAt, err = time.Parse("2006-01-02T15:04:05.999Z", l.Get(r, "created at"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing CreatedAt : ", r[4])
+					diag.Add(SOURCE, n+1, "created at", l.Get(r, "created at"), "Error Parsing CreatedAt")
 				}
-				tx.Size, err = decimal.NewFromString(r[5])
+				tx.Size, err = decimal.NewFromString(l.Get(r, "size"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Size : ", r[5])
+					diag.Add(SOURCE, n+1, "size", l.Get(r, "size"), "Error Parsing Size")
 				}
-				tx.SizeUnit = r[6]
-				tx.Price, err = decimal.NewFromString(r[7])
+				tx.SizeUnit = l.Get(r, "size unit")
+				tx.Price, err = decimal.NewFromString(l.Get(r, "price"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Price : ", r[7])
+					diag.Add(SOURCE, n+1, "price", l.Get(r, "price"), "Error Parsing Price")
 				}
-				if r[8] != "" {
-					tx.Fee, err = decimal.NewFromString(r[8])
+				if l.Get(r, "fee") != "" {
+					tx.Fee, err = decimal.NewFromString(l.Get(r, "fee"))
 					if err != nil {
-						log.Println(SOURCE, "Error Parsing Fee : ", r[8])
+						diag.Add(SOURCE, n+1, "fee", l.Get(r, "fee"), "Error Parsing Fee")
 					}
 				}
-				if r[9] != "" {
-					tx.Total, err = decimal.NewFromString(r[9])
+				if l.Get(r, "total") != "" {
+					tx.Total, err = decimal.NewFromString(l.Get(r, "total"))
 					if err != nil {
-						log.Println(SOURCE, "Error Parsing Total : ", r[9])
+						diag.Add(SOURCE, n+1, "total", l.Get(r, "total"), "Error Parsing Total")
 					}
 				}
-				tx.PriceFeeTotalUnit = r[10]
+				tx.PriceFeeTotalUnit = l.Get(r, "price/fee/total unit")
 				cbp.CsvFillsTXs = append(cbp.CsvFillsTXs, tx)
 				if tx.CreatedAt.Before(firstTimeUsed) {
 					firstTimeUsed = tx.CreatedAt
@@ -105,15 +117,7 @@ func (cbp *CoinbasePro) ParseFillsCSV(reader io.ReadSeeker, account string) (err
 			cbp.Sources["CoinbasePro"] = src
 		}
 	} else {
-		cbp.Sources["CoinbasePro"] = source.Source{
-			Crypto:        true,
-			AccountNumber: account,
-			OpeningDate:   firstTimeUsed,
-			ClosingDate:   lastTimeUsed,
-			LegalName:     "Coinbase Europe Limited",
-			Address:       "70 Sir John Rogerson’s Quay,\nDublin D02 R296\nIrlande",
-			URL:           "https://pro.coinbase.com",
-		}
+		cbp.Sources["CoinbasePro"] = source.New("CoinbasePro", account, firstTimeUsed, lastTimeUsed)
 	}
 	return
 }
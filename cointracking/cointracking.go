@@ -0,0 +1,19 @@
+package cointracking
+
+import (
+	"github.com/fiscafacile/CryptoFiscaFacile/source"
+	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
+)
+
+type CoinTracking struct {
+	CsvTXs        []CsvTX
+	TXsByCategory wallet.TXsByCategory
+	Sources       source.Sources
+}
+
+func New() *CoinTracking {
+	ct := &CoinTracking{}
+	ct.TXsByCategory = make(map[string]wallet.TXs)
+	ct.Sources = make(source.Sources)
+	return ct
+}
@@ -0,0 +1,175 @@
+package cointracking
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
+	"github.com/fiscafacile/CryptoFiscaFacile/source"
+	"github.com/fiscafacile/CryptoFiscaFacile/utils"
+	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
+	"github.com/shopspring/decimal"
+)
+
+type CsvTX struct {
+	ID           string
+	Type         string
+	BuyAmount    decimal.Decimal
+	BuyCurrency  string
+	SellAmount   decimal.Decimal
+	SellCurrency string
+	Fee          decimal.Decimal
+	FeeCurrency  string
+	Exchange     string
+	Group        string
+	Comment      string
+	Date         time.Time
+}
+
+func parseDate(s string) (t time.Time, err error) {
+	for _, layout := range []string{"02.01.2006 15:04:05", "02.01.2006 15:04", "2006-01-02 15:04:05", "2006-01-02 15:04", "01/02/2006 15:04:05", "01/02/2006 15:04"} {
+		t, err = time.Parse(layout, s)
+		if err == nil {
+			return
+		}
+	}
+	return
+}
+
+var CSVFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"Type", "Buy", "Cur.", "Sell", "Cur.#2", "Fee", "Cur.#3", "Exchange", "Group", "Comment", "Date"}},
+	{Version: "trade-id", Columns: []string{"Type", "Buy", "Cur.", "Sell", "Cur.#2", "Fee", "Cur.#3", "Exchange", "Group", "Comment", "Date", "Trade ID"}},
+	{Version: "tx-id", Columns: []string{"Type", "Buy", "Cur.", "Sell", "Cur.#2", "Fee", "Cur.#3", "Exchange", "Group", "Comment", "Date", "Tx-ID"}},
+}
+
+// ParseCSV reads the CoinTracking Trade List export, the "Cur." columns are told apart by their order
+func (ct *CoinTracking) ParseCSV(reader io.Reader) (err error) {
+	const SOURCE = "CoinTracking CSV :"
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return errors.New(SOURCE + " " + err.Error())
+	}
+	var l utils.CSVLayout
+	alreadyAsked := []string{}
+	for n, r := range records {
+		if n == 0 {
+			l, err = utils.DetectCSVLayout(SOURCE, r, CSVFormats...)
+			if err != nil {
+				return
+			}
+			continue
+		}
+		if l.IsHeader(r) {
+			continue
+		}
+		if len(r) < 11 {
+			diag.Add(SOURCE, n+1, "", strings.Join(r, ","), "Missing fields")
+			continue
+		}
+		amount := func(col string) (d decimal.Decimal) {
+			s := l.Get(r, col)
+			if s != "" && s != "-" {
+				d, err = decimal.NewFromString(strings.ReplaceAll(s, ",", ""))
+				if err != nil {
+					diag.Add(SOURCE, n+1, col, s, "Error Parsing Amount")
+				}
+			}
+			return
+		}
+		tx := CsvTX{}
+		tx.Type = l.Get(r, "Type")
+		tx.BuyAmount = amount("Buy")
+		tx.BuyCurrency = l.Get(r, "Cur.")
+		tx.SellAmount = amount("Sell")
+		tx.SellCurrency = l.Get(r, "Cur.#2")
+		tx.Fee = amount("Fee")
+		tx.FeeCurrency = l.Get(r, "Cur.#3")
+		tx.Exchange = l.Get(r, "Exchange")
+		tx.Group = l.Get(r, "Group")
+		tx.Comment = l.Get(r, "Comment")
+		tx.Date, err = parseDate(l.Get(r, "Date"))
+		if err != nil {
+			diag.Add(SOURCE, n+1, "Date", l.Get(r, "Date"), "Error Parsing Date")
+			continue
+		}
+		tx.ID = l.Get(r, "Trade ID")
+		if tx.ID == "" {
+			tx.ID = l.Get(r, "Tx-ID")
+		}
+		if tx.ID == "" {
+			tx.ID = "CoinTracking-" + tx.Date.UTC().Format("20060102150405") + "-" + strconv.Itoa(n+1)
+		}
+		ct.CsvTXs = append(ct.CsvTXs, tx)
+		err = ct.add(SOURCE, tx, &alreadyAsked)
+		if err != nil {
+			diag.Add(SOURCE, n+1, "", tx.ID, err.Error())
+		}
+	}
+	return nil
+}
+
+func (ct *CoinTracking) add(src string, tx CsvTX, alreadyAsked *[]string) error {
+	loc, _ := source.Lookup(tx.Exchange)
+	t := wallet.TX{Timestamp: tx.Date, ID: tx.ID, Note: "CoinTracking CSV " + loc + " : " + tx.Type + " " + tx.Comment}
+	t.Items = make(map[string]wallet.Currencies)
+	buy := wallet.Currency{Code: tx.BuyCurrency, Amount: tx.BuyAmount, Location: loc}
+	sell := wallet.Currency{Code: tx.SellCurrency, Amount: tx.SellAmount, Location: loc}
+	category := ""
+	switch tx.Type {
+	case "Trade":
+		t.Items["From"] = append(t.Items["From"], sell)
+		t.Items["To"] = append(t.Items["To"], buy)
+		category = "Exchanges"
+	case "Deposit":
+		t.Items["To"] = append(t.Items["To"], buy)
+		category = "Deposits"
+	case "Withdrawal", "Spend":
+		t.Items["From"] = append(t.Items["From"], sell)
+		category = "Withdrawals"
+	case "Mining", "Mining (commercial)":
+		t.Items["To"] = append(t.Items["To"], buy)
+		category = "Minings"
+	case "Airdrop", "Airdrop (non taxable)":
+		t.Items["To"] = append(t.Items["To"], buy)
+		category = "AirDrops"
+	case "Staking", "Interest Income", "Lending Income", "Dividends Income":
+		t.Items["To"] = append(t.Items["To"], buy)
+		category = "Interests"
+	case "Income", "Income (non taxable)", "Reward / Bonus":
+		t.Items["To"] = append(t.Items["To"], buy)
+		category = "Referrals"
+	case "Gift/Tip":
+		t.Items["To"] = append(t.Items["To"], buy)
+		category = "Gifts"
+	case "Gift", "Donation":
+		t.Items["From"] = append(t.Items["From"], sell)
+		category = "Gifts"
+	case "Lost", "Stolen":
+		t.Items["Lost"] = append(t.Items["Lost"], sell)
+		category = "Withdrawals"
+	case "Other Fee", "Margin Fee", "Borrowing Fee":
+		t.Items["Fee"] = append(t.Items["Fee"], sell)
+		category = "Fees"
+	default:
+		*alreadyAsked = wallet.AskForHelp(src+" "+tx.Type, tx, *alreadyAsked)
+		return nil
+	}
+	if !tx.Fee.IsZero() && tx.FeeCurrency != "" {
+		t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: tx.FeeCurrency, Amount: tx.Fee, Location: loc})
+	}
+	err := t.Validate(category)
+	if err != nil {
+		return err
+	}
+	ct.TXsByCategory[category] = append(ct.TXsByCategory[category], t)
+	if loc != "" {
+		ct.Sources.AddUsage(loc, "", tx.Date)
+	}
+	return nil
+}
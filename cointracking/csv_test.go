@@ -0,0 +1,53 @@
+package cointracking
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_CSVParseExemple(t *testing.T) {
+	const header = "\"Type\",\"Buy\",\"Cur.\",\"Sell\",\"Cur.\",\"Fee\",\"Cur.\",\"Exchange\",\"Group\",\"Comment\",\"Date\"\n"
+	tests := []struct {
+		name     string
+		csv      string
+		category string
+		location string
+	}{
+		{
+			name:     "ParseCSV Trade",
+			csv:      header + "\"Trade\",\"0.5\",\"BTC\",\"5000\",\"EUR\",\"10\",\"EUR\",\"Kraken\",\"\",\"\",\"12.03.2020 10:15\"",
+			category: "Exchanges",
+			location: "Kraken",
+		},
+		{
+			name:     "ParseCSV Mining",
+			csv:      header + "\"Mining\",\"0.01\",\"ETH\",\"\",\"\",\"\",\"\",\"Ledger\",\"\",\"\",\"13.03.2020 10:15:00\"",
+			category: "Minings",
+			location: "Ledger",
+		},
+		{
+			name:     "ParseCSV Withdrawal",
+			csv:      header + "\"Withdrawal\",\"\",\"\",\"0.5\",\"BTC\",\"0.0005\",\"BTC\",\"Crypto.com\",\"\",\"\",\"2020-03-14 10:15:00\"",
+			category: "Withdrawals",
+			location: "CdC App",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ct := New()
+			err := ct.ParseCSV(strings.NewReader(tt.csv))
+			if err != nil {
+				t.Fatalf("CoinTracking.ParseCSV() error = %v", err)
+			}
+			txs := ct.TXsByCategory[tt.category]
+			if len(txs) != 1 {
+				t.Fatalf("CoinTracking.ParseCSV() %s = %v, want 1 TX", tt.category, ct.TXsByCategory)
+			}
+			for _, i := range txs[0].Items {
+				if i[0].Location != tt.location {
+					t.Errorf("CoinTracking.ParseCSV() Location = %v, want %v", i[0].Location, tt.location)
+				}
+			}
+		})
+	}
+}
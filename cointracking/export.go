@@ -0,0 +1,93 @@
+package cointracking
+
+import (
+	"io"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
+)
+
+var types = map[string]string{
+	"AirDrops":          "Airdrop",
+	"CashIn":            "Trade",
+	"CashOut":           "Trade",
+	"CommercialRebates": "Reward / Bonus",
+	"Deposits":          "Deposit",
+	"Exchanges":         "Trade",
+	"Fees":              "Other Fee",
+	"Forks":             "Income",
+	"Interests":         "Interest Income",
+	"Minings":           "Mining",
+	"Referrals":         "Reward / Bonus",
+	"Withdrawals":       "Withdrawal",
+}
+
+func row(tx wallet.TX, kind string, buy, sell, fee *wallet.Currency) []string {
+	ba, bc := wallet.ExportAmount(buy)
+	sa, sc := wallet.ExportAmount(sell)
+	fa, fc := wallet.ExportAmount(fee)
+	exchange := ""
+	for _, c := range []*wallet.Currency{sell, buy, fee} {
+		if c != nil && c.Location != "" {
+			exchange = c.Location
+			break
+		}
+	}
+	return []string{kind, ba, bc, sa, sc, fa, fc, exchange, "", tx.Note + " " + tx.ID, tx.Timestamp.UTC().Format("2006-01-02 15:04:05")}
+}
+
+func rows(tx wallet.TX) (lines [][]string) {
+	fees := tx.Items["Fee"]
+	var fee *wallet.Currency
+	if len(fees) > 0 {
+		fee = &fees[0]
+		fees = fees[1:]
+	}
+	if tx.Category == "Transfers" {
+		// One Withdrawal from the source location and one Deposit to the destination
+		for i := range tx.Items["From"] {
+			lines = append(lines, row(tx, "Withdrawal", nil, &tx.Items["From"][i], fee))
+			fee = nil
+		}
+		for i := range tx.Items["To"] {
+			lines = append(lines, row(tx, "Deposit", &tx.Items["To"][i], nil, nil))
+		}
+	} else {
+		kind := types[tx.Category]
+		if tx.Category == "Gifts" {
+			kind = "Gift/Tip"
+			if len(tx.Items["From"]) > 0 {
+				kind = "Gift"
+			}
+		}
+		n := len(tx.Items["From"])
+		if len(tx.Items["To"]) > n {
+			n = len(tx.Items["To"])
+		}
+		for i := 0; i < n; i++ {
+			var buy, sell *wallet.Currency
+			if i < len(tx.Items["From"]) {
+				sell = &tx.Items["From"][i]
+			}
+			if i < len(tx.Items["To"]) {
+				buy = &tx.Items["To"][i]
+			}
+			lines = append(lines, row(tx, kind, buy, sell, fee))
+			fee = nil
+		}
+	}
+	if fee != nil {
+		fees = append([]wallet.Currency{*fee}, fees...)
+	}
+	for i := range fees {
+		lines = append(lines, row(tx, "Other Fee", nil, &fees[i], nil))
+	}
+	for i := range tx.Items["Lost"] {
+		lines = append(lines, row(tx, "Lost", nil, &tx.Items["Lost"][i], nil))
+	}
+	return
+}
+
+// ExportCSV writes the CoinTracking custom CSV import format
+func ExportCSV(writer io.Writer, txs wallet.TXsByCategory) error {
+	return txs.RowsToCSV(writer, []string{"Type", "Buy Amount", "Buy Currency", "Sell Amount", "Sell Currency", "Fee", "Fee Currency", "Exchange", "Trade-Group", "Comment", "Date"}, rows)
+}
@@ -20,6 +20,7 @@ type apiEx struct {
 	doneWit            chan error
 	clientSpotTra      *resty.Client
 	doneSpotTra        chan error
+	clientBal          *resty.Client
 	basePath           string
 	apiKey             string
 	secretKey          string
@@ -57,6 +58,9 @@ func (cdc *CryptoCom) NewExchangeAPI(apiKey, secretKey string, debug bool) {
 	cdc.apiEx.clientSpotTra.SetRetryCount(3).SetRetryWaitTime(1 * time.Second)
 	cdc.apiEx.clientSpotTra.SetDebug(debug)
 	cdc.apiEx.doneSpotTra = make(chan error)
+	cdc.apiEx.clientBal = resty.New()
+	cdc.apiEx.clientBal.SetRetryCount(3)
+	cdc.apiEx.clientBal.SetDebug(debug)
 	cdc.apiEx.basePath = "https://api.crypto.com/v2/"
 	cdc.apiEx.apiKey = apiKey
 	cdc.apiEx.secretKey = secretKey
@@ -0,0 +1,51 @@
+package cryptocom
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
+	"github.com/shopspring/decimal"
+)
+
+type GetAccountSummaryResp struct {
+	ID     int64  `json:"id"`
+	Method string `json:"method"`
+	Code   int    `json:"code"`
+	Result struct {
+		Accounts []struct {
+			Balance   float64 `json:"balance"`
+			Available float64 `json:"available"`
+			Order     float64 `json:"order"`
+			Stake     float64 `json:"stake"`
+			Currency  string  `json:"currency"`
+		} `json:"accounts"`
+	} `json:"result"`
+}
+
+func (cdc *CryptoCom) GetAPIExchangeBalances() (balances wallet.WalletCurrencies, err error) {
+	const SOURCE = "Crypto.com Exchange API Balances :"
+	balances = make(wallet.WalletCurrencies)
+	method := "private/get-account-summary"
+	body := make(map[string]interface{})
+	body["method"] = method
+	body["params"] = map[string]interface{}{}
+	cdc.apiEx.sign(body)
+	resp, err := cdc.apiEx.clientBal.R().
+		SetBody(body).
+		SetResult(&GetAccountSummaryResp{}).
+		SetError(&ErrorResp{}).
+		Post(cdc.apiEx.basePath + method)
+	if err != nil {
+		return balances, errors.New(SOURCE + " Error Requesting")
+	}
+	if resp.StatusCode() > 300 {
+		return balances, errors.New(SOURCE + " Error StatusCode" + strconv.Itoa(resp.StatusCode()))
+	}
+	for _, acc := range (*resp.Result().(*GetAccountSummaryResp)).Result.Accounts {
+		if acc.Balance != 0 {
+			balances[acc.Currency] = balances[acc.Currency].Add(decimal.NewFromFloat(acc.Balance))
+		}
+	}
+	return balances, nil
+}
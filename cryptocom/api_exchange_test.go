@@ -34,7 +34,7 @@ func Test_API_Exchange_sign(t *testing.T) {
 	}
 	for _, tt := range tests {
 		cdc := New()
-		cdc.NewExchangeAPI(tt.apiKey, tt.apiSecret)
+		cdc.NewExchangeAPI(tt.apiKey, tt.apiSecret, false)
 		t.Run(tt.name, func(t *testing.T) {
 			cdc.apiEx.sign(tt.args.body)
 			if tt.args.body["sig"] != tt.wantSig {
@@ -71,15 +71,7 @@ func (cdc *CryptoCom) WaitFinish(account string) error {
 			cdc.Sources["CdC Exchange"] = src
 		}
 	} else {
-		cdc.Sources["CdC Exchange"] = source.Source{
-			Crypto:        true,
-			AccountNumber: account,
-			OpeningDate:   cdc.apiEx.firstTimeUsed,
-			ClosingDate:   cdc.apiEx.lastTimeUsed,
-			LegalName:     "MCO Malta DAX Limited",
-			Address:       "Level 7, Spinola Park, Triq Mikiel Ang Borg,\nSt Julian's SPK 1000,\nMalte",
-			URL:           "https://crypto.com/exchange",
-		}
+		cdc.Sources["CdC Exchange"] = source.New("CdC Exchange", account, cdc.apiEx.firstTimeUsed, cdc.apiEx.lastTimeUsed)
 	}
 	return err
 }
@@ -3,10 +3,10 @@ package cryptocom
 import (
 	"encoding/csv"
 	"io"
-	"log"
 	"time"
 
 	"github.com/fiscafacile/CryptoFiscaFacile/category"
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
 	"github.com/fiscafacile/CryptoFiscaFacile/source"
 	"github.com/fiscafacile/CryptoFiscaFacile/utils"
 	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
@@ -27,6 +27,10 @@ type csvAppCryptoTX struct {
 	Kind            string
 }
 
+var CSVAppCryptoFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"Timestamp (UTC)", "Transaction Description", "Currency", "Amount", "To Currency", "To Amount", "Native Currency", "Native Amount", "Native Amount (in USD)", "Transaction Kind"}, Positional: true, Date: "Timestamp (UTC)", DateLayouts: []string{"2006-01-02 15:04:05"}, Amounts: []string{"Amount", "To Amount", "Native Amount", "Native Amount (in USD)"}},
+}
+
 func (cdc *CryptoCom) ParseCSVAppCrypto(reader io.Reader, cat category.Category, account string) (err error) {
 	firstTimeUsed := time.Now()
 	lastTimeUsed := time.Date(2009, time.January, 1, 0, 0, 0, 0, time.UTC)
@@ -38,32 +42,39 @@ func (cdc *CryptoCom) ParseCSVAppCrypto(reader io.Reader, cat category.Category,
 	records, err := csvReader.ReadAll()
 	if err == nil {
 		alreadyAsked := []string{}
-		for _, r := range records {
-			if r[0] != "Timestamp (UTC)" {
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVAppCryptoFormats...)
+				if err != nil {
+					return
+				}
+			}
+			if !l.IsHeader(r) {
 				tx := csvAppCryptoTX{}
-				tx.Timestamp, err = time.Parse("2006-01-02 15:04:05", r[0])
+				tx.Timestamp, err = time.Parse("2006-01-02 15:04:05", l.Get(r, "Timestamp (UTC)"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Timestamp", r[0])
+					diag.Add(SOURCE, n+1, "Timestamp (UTC)", l.Get(r, "Timestamp (UTC)"), "Error Parsing Timestamp")
 				}
 				tx.ID = utils.GetUniqueID(SOURCE + tx.Timestamp.String())
-				tx.Description = r[1]
-				tx.Currency = r[2]
-				tx.Amount, err = decimal.NewFromString(r[3])
+				tx.Description = l.Get(r, "Transaction Description")
+				tx.Currency = l.Get(r, "Currency")
+				tx.Amount, err = decimal.NewFromString(l.Get(r, "Amount"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Amount", r[3])
+					diag.Add(SOURCE, n+1, "Amount", l.Get(r, "Amount"), "Error Parsing Amount")
 				}
-				tx.ToCurrency = r[4]
-				tx.ToAmount, _ = decimal.NewFromString(r[5])
-				tx.NativeCurrency = r[6]
-				tx.NativeAmount, err = decimal.NewFromString(r[7])
+				tx.ToCurrency = l.Get(r, "To Currency")
+				tx.ToAmount, _ = decimal.NewFromString(l.Get(r, "To Amount"))
+				tx.NativeCurrency = l.Get(r, "Native Currency")
+				tx.NativeAmount, err = decimal.NewFromString(l.Get(r, "Native Amount"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing NativeAmount", r[7])
+					diag.Add(SOURCE, n+1, "Native Amount", l.Get(r, "Native Amount"), "Error Parsing NativeAmount")
 				}
-				tx.NativeAmountUSD, err = decimal.NewFromString(r[8])
+				tx.NativeAmountUSD, err = decimal.NewFromString(l.Get(r, "Native Amount (in USD)"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing NativeAmountUSD", r[8])
+					diag.Add(SOURCE, n+1, "Native Amount (in USD)", l.Get(r, "Native Amount (in USD)"), "Error Parsing NativeAmountUSD")
 				}
-				tx.Kind = r[9]
+				tx.Kind = l.Get(r, "Transaction Kind")
 				cdc.csvAppCryptoTXs = append(cdc.csvAppCryptoTXs, tx)
 				if tx.Timestamp.Before(firstTimeUsed) {
 					firstTimeUsed = tx.Timestamp
@@ -264,58 +275,18 @@ func (cdc *CryptoCom) ParseCSVAppCrypto(reader io.Reader, cat category.Category,
 			}
 		}
 	}
-	cdc.Sources["CdC App"] = source.Source{
-		Crypto:        true,
-		AccountNumber: account,
-		OpeningDate:   firstTimeUsed,
-		ClosingDate:   lastTimeUsed,
-		LegalName:     "MCO Malta DAX Limited",
-		Address:       "Level 7, Spinola Park, Triq Mikiel Ang Borg,\nSt Julian's SPK 1000,\nMalte",
-		URL:           "https://crypto.com/app",
-	}
+	cdc.Sources["CdC App"] = source.New("CdC App", account, firstTimeUsed, lastTimeUsed)
 	if hasCashback {
 		switchGBLT := time.Date(2020, 12, 27, 3, 0, 0, 0, time.UTC)
 		if firstTimeCashback.Before(switchGBLT) {
 			if lastTimeCashback.Before(switchGBLT) {
-				cdc.Sources["CdC MCO Card GB"] = source.Source{
-					Crypto:        false,
-					AccountNumber: "votre IBAN GBxxxxx",
-					OpeningDate:   firstTimeCashback,
-					ClosingDate:   lastTimeCashback,
-					LegalName:     "MCO Malta DAX Limited The Currency Cloud)",
-					Address:       "12 Steward Street, The Steward Building, London, E1 6FQ, Royaume-Uni",
-					URL:           "https://crypto.com/cards",
-				}
+				cdc.Sources["CdC MCO Card GB"] = source.New("CdC MCO Card GB", "votre IBAN GBxxxxx", firstTimeCashback, lastTimeCashback)
 			} else {
-				cdc.Sources["CdC MCO Card GB"] = source.Source{
-					Crypto:        false,
-					AccountNumber: "votre IBAN GBxxxxx",
-					OpeningDate:   firstTimeCashback,
-					ClosingDate:   switchGBLT,
-					LegalName:     "MCO Malta DAX Limited The Currency Cloud)",
-					Address:       "12 Steward Street, The Steward Building, London, E1 6FQ, Royaume-Uni",
-					URL:           "https://crypto.com/cards",
-				}
-				cdc.Sources["CdC MCO Card LT"] = source.Source{
-					Crypto:        false,
-					AccountNumber: "votre IBAN LTxxxxx",
-					OpeningDate:   switchGBLT,
-					ClosingDate:   lastTimeCashback,
-					LegalName:     "MCO Malta DAX Limited (Transactive Systems UAB)",
-					Address:       "Jogailos St 9, Vilnius, 01103, Lithuania",
-					URL:           "https://crypto.com/cards",
-				}
+				cdc.Sources["CdC MCO Card GB"] = source.New("CdC MCO Card GB", "votre IBAN GBxxxxx", firstTimeCashback, switchGBLT)
+				cdc.Sources["CdC MCO Card LT"] = source.New("CdC MCO Card LT", "votre IBAN LTxxxxx", switchGBLT, lastTimeCashback)
 			}
 		} else {
-			cdc.Sources["CdC MCO Card LT"] = source.Source{
-				Crypto:        false,
-				AccountNumber: "votre IBAN LTxxxxx",
-				OpeningDate:   firstTimeCashback,
-				ClosingDate:   lastTimeCashback,
-				LegalName:     "MCO Malta DAX Limited (Transactive Systems UAB)",
-				Address:       "Jogailos St 9, Vilnius, 01103, Lithuania",
-				URL:           "https://crypto.com/cards",
-			}
+			cdc.Sources["CdC MCO Card LT"] = source.New("CdC MCO Card LT", "votre IBAN LTxxxxx", firstTimeCashback, lastTimeCashback)
 		}
 	}
 	return
@@ -1,6 +1,7 @@
 package cryptocom
 
 import (
+	"github.com/fiscafacile/CryptoFiscaFacile/category"
 	"strings"
 	"testing"
 )
@@ -25,7 +26,7 @@ func Test_CSVParseExemple(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			cdc := New()
-			err := cdc.ParseCSVAppCrypto(strings.NewReader(tt.csv))
+			err := cdc.ParseCSVAppCrypto(strings.NewReader(tt.csv), *category.New(), "")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("CryptoCom.ParseCSVAppCrypto() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -3,10 +3,11 @@ package cryptocom
 import (
 	"encoding/csv"
 	"io"
-	"log"
 	"strings"
 	"time"
 
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
+	"github.com/fiscafacile/CryptoFiscaFacile/utils"
 	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
 	"github.com/shopspring/decimal"
 )
@@ -30,40 +31,51 @@ type csvExSpotTradeTX struct {
 	FeeCurrency        string
 }
 
+var CSVExSpotTradeFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"account_type", "order_id", "trade_id", "create_time_utc", "symbol", "side", "liquditiy_indicator", "traded_price", "traded_quantity", "fee", "fee_currency"}, Positional: true, Date: "create_time_utc", DateLayouts: []string{"2006-01-02 15:04:05.000"}, Amounts: []string{"traded_price", "traded_quantity", "fee"}},
+}
+
 func (cdc *CryptoCom) ParseCSVExchangeSpotTrade(reader io.Reader) (err error) {
 	const SOURCE = "Crypto.com Exchange Spot Trade CSV :"
 	alreadyAsked := []string{}
 	csvReader := csv.NewReader(reader)
 	records, err := csvReader.ReadAll()
 	if err == nil {
-		for _, r := range records {
-			if r[0] != "account_type" {
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVExSpotTradeFormats...)
+				if err != nil {
+					return
+				}
+			}
+			if !l.IsHeader(r) {
 				tx := csvExSpotTradeTX{}
-				tx.AccountType = r[0]
-				tx.OrderID = r[1]
-				tx.TradeID = r[2]
-				tx.CreateTimeUTC, err = time.Parse("2006-01-02 15:04:05.000", r[3])
+				tx.AccountType = l.Get(r, "account_type")
+				tx.OrderID = l.Get(r, "order_id")
+				tx.TradeID = l.Get(r, "trade_id")
+				tx.CreateTimeUTC, err = time.Parse("2006-01-02 15:04:05.000", l.Get(r, "create_time_utc"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing CreateTimeUTC", r[3])
+					diag.Add(SOURCE, n+1, "create_time_utc", l.Get(r, "create_time_utc"), "Error Parsing CreateTimeUTC")
 				}
-				symbol := strings.Split(r[4], "_")
+				symbol := strings.Split(l.Get(r, "symbol"), "_")
 				tx.SymbolLeft = symbol[0]
 				tx.SymbolRight = symbol[1]
-				tx.Side = r[5]
-				tx.LiquidityIndicator = r[6]
-				tx.TradedPrice, err = decimal.NewFromString(r[7])
+				tx.Side = l.Get(r, "side")
+				tx.LiquidityIndicator = l.Get(r, "liquditiy_indicator")
+				tx.TradedPrice, err = decimal.NewFromString(l.Get(r, "traded_price"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing TradedPrice", r[7])
+					diag.Add(SOURCE, n+1, "traded_price", l.Get(r, "traded_price"), "Error Parsing TradedPrice")
 				}
-				tx.TradedQuantity, err = decimal.NewFromString(r[8])
+				tx.TradedQuantity, err = decimal.NewFromString(l.Get(r, "traded_quantity"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing TradedQuantity", r[8])
+					diag.Add(SOURCE, n+1, "traded_quantity", l.Get(r, "traded_quantity"), "Error Parsing TradedQuantity")
 				}
-				tx.Fee, err = decimal.NewFromString(r[9])
+				tx.Fee, err = decimal.NewFromString(l.Get(r, "fee"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Fee", r[9])
+					diag.Add(SOURCE, n+1, "fee", l.Get(r, "fee"), "Error Parsing Fee")
 				}
-				tx.FeeCurrency = r[10]
+				tx.FeeCurrency = l.Get(r, "fee_currency")
 				cdc.csvExSpotTradeTXs = append(cdc.csvExSpotTradeTXs, tx)
 				// Fill txsByCategory
 				t := wallet.TX{Timestamp: tx.CreateTimeUTC, ID: tx.OrderID + "-" + tx.TradeID, Note: SOURCE + " " + tx.Side + " " + tx.LiquidityIndicator}
@@ -3,9 +3,9 @@ package cryptocom
 import (
 	"encoding/csv"
 	"io"
-	"log"
 	"time"
 
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
 	"github.com/fiscafacile/CryptoFiscaFacile/utils"
 	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
 	"github.com/shopspring/decimal"
@@ -24,31 +24,42 @@ type csvExStakeTX struct {
 	Status   string
 }
 
+var CSVExStakeFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"create_time_utc", "stake_currency", "stake_amount", "apr", "interest_currency", "interest_amount", "status"}, Positional: true, Date: "create_time_utc", DateLayouts: []string{"2006-01-02 15:04:05.000"}, Amounts: []string{"stake_amount", "interest_amount"}},
+}
+
 func (cdc *CryptoCom) ParseCSVExchangeStake(reader io.Reader) (err error) {
 	const SOURCE = "Crypto.com Exchange Stake CSV :"
 	csvReader := csv.NewReader(reader)
 	records, err := csvReader.ReadAll()
 	if err == nil {
-		for _, r := range records {
-			if r[0] != "create_time_utc" {
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVExStakeFormats...)
+				if err != nil {
+					return
+				}
+			}
+			if !l.IsHeader(r) {
 				tx := csvExStakeTX{}
-				tx.Time, err = time.Parse("2006-01-02 15:04:05.000", r[0])
+				tx.Time, err = time.Parse("2006-01-02 15:04:05.000", l.Get(r, "create_time_utc"))
 				if err != nil {
-					log.Println("Error Parsing Time : ", r[0])
+					diag.Add(SOURCE, n+1, "create_time_utc", l.Get(r, "create_time_utc"), "Error Parsing Time")
 				}
 				tx.ID = utils.GetUniqueID(SOURCE + tx.Time.String())
-				tx.Stake.Code = r[1]
-				tx.Stake.Amount, err = decimal.NewFromString(r[2])
+				tx.Stake.Code = l.Get(r, "stake_currency")
+				tx.Stake.Amount, err = decimal.NewFromString(l.Get(r, "stake_amount"))
 				if err != nil {
-					log.Println("Error Parsing Stake.Amount : ", r[2])
+					diag.Add(SOURCE, n+1, "stake_amount", l.Get(r, "stake_amount"), "Error Parsing Stake.Amount")
 				}
-				tx.Apr = r[3]
-				tx.Interest.Code = r[4]
-				tx.Interest.Amount, err = decimal.NewFromString(r[5])
+				tx.Apr = l.Get(r, "apr")
+				tx.Interest.Code = l.Get(r, "interest_currency")
+				tx.Interest.Amount, err = decimal.NewFromString(l.Get(r, "interest_amount"))
 				if err != nil {
-					log.Println("Error Parsing Interest.Amount : ", r[5])
+					diag.Add(SOURCE, n+1, "interest_amount", l.Get(r, "interest_amount"), "Error Parsing Interest.Amount")
 				}
-				tx.Status = r[6]
+				tx.Status = l.Get(r, "status")
 				cdc.csvExStakeTXs = append(cdc.csvExStakeTXs, tx)
 				t := wallet.TX{Timestamp: tx.Time, ID: tx.ID, Note: SOURCE + " " + tx.Stake.Amount.String() + " " + tx.Stake.Code + " " + tx.Apr}
 				t.Items = make(map[string]wallet.Currencies)
@@ -3,9 +3,9 @@ package cryptocom
 import (
 	"encoding/csv"
 	"io"
-	"log"
 	"time"
 
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
 	"github.com/fiscafacile/CryptoFiscaFacile/utils"
 	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
 	"github.com/shopspring/decimal"
@@ -23,25 +23,36 @@ type csvExSuperchargerTX struct {
 	Description string
 }
 
+var CSVExSuperchargerFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"create_time_utc", "currency", "amount", "description"}, Positional: true, Date: "create_time_utc", DateLayouts: []string{"2006-01-02 15:04:05"}, Amounts: []string{"amount"}},
+}
+
 func (cdc *CryptoCom) ParseCSVExchangeSupercharger(reader io.Reader) (err error) {
 	const SOURCE = "Crypto.com Exchange SuperCharger CSV :"
 	csvReader := csv.NewReader(reader)
 	records, err := csvReader.ReadAll()
 	if err == nil {
-		for _, r := range records {
-			if r[0] != "create_time_utc" {
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVExSuperchargerFormats...)
+				if err != nil {
+					return
+				}
+			}
+			if !l.IsHeader(r) {
 				tx := csvExSuperchargerTX{}
-				tx.Time, err = time.Parse("2006-01-02 15:04:05", r[0])
+				tx.Time, err = time.Parse("2006-01-02 15:04:05", l.Get(r, "create_time_utc"))
 				if err != nil {
-					log.Println("Error Parsing Time : ", r[0])
+					diag.Add(SOURCE, n+1, "create_time_utc", l.Get(r, "create_time_utc"), "Error Parsing Time")
 				}
 				tx.ID = utils.GetUniqueID(SOURCE + tx.Time.String())
-				tx.Currency = r[1]
-				tx.Amount, err = decimal.NewFromString(r[2])
+				tx.Currency = l.Get(r, "currency")
+				tx.Amount, err = decimal.NewFromString(l.Get(r, "amount"))
 				if err != nil {
-					log.Println("Error Parsing Amount : ", r[2])
+					diag.Add(SOURCE, n+1, "amount", l.Get(r, "amount"), "Error Parsing Amount")
 				}
-				tx.Description = r[3]
+				tx.Description = l.Get(r, "description")
 				cdc.csvExSuperchargerTXs = append(cdc.csvExSuperchargerTXs, tx)
 				t := wallet.TX{Timestamp: tx.Time, ID: tx.ID, Note: SOURCE + " " + tx.Description}
 				t.Items = make(map[string]wallet.Currencies)
@@ -3,9 +3,9 @@ package cryptocom
 import (
 	"encoding/csv"
 	"io"
-	"log"
 	"time"
 
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
 	"github.com/fiscafacile/CryptoFiscaFacile/utils"
 	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
 	"github.com/shopspring/decimal"
@@ -25,30 +25,41 @@ type csvExTransferTX struct {
 	Status   string
 }
 
+var CSVExTransferFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"create_time_utc", "currency", "amount", "fee", "address", "status"}, Positional: true, Date: "create_time_utc", DateLayouts: []string{"2006-01-02 15:04:05.000"}, Amounts: []string{"amount", "fee"}},
+}
+
 func (cdc *CryptoCom) ParseCSVExchangeTransfer(reader io.Reader) (err error) {
 	const SOURCE = "Crypto.com Exchange Transfer CSV :"
 	csvReader := csv.NewReader(reader)
 	records, err := csvReader.ReadAll()
 	if err == nil {
-		for _, r := range records {
-			if r[0] != "create_time_utc" {
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVExTransferFormats...)
+				if err != nil {
+					return
+				}
+			}
+			if !l.IsHeader(r) {
 				tx := csvExTransferTX{}
-				tx.Time, err = time.Parse("2006-01-02 15:04:05.000", r[0])
+				tx.Time, err = time.Parse("2006-01-02 15:04:05.000", l.Get(r, "create_time_utc"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Time", r[0])
+					diag.Add(SOURCE, n+1, "create_time_utc", l.Get(r, "create_time_utc"), "Error Parsing Time")
 				}
 				tx.ID = utils.GetUniqueID(SOURCE + tx.Time.String())
-				tx.Currency = r[1]
-				tx.Amount, err = decimal.NewFromString(r[2])
+				tx.Currency = l.Get(r, "currency")
+				tx.Amount, err = decimal.NewFromString(l.Get(r, "amount"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Amount", r[2])
+					diag.Add(SOURCE, n+1, "amount", l.Get(r, "amount"), "Error Parsing Amount")
 				}
-				tx.Fee, err = decimal.NewFromString(r[3])
+				tx.Fee, err = decimal.NewFromString(l.Get(r, "fee"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Fee", r[3])
+					diag.Add(SOURCE, n+1, "fee", l.Get(r, "fee"), "Error Parsing Fee")
 				}
-				tx.Address = r[4]
-				tx.Status = r[5]
+				tx.Address = l.Get(r, "address")
+				tx.Status = l.Get(r, "status")
 				if tx.Address == "EARLY_SWAP_BONUS_DEPOSIT" ||
 					tx.Address == "INTERNAL_DEPOSIT" {
 					cdc.csvExTransferTXs = append(cdc.csvExTransferTXs, tx)
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
 	"github.com/fiscafacile/CryptoFiscaFacile/source"
 	"github.com/fiscafacile/CryptoFiscaFacile/utils"
 	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
@@ -211,7 +212,7 @@ func (cdc *CryptoCom) ParseJSONExchangeExportJS(reader io.Reader, account string
 				t.Items = make(map[string]wallet.Currencies)
 				amount, err := decimal.NewFromString(w.Amount)
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Amount", w.Amount)
+					diag.Add(SOURCE, 0, "Amount", w.Amount, "Error Parsing Amount")
 				} else {
 					t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: w.Symbol, Amount: amount})
 				}
@@ -233,7 +234,7 @@ func (cdc *CryptoCom) ParseJSONExchangeExportJS(reader io.Reader, account string
 				t.Items = make(map[string]wallet.Currencies)
 				amount, err := decimal.NewFromString(d.Amount)
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Amount", d.Amount)
+					diag.Add(SOURCE, 0, "Amount", d.Amount, "Error Parsing Amount")
 				} else {
 					t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: d.Symbol, Amount: amount})
 					cdc.jsonEx.txsByCategory["Deposits"] = append(cdc.jsonEx.txsByCategory["Deposits"], t)
@@ -253,7 +254,7 @@ func (cdc *CryptoCom) ParseJSONExchangeExportJS(reader io.Reader, account string
 				t.Items = make(map[string]wallet.Currencies)
 				amount, err := decimal.NewFromString(cs.InterestAmount)
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing InterestAmount", cs.InterestAmount)
+					diag.Add(SOURCE, 0, "InterestAmount", cs.InterestAmount, "Error Parsing InterestAmount")
 				} else {
 					t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: cs.CoinSymbol, Amount: amount})
 					cdc.jsonEx.txsByCategory["Interests"] = append(cdc.jsonEx.txsByCategory["Interests"], t)
@@ -272,7 +273,7 @@ func (cdc *CryptoCom) ParseJSONExchangeExportJS(reader io.Reader, account string
 				t.Items = make(map[string]wallet.Currencies)
 				amount, err := decimal.NewFromString(ss.Amount)
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Amount", ss.Amount)
+					diag.Add(SOURCE, 0, "Amount", ss.Amount, "Error Parsing Amount")
 				} else {
 					t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: ss.CoinSymbol, Amount: amount})
 					cdc.jsonEx.txsByCategory["Interests"] = append(cdc.jsonEx.txsByCategory["Interests"], t)
@@ -292,7 +293,7 @@ func (cdc *CryptoCom) ParseJSONExchangeExportJS(reader io.Reader, account string
 				t.Items = make(map[string]wallet.Currencies)
 				amount, err := decimal.NewFromString(r.RebateAmount)
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing RebateAmount", r.RebateAmount)
+					diag.Add(SOURCE, 0, "RebateAmount", r.RebateAmount, "Error Parsing RebateAmount")
 				} else {
 					t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: r.CoinSymbol, Amount: amount})
 					cdc.jsonEx.txsByCategory["CommercialRebates"] = append(cdc.jsonEx.txsByCategory["CommercialRebates"], t)
@@ -310,18 +311,18 @@ func (cdc *CryptoCom) ParseJSONExchangeExportJS(reader io.Reader, account string
 			t.Items = make(map[string]wallet.Currencies)
 			allocatedVolume, err1 := decimal.NewFromString(s.AllocatedVolume)
 			if err1 != nil {
-				log.Println(SOURCE, "Error Parsing AllocatedVolume", s.AllocatedVolume)
+				diag.Add(SOURCE, 0, "AllocatedVolume", s.AllocatedVolume, "Error Parsing AllocatedVolume")
 			} else {
 				t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: s.SyndicateCoin, Amount: allocatedVolume})
 			}
 			committedCRO, err2 := decimal.NewFromString(s.CommittedCRO)
 			var err3 error
 			if err2 != nil {
-				log.Println(SOURCE, "Error Parsing CommittedCRO", s.CommittedCRO)
+				diag.Add(SOURCE, 0, "CommittedCRO", s.CommittedCRO, "Error Parsing CommittedCRO")
 			} else {
 				refundedCRO, err3 := decimal.NewFromString(s.RefundedCRO)
 				if err3 != nil {
-					log.Println(SOURCE, "Error Parsing RefundedCRO", s.RefundedCRO)
+					diag.Add(SOURCE, 0, "RefundedCRO", s.RefundedCRO, "Error Parsing RefundedCRO")
 				} else {
 					t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: "CRO", Amount: committedCRO.Sub(refundedCRO)})
 				}
@@ -342,7 +343,7 @@ func (cdc *CryptoCom) ParseJSONExchangeExportJS(reader io.Reader, account string
 			t.Items = make(map[string]wallet.Currencies)
 			amount, err := decimal.NewFromString(s.RewardAmount)
 			if err != nil {
-				log.Println(SOURCE, "Error Parsing RewardAmount", s.RewardAmount)
+				diag.Add(SOURCE, 0, "RewardAmount", s.RewardAmount, "Error Parsing RewardAmount")
 			} else {
 				t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: s.CoinSymbol, Amount: amount})
 				cdc.jsonEx.txsByCategory["Minings"] = append(cdc.jsonEx.txsByCategory["Minings"], t)
@@ -360,7 +361,7 @@ func (cdc *CryptoCom) ParseJSONExchangeExportJS(reader io.Reader, account string
 				t.Items = make(map[string]wallet.Currencies)
 				amount, err := decimal.NewFromString(tc.Commission)
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Commission", tc.Commission)
+					diag.Add(SOURCE, 0, "Commission", tc.Commission, "Error Parsing Commission")
 				} else {
 					t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: "CRO", Amount: amount})
 					cdc.jsonEx.txsByCategory["Referrals"] = append(cdc.jsonEx.txsByCategory["Referrals"], t)
@@ -379,7 +380,7 @@ func (cdc *CryptoCom) ParseJSONExchangeExportJS(reader io.Reader, account string
 				t.Items = make(map[string]wallet.Currencies)
 				amount, err := decimal.NewFromString(b.ReferralBonusInCRO)
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing ReferralBonusInCRO", b.ReferralBonusInCRO)
+					diag.Add(SOURCE, 0, "ReferralBonusInCRO", b.ReferralBonusInCRO, "Error Parsing ReferralBonusInCRO")
 				} else {
 					t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: "CRO", Amount: amount})
 					cdc.jsonEx.txsByCategory["Referrals"] = append(cdc.jsonEx.txsByCategory["Referrals"], t)
@@ -395,14 +396,14 @@ func (cdc *CryptoCom) ParseJSONExchangeExportJS(reader io.Reader, account string
 		if exch.Rew.SignupBonus != "0" {
 			signupBonusCreatedAt, err := strconv.ParseInt(exch.Rew.SignupBonusCreatedAt, 10, 64)
 			if err != nil {
-				log.Println(SOURCE, "Error Parsing SignupBonusCreatedAt", exch.Rew.SignupBonusCreatedAt)
+				diag.Add(SOURCE, 0, "SignupBonusCreatedAt", exch.Rew.SignupBonusCreatedAt, "Error Parsing SignupBonusCreatedAt")
 			}
 			t := wallet.TX{Timestamp: time.Unix(signupBonusCreatedAt/1000, 0), Note: SOURCE + " Signup Bonus"}
 			t.ID = utils.GetUniqueID(SOURCE + t.Timestamp.String())
 			t.Items = make(map[string]wallet.Currencies)
 			amount, err := decimal.NewFromString(exch.Rew.SignupBonus)
 			if err != nil {
-				log.Println(SOURCE, "Error Parsing SignupBonus", exch.Rew.SignupBonus)
+				diag.Add(SOURCE, 0, "SignupBonus", exch.Rew.SignupBonus, "Error Parsing SignupBonus")
 			} else {
 				t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: "CRO", Amount: amount})
 				cdc.jsonEx.txsByCategory["CommercialRebates"] = append(cdc.jsonEx.txsByCategory["CommercialRebates"], t)
@@ -410,15 +411,7 @@ func (cdc *CryptoCom) ParseJSONExchangeExportJS(reader io.Reader, account string
 		}
 	}
 	if _, ok := cdc.Sources["CdC Exchange"]; !ok {
-		cdc.Sources["CdC Exchange"] = source.Source{
-			Crypto:        true,
-			AccountNumber: account,
-			OpeningDate:   firstTimeUsed,
-			ClosingDate:   lastTimeUsed,
-			LegalName:     "MCO Malta DAX Limited",
-			Address:       "Level 7, Spinola Park, Triq Mikiel Ang Borg,\nSt Julian's SPK 1000,\nMalte",
-			URL:           "https://crypto.com/exchange",
-		}
+		cdc.Sources["CdC Exchange"] = source.New("CdC Exchange", account, firstTimeUsed, lastTimeUsed)
 	}
 	return
 }
@@ -0,0 +1,84 @@
+package diag
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// Problem is a value that a parser could not read, it was replaced by a zero value
+type Problem struct {
+	File    string
+	Source  string
+	Line    int
+	Column  string
+	Value   string
+	Message string
+}
+
+var (
+	mutex    sync.Mutex
+	problems []Problem
+	current  string
+)
+
+// SetFile tells which file is being parsed so that next problems refer to it
+func SetFile(file string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	current = file
+}
+
+// Add records a problem, line is 1-based and 0 when unknown
+func Add(src string, line int, column, value, message string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	problems = append(problems, Problem{File: current, Source: src, Line: line, Column: column, Value: value, Message: message})
+	if line > 0 {
+		log.Println(src, message, value, "line", line)
+	} else {
+		log.Println(src, message, value)
+	}
+}
+
+func Problems() []Problem {
+	mutex.Lock()
+	defer mutex.Unlock()
+	return append([]Problem{}, problems...)
+}
+
+func Reset() {
+	mutex.Lock()
+	defer mutex.Unlock()
+	problems = nil
+	current = ""
+}
+
+// Println displays the problems as a table sorted by file and line
+func Println() {
+	ps := Problems()
+	if len(ps) == 0 {
+		return
+	}
+	sort.SliceStable(ps, func(i, j int) bool {
+		if ps[i].File != ps[j].File {
+			return ps[i].File < ps[j].File
+		}
+		return ps[i].Line < ps[j].Line
+	})
+	fmt.Println("Parsing Problems :", len(ps))
+	fmt.Printf("  %-40s %6s %-25s %-25s %s\n", "File", "Line", "Column", "Value", "Problem")
+	for _, p := range ps {
+		line := ""
+		if p.Line > 0 {
+			line = strconv.Itoa(p.Line)
+		}
+		file := p.File
+		if file == "" {
+			file = p.Source
+		}
+		fmt.Printf("  %-40s %6s %-25s %-25q %s\n", file, line, p.Column, p.Value, p.Message)
+	}
+}
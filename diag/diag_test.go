@@ -0,0 +1,28 @@
+package diag
+
+import (
+	"testing"
+)
+
+func TestAdd(t *testing.T) {
+	Reset()
+	SetFile("Inputs/binance.csv")
+	Add("Binance CSV :", 3, "Change", "1,2", "Error Parsing Amount")
+	SetFile("")
+	Add("Crypto.com Exchange JSON :", 0, "amount", "x", "Error Parsing Amount")
+	ps := Problems()
+	if len(ps) != 2 {
+		t.Fatalf("Problems() = %v, want 2", ps)
+	}
+	want := Problem{File: "Inputs/binance.csv", Source: "Binance CSV :", Line: 3, Column: "Change", Value: "1,2", Message: "Error Parsing Amount"}
+	if ps[0] != want {
+		t.Errorf("Problems()[0] = %v, want %v", ps[0], want)
+	}
+	if ps[1].File != "" {
+		t.Errorf("Problems()[1].File = %v, want empty", ps[1].File)
+	}
+	Reset()
+	if len(Problems()) != 0 {
+		t.Errorf("Reset() did not clear problems")
+	}
+}
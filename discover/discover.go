@@ -0,0 +1,217 @@
+package discover
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Input is a file recognised during the scan, Kind is the name of the CLI option that would load it
+type Input struct {
+	Path string
+	Kind string
+}
+
+type Unknown struct {
+	Path   string
+	Reason string
+}
+
+type Report struct {
+	Inputs   []Input
+	Unknowns []Unknown
+}
+
+// maxHeaderLines allows for the few summary lines that some exports write before the header
+const maxHeaderLines = 20
+
+// Scan walks dir and fingerprints each file by its header and content
+func Scan(dir string) (rep Report, err error) {
+	const SOURCE = "Inputs Discovery :"
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir && strings.HasPrefix(info.Name(), ".") {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() {
+			return nil
+		}
+		if isContainer(path) {
+			rep.scanContainer(path)
+			return nil
+		}
+		kind, reason := identify(path)
+		if kind != "" {
+			rep.Inputs = append(rep.Inputs, Input{Path: path, Kind: kind})
+		} else {
+			rep.Unknowns = append(rep.Unknowns, Unknown{Path: path, Reason: reason})
+		}
+		return nil
+	})
+	if err != nil {
+		return rep, errors.New(SOURCE + " " + err.Error())
+	}
+	return
+}
+
+func identify(path string) (kind, reason string) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err.Error()
+	}
+	defer f.Close()
+	return identifyReader(path, f)
+}
+
+func identifyReader(name string, reader io.Reader) (kind, reason string) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return identifyCSV(reader)
+	case ".json":
+		return identifyJSON(reader)
+	}
+	return "", "unsupported file type"
+}
+
+// scanContainer reports the archive or workbook once per kind of its content
+func (rep *Report) scanContainer(path string) {
+	parts, err := unpackFile(path)
+	if err != nil {
+		rep.Unknowns = append(rep.Unknowns, Unknown{Path: path, Reason: err.Error()})
+		return
+	}
+	kinds := make(map[string]bool)
+	for _, p := range parts {
+		kind, reason := identifyReader(p.Name, bytes.NewReader(p.Data))
+		if kind == "" {
+			rep.Unknowns = append(rep.Unknowns, Unknown{Path: p.Name, Reason: reason})
+		} else if !kinds[kind] {
+			kinds[kind] = true
+			rep.Inputs = append(rep.Inputs, Input{Path: path, Kind: kind})
+		}
+	}
+}
+
+func normalize(h string) string {
+	return strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))
+}
+
+// identifyCSV looks for a known header in the first lines
+func identifyCSV(reader io.Reader) (kind, reason string) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.LazyQuotes = true
+	for n := 0; n < maxHeaderLines; n++ {
+		r, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			if _, ok := err.(*csv.ParseError); ok {
+				continue
+			}
+			return "", err.Error()
+		}
+		names := make(map[string]bool)
+		for _, h := range r {
+			names[normalize(h)] = true
+		}
+		best := -1
+		for i, s := range csvSignatures {
+			match := true
+			for _, c := range s.Columns {
+				if !names[c] {
+					match = false
+					break
+				}
+			}
+			if match && (best < 0 || len(s.Columns) > len(csvSignatures[best].Columns)) {
+				best = i
+			}
+		}
+		if best < 0 {
+			continue
+		}
+		kind = csvSignatures[best].Kind
+		if kind == "addresses" {
+			// BTC, ETH and ADA addresses lists share the same header
+			kind = "btc-addresses-csv"
+			first, err := csvReader.Read()
+			if err == nil && len(first) > 0 {
+				add := strings.ToLower(normalize(first[0]))
+				if strings.HasPrefix(add, "0x") {
+					kind = "eth-addresses-csv"
+				} else if strings.HasPrefix(add, "addr1") || strings.HasPrefix(add, "stake1") {
+					kind = "ada-addresses-csv"
+				}
+			}
+		}
+		return kind, ""
+	}
+	return "", "unknown CSV header"
+}
+
+// identifyJSON looks at the top level keys of the document
+func identifyJSON(reader io.Reader) (kind, reason string) {
+	var doc interface{}
+	err := json.NewDecoder(reader).Decode(&doc)
+	if err != nil {
+		return "", "invalid JSON"
+	}
+	has := func(m map[string]interface{}, keys ...string) bool {
+		for _, k := range keys {
+			found := false
+			for mk := range m {
+				if strings.EqualFold(mk, k) {
+					found = true
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	}
+	switch d := doc.(type) {
+	case map[string]interface{}:
+		if has(d, "withs", "deps") {
+			return "cdc-ex-exportjs", ""
+		} else if has(d, "version", "txs") {
+			return "txs-import", ""
+		}
+	case []interface{}:
+		if len(d) == 0 {
+			return "", "empty JSON list"
+		}
+		if first, ok := d[0].(map[string]interface{}); ok {
+			if has(first, "category", "items") {
+				return "manual-json", ""
+			} else if has(first, "txid", "category", "confirmations") {
+				return "bitcoin-core-json", ""
+			} else if has(first, "txid", "date") {
+				return "btg-txs", ""
+			}
+		}
+	}
+	return "", "unknown JSON content"
+}
+
+func (rep Report) Println() {
+	fmt.Println("Inputs Discovery :", len(rep.Inputs), "recognised,", len(rep.Unknowns), "not recognised")
+	for _, in := range rep.Inputs {
+		fmt.Println("  ", in.Kind, ":", in.Path)
+	}
+	for _, u := range rep.Unknowns {
+		fmt.Println("   ??? :", u.Path, "("+u.Reason+")")
+	}
+}
@@ -0,0 +1,92 @@
+package discover
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/cfg"
+	"github.com/fiscafacile/CryptoFiscaFacile/kraken"
+	"github.com/fiscafacile/CryptoFiscaFacile/revolut"
+)
+
+func TestScan(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"binance.csv":       "UTC_Time,Account,Operation,Coin,Change,Fee,Remark\n2020-04-13 09:22:50,Spot,Buy,ETH,0.75673607,,\n",
+		"coinbase.csv":      "You can use this transaction report to inform your likely tax obligations.\n\nTransactions\nUser,someone@example.com,abc\n\nTimestamp,Transaction Type,Asset,Quantity Transacted,EUR Spot Price at Transaction,EUR Subtotal,EUR Total (inclusive of fees),EUR Fees,Notes\n",
+		"btc.csv":           "Address,Description\n1BoatSLRHtKNngkdXEeobR76b53LETtpyT,Cold\n",
+		"eth.csv":           "Address,Description\n0xde0B295669a9FD93d5F28D9Ec85E40f4cb697BAe,Metamask\n",
+		"ada.csv":           "Address,Description\nstake1u9ylzsgxaa6xctf4juup682ar3juj85n8tx3hthnljg47zctvm3rc,Yoroi\n",
+		"polo/deposits.csv": "Date,Currency,Amount,Address,Status\n",
+		"polo/withdraw.csv": "Date,Currency,Amount,Fee Deducted,Amount - Fee,Address,Status\n",
+		"manual.json":       `[{"id":"otc-1","date":"2021-01-01T00:00:00Z","category":"CashIn","items":{"To":[{"code":"BTC","amount":"0.1"}]}}]`,
+		"txs.json":          `{"version":1,"txs":[]}`,
+		"core.json":         `[{"address":"bc1q","category":"receive","amount":0.1,"confirmations":3,"txid":"aaa"}]`,
+		"electrum.csv":      "transaction_hash,label,confirmations,value,fiat_value,fee,fiat_fee,timestamp\n",
+		"other.csv":         "foo,bar\n1,2\n",
+		"notes.txt":         "hello",
+		".hidden/x.csv":     "UTC_Time,Account,Operation,Coin,Change,Remark\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rep, err := Scan(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"binance.csv":       "binance",
+		"coinbase.csv":      "coinbase",
+		"btc.csv":           "btc-addresses-csv",
+		"eth.csv":           "eth-addresses-csv",
+		"ada.csv":           "ada-addresses-csv",
+		"polo/deposits.csv": "poloniex-deposits",
+		"polo/withdraw.csv": "poloniex-withdrawals",
+		"manual.json":       "manual-json",
+		"txs.json":          "txs-import",
+		"core.json":         "bitcoin-core-json",
+		"electrum.csv":      "electrum-csv",
+	}
+	if len(rep.Inputs) != len(want) {
+		t.Errorf("Scan() Inputs = %v, want %d", rep.Inputs, len(want))
+	}
+	for _, in := range rep.Inputs {
+		rel, _ := filepath.Rel(dir, in.Path)
+		if want[filepath.ToSlash(rel)] != in.Kind {
+			t.Errorf("Scan() %s Kind = %s, want %s", rel, in.Kind, want[filepath.ToSlash(rel)])
+		}
+	}
+	if len(rep.Unknowns) != 2 {
+		t.Errorf("Scan() Unknowns = %v, want other.csv and notes.txt", rep.Unknowns)
+	}
+	config := &cfg.Config{}
+	config.Exchanges.Binance.CSV.All = []string{filepath.Join(dir, "binance.csv")}
+	rep.AddTo(config)
+	if len(config.Exchanges.Binance.CSV.All) != 1 {
+		t.Errorf("AddTo() Binance = %v, want no duplicate", config.Exchanges.Binance.CSV.All)
+	}
+	if len(config.Blockchains.ETH.CSV) != 1 || len(config.Exchanges.Poloniex.CSV.Withdrawals) != 1 || len(config.Options.TxsImport) != 1 {
+		t.Errorf("AddTo() did not route every recognised file")
+	}
+}
+
+func TestIdentifyCSVParserFormats(t *testing.T) {
+	tests := map[string]string{
+		"kraken":  strings.Join(kraken.CSVFormats[0].Columns, ","),
+		"revolut": strings.Join(revolut.CSVFormats("EUR")[0].Columns, ","),
+	}
+	for want, header := range tests {
+		kind, reason := identifyCSV(strings.NewReader(header + "\n"))
+		if kind != want {
+			t.Errorf("identifyCSV() %s = %s (%s), want %s", header, kind, reason, want)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package discover
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+	"github.com/fiscafacile/CryptoFiscaFacile/cfg"
+)
+
+// part is a CSV or JSON file found in an archive or a workbook
+type part struct {
+	Name string
+	Data []byte
+}
+
+func isContainer(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".zip" || ext == ".xlsx"
+}
+
+func unpackFile(path string) (parts []part, err error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return
+	}
+	return unpack(path, data)
+}
+
+// unpack extracts the CSV and JSON of a ZIP (recursively) and converts each sheet of a XLSX into a CSV
+func unpack(name string, data []byte) (parts []part, err error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".zip":
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range zr.File {
+			if f.FileInfo().IsDir() || strings.HasPrefix(f.Name, "__MACOSX") || strings.HasPrefix(filepath.Base(f.Name), ".") {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return nil, err
+			}
+			content, err := ioutil.ReadAll(rc)
+			rc.Close()
+			if err != nil {
+				return nil, err
+			}
+			sub, err := unpack(name+"/"+f.Name, content)
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, sub...)
+		}
+	case ".xlsx":
+		xl, err := excelize.OpenReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		sheets := xl.GetSheetMap()
+		var ids []int
+		for id := range sheets {
+			ids = append(ids, id)
+		}
+		sort.Ints(ids)
+		for _, id := range ids {
+			rows := xl.GetRows(sheets[id])
+			if len(rows) == 0 {
+				continue
+			}
+			var buf bytes.Buffer
+			w := csv.NewWriter(&buf)
+			err = w.WriteAll(rows)
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, part{Name: name + "/" + sheets[id] + ".csv", Data: buf.Bytes()})
+		}
+	case ".csv", ".json":
+		parts = append(parts, part{Name: name, Data: data})
+	}
+	return
+}
+
+// Expand replaces each ZIP or XLSX of the configuration by the CSV and JSON it contains, written in a temporary directory.
+// Contents recognised as another kind of file are skipped, the caller should remove tmpDir when done.
+func Expand(config *cfg.Config) (tmpDir string, err error) {
+	const SOURCE = "Inputs Expand :"
+	n := 0
+	expand := func(kind, file string) (files []string, err error) {
+		parts, err := unpackFile(file)
+		if err != nil {
+			return nil, errors.New(SOURCE + " " + file + " " + err.Error())
+		}
+		for _, p := range parts {
+			k, _ := identifyReader(p.Name, bytes.NewReader(p.Data))
+			if k != "" && k != kind {
+				log.Println(SOURCE, "Skipping", p.Name, "recognised as", k, "instead of", kind)
+				continue
+			}
+			if tmpDir == "" {
+				tmpDir, err = ioutil.TempDir("", "cryptofiscafacile")
+				if err != nil {
+					return nil, errors.New(SOURCE + " " + err.Error())
+				}
+			}
+			n += 1
+			name := strings.NewReplacer("/", "_", "\\", "_", ":", "_").Replace(filepath.Base(file) + "/" + strings.TrimPrefix(p.Name, file+"/"))
+			path := filepath.Join(tmpDir, strconv.Itoa(n)+"-"+name)
+			err = ioutil.WriteFile(path, p.Data, 0600)
+			if err != nil {
+				return nil, errors.New(SOURCE + " " + err.Error())
+			}
+			files = append(files, path)
+		}
+		return
+	}
+	for kind, l := range lists(config) {
+		var files []string
+		for _, file := range *l {
+			if !isContainer(file) {
+				files = append(files, file)
+				continue
+			}
+			parts, err := expand(kind, file)
+			if err != nil {
+				return tmpDir, err
+			}
+			files = append(files, parts...)
+		}
+		*l = files
+	}
+	for kind, s := range singles(config) {
+		if !isContainer(*s) {
+			continue
+		}
+		files, err := expand(kind, *s)
+		if err != nil {
+			return tmpDir, err
+		}
+		if len(files) == 0 {
+			return tmpDir, errors.New(SOURCE + " " + *s + " contains no " + kind + " file")
+		}
+		if len(files) > 1 {
+			log.Println(SOURCE, "Ignoring", strings.Join(files[1:], ", "), "as", kind, "takes only one file")
+		}
+		*s = files[0]
+	}
+	return
+}
@@ -0,0 +1,118 @@
+package discover
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+	"github.com/fiscafacile/CryptoFiscaFacile/cfg"
+)
+
+func TestExpand(t *testing.T) {
+	dir := t.TempDir()
+	zipPath := filepath.Join(dir, "kraken.zip")
+	zf, err := os.Create(zipPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(zf)
+	for name, content := range map[string]string{
+		"ledgers.csv":            "\"txid\",\"refid\",\"time\",\"type\",\"subtype\",\"aclass\",\"asset\",\"amount\",\"fee\",\"balance\"\n",
+		"deposits.csv":           "Date,Currency,Amount,Address,Status\n",
+		"__MACOSX/._ledgers.csv": "junk",
+	} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(content))
+	}
+	zw.Close()
+	zf.Close()
+	xlsxPath := filepath.Join(dir, "binance.xlsx")
+	xl := excelize.NewFile()
+	xl.SetSheetRow("Sheet1", "A1", &[]string{"User_ID", "UTC_Time", "Account", "Operation", "Coin", "Change", "Remark"})
+	xl.SetSheetRow("Sheet1", "A2", &[]string{"1234", "2021-01-02 03:04:05", "Spot", "Deposit", "BTC", "0.1", ""})
+	err = xl.SaveAs(xlsxPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rep, err := Scan(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kinds := make(map[string]string)
+	for _, in := range rep.Inputs {
+		kinds[in.Kind] = filepath.Base(in.Path)
+	}
+	if kinds["kraken"] != "kraken.zip" || kinds["poloniex-deposits"] != "kraken.zip" || kinds["binance"] != "binance.xlsx" {
+		t.Errorf("Scan() Inputs = %v", rep.Inputs)
+	}
+	config := &cfg.Config{}
+	config.Exchanges.Kraken.CSV.All = []string{zipPath}
+	config.Exchanges.Binance.CSV.All = []string{xlsxPath, "binance.csv"}
+	tmpDir, err := Expand(config)
+	if tmpDir != "" {
+		defer os.RemoveAll(tmpDir)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(config.Exchanges.Kraken.CSV.All) != 1 {
+		t.Fatalf("Expand() Kraken = %v, want only ledgers.csv", config.Exchanges.Kraken.CSV.All)
+	}
+	if len(config.Exchanges.Binance.CSV.All) != 2 || config.Exchanges.Binance.CSV.All[1] != "binance.csv" {
+		t.Fatalf("Expand() Binance = %v", config.Exchanges.Binance.CSV.All)
+	}
+	data, err := ioutil.ReadFile(config.Exchanges.Binance.CSV.All[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "User_ID,UTC_Time,Account,Operation,Coin,Change,Remark\n1234,2021-01-02 03:04:05,Spot,Deposit,BTC,0.1,") {
+		t.Errorf("Expand() Binance sheet = %q", string(data))
+	}
+}
+
+func TestExpandSingle(t *testing.T) {
+	dir := t.TempDir()
+	zipPath := filepath.Join(dir, "categ.zip")
+	zf, err := os.Create(zipPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(zf)
+	w, err := zw.Create("categ.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write([]byte("TxID,Type,Description,Value,Currency\neee,IN,achat P2P,3000,EUR\n"))
+	zw.Close()
+	zf.Close()
+	config := &cfg.Config{}
+	config.Options.TxsCategory = zipPath
+	tmpDir, err := Expand(config)
+	if tmpDir != "" {
+		defer os.RemoveAll(tmpDir)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Dir(config.Options.TxsCategory) != tmpDir || !strings.HasSuffix(config.Options.TxsCategory, "categ.csv") {
+		t.Errorf("Expand() TxsCategory = %s, want the extracted categ.csv", config.Options.TxsCategory)
+	}
+	config.Options.TxsCategory = filepath.Join(dir, "empty.zip")
+	zf, err = os.Create(config.Options.TxsCategory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zip.NewWriter(zf).Close()
+	zf.Close()
+	_, err = Expand(config)
+	if err == nil {
+		t.Errorf("Expand() should fail when the archive has no matching file")
+	}
+}
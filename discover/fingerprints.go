@@ -0,0 +1,102 @@
+package discover
+
+import (
+	"strings"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/binance"
+	"github.com/fiscafacile/CryptoFiscaFacile/bitfinex"
+	"github.com/fiscafacile/CryptoFiscaFacile/bitstamp"
+	"github.com/fiscafacile/CryptoFiscaFacile/bittrex"
+	"github.com/fiscafacile/CryptoFiscaFacile/btc"
+	"github.com/fiscafacile/CryptoFiscaFacile/category"
+	"github.com/fiscafacile/CryptoFiscaFacile/coinbase"
+	"github.com/fiscafacile/CryptoFiscaFacile/coinbasepro"
+	"github.com/fiscafacile/CryptoFiscaFacile/cointracking"
+	"github.com/fiscafacile/CryptoFiscaFacile/cryptocom"
+	"github.com/fiscafacile/CryptoFiscaFacile/hitbtc"
+	"github.com/fiscafacile/CryptoFiscaFacile/koinly"
+	"github.com/fiscafacile/CryptoFiscaFacile/kraken"
+	"github.com/fiscafacile/CryptoFiscaFacile/ledgerlive"
+	"github.com/fiscafacile/CryptoFiscaFacile/localbitcoin"
+	"github.com/fiscafacile/CryptoFiscaFacile/manual"
+	"github.com/fiscafacile/CryptoFiscaFacile/monero"
+	"github.com/fiscafacile/CryptoFiscaFacile/mycelium"
+	"github.com/fiscafacile/CryptoFiscaFacile/poloniex"
+	"github.com/fiscafacile/CryptoFiscaFacile/revolut"
+	"github.com/fiscafacile/CryptoFiscaFacile/uphold"
+	"github.com/fiscafacile/CryptoFiscaFacile/utils"
+	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
+)
+
+// signature lists the header columns that identify a kind of CSV, the most specific matching one wins
+type signature struct {
+	Kind    string
+	Columns []string
+}
+
+var csvSignatures = buildSignatures()
+
+func buildSignatures() (sigs []signature) {
+	add := func(kind string, formats []utils.CSVFormat) {
+		for _, f := range formats {
+			sigs = append(sigs, signature{Kind: kind, Columns: columnNames(f.Columns, "")})
+		}
+	}
+	// the column names of these exports depend on the fiat or the crypto, only the fixed ones are kept
+	addParam := func(kind string, formats func(string) []utils.CSVFormat) {
+		const param = "\x00"
+		for _, f := range formats(param) {
+			sigs = append(sigs, signature{Kind: kind, Columns: columnNames(f.Columns, param)})
+		}
+	}
+	add("binance", binance.CSVFormats)
+	add("bitcoin-core-csv", btc.CSVCoreFormats)
+	add("bitfinex", bitfinex.CSVFormats)
+	add("bitstamp", bitstamp.CSVFormats)
+	add("bittrex", bittrex.CSVFormats)
+	// BTC, ETH and ADA addresses lists share the same header
+	add("addresses", btc.CSVFormats)
+	add("cdc-app-crypto", cryptocom.CSVAppCryptoFormats)
+	add("cdc-ex-spot-trade", cryptocom.CSVExSpotTradeFormats)
+	add("cdc-ex-transfer", cryptocom.CSVExTransferFormats)
+	add("cdc-ex-stake", cryptocom.CSVExStakeFormats)
+	add("cdc-ex-supercharger", cryptocom.CSVExSuperchargerFormats)
+	addParam("coinbase", coinbase.CSVFormats)
+	add("coinbase-pro-account", coinbasepro.CSVAccountFormats)
+	add("coinbase-pro-fills", coinbasepro.CSVFillsFormats)
+	add("cointracking-csv", cointracking.CSVFormats)
+	add("electrum-csv", btc.CSVElectrumFormats)
+	add("hitbtc-trades", hitbtc.CSVTradesFormats)
+	add("hitbtc-transactions", hitbtc.CSVTransactionsFormats)
+	add("koinly-csv", koinly.CSVFormats)
+	add("kraken", kraken.CSVFormats)
+	addParam("lb-trade", localbitcoin.CSVTradeFormats)
+	add("lb-transfer", localbitcoin.CSVTransferFormats)
+	add("ledgerlive", ledgerlive.CSVFormats)
+	add("manual", manual.CSVFormats)
+	add("monero", monero.CSVFormats)
+	add("mycelium", mycelium.CSVFormats)
+	add("poloniex-deposits", poloniex.CSVDepositsFormats)
+	add("poloniex-distributions", poloniex.CSVDistributionsFormats)
+	add("poloniex-trades", poloniex.CSVTradesFormats)
+	add("poloniex-withdrawals", poloniex.CSVWithdrawalsFormats)
+	addParam("revolut", revolut.CSVFormats)
+	add("txs-categ", category.CSVFormats)
+	add("txs-import", []utils.CSVFormat{{Columns: wallet.ArchiveCSVHeader}})
+	add("uphold", uphold.CSVFormats)
+	return
+}
+
+// columnNames drops the "#N" suffix of repeated columns and the columns containing param
+func columnNames(columns []string, param string) (names []string) {
+	for _, c := range columns {
+		if param != "" && strings.Contains(c, param) {
+			continue
+		}
+		if i := strings.LastIndex(c, "#"); i > 0 {
+			c = c[:i]
+		}
+		names = append(names, c)
+	}
+	return
+}
@@ -0,0 +1,85 @@
+package discover
+
+import (
+	"log"
+	"path/filepath"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/cfg"
+)
+
+func lists(config *cfg.Config) map[string]*[]string {
+	return map[string]*[]string{
+		"ada-addresses-csv":      &config.Blockchains.ADA.CSV,
+		"binance":                &config.Exchanges.Binance.CSV.All,
+		"bitcoin-core-csv":       &config.Wallets.BitcoinCore.CSV.All,
+		"bitcoin-core-json":      &config.Wallets.BitcoinCore.JSON,
+		"bitfinex":               &config.Exchanges.Bitfinex.CSV.All,
+		"bitstamp":               &config.Exchanges.Bitstamp.CSV.All,
+		"bittrex":                &config.Exchanges.Bittrex.CSV.All,
+		"btc-addresses-csv":      &config.Blockchains.BTC.CSV,
+		"cdc-app-crypto":         &config.Exchanges.CdcApp.CSV.All,
+		"cdc-ex-spot-trade":      &config.Exchanges.CdcEx.CSV.Trades,
+		"cdc-ex-stake":           &config.Exchanges.CdcEx.CSV.Staking,
+		"cdc-ex-supercharger":    &config.Exchanges.CdcEx.CSV.Supercharger,
+		"cdc-ex-transfer":        &config.Exchanges.CdcEx.CSV.Transfers,
+		"coinbase":               &config.Exchanges.Coinbase.CSV.All,
+		"coinbase-pro-account":   &config.Exchanges.CoinbasePro.CSV.Transfers,
+		"coinbase-pro-fills":     &config.Exchanges.CoinbasePro.CSV.Trades,
+		"cointracking-csv":       &config.Wallets.CoinTracking.CSV.All,
+		"electrum-csv":           &config.Wallets.Electrum.CSV.All,
+		"eth-addresses-csv":      &config.Blockchains.ETH.CSV,
+		"hitbtc-trades":          &config.Exchanges.HitBTC.CSV.Trades,
+		"hitbtc-transactions":    &config.Exchanges.HitBTC.CSV.Transfers,
+		"koinly-csv":             &config.Wallets.Koinly.CSV.All,
+		"kraken":                 &config.Exchanges.Kraken.CSV.All,
+		"lb-trade":               &config.Exchanges.LocalBitcoins.CSV.Trades,
+		"lb-transfer":            &config.Exchanges.LocalBitcoins.CSV.Transfers,
+		"ledgerlive":             &config.Wallets.LedgerLive.CSV.All,
+		"manual":                 &config.Wallets.Manual.CSV.All,
+		"manual-json":            &config.Wallets.Manual.JSON,
+		"monero":                 &config.Wallets.Monero.CSV.All,
+		"mycelium":               &config.Wallets.MyCelium.CSV.All,
+		"poloniex-deposits":      &config.Exchanges.Poloniex.CSV.Deposits,
+		"poloniex-distributions": &config.Exchanges.Poloniex.CSV.Distributions,
+		"poloniex-trades":        &config.Exchanges.Poloniex.CSV.Trades,
+		"poloniex-withdrawals":   &config.Exchanges.Poloniex.CSV.Withdrawals,
+		"revolut":                &config.Exchanges.Revolut.CSV.All,
+		"txs-import":             &config.Options.TxsImport,
+		"uphold":                 &config.Exchanges.Uphold.CSV.All,
+	}
+}
+
+// singles are the options that take only one file
+func singles(config *cfg.Config) map[string]*string {
+	return map[string]*string{
+		"btg-txs":         &config.Blockchains.BTG.JSON,
+		"cdc-ex-exportjs": &config.Exchanges.CdcEx.JSON,
+		"txs-categ":       &config.Options.TxsCategory,
+	}
+}
+
+// AddTo routes the recognised files to the matching options, files already configured are not added twice
+func (rep Report) AddTo(config *cfg.Config) {
+	const SOURCE = "Inputs Discovery :"
+	ls := lists(config)
+	ss := singles(config)
+	for _, in := range rep.Inputs {
+		if l, ok := ls[in.Kind]; ok {
+			found := false
+			for _, f := range *l {
+				if filepath.Clean(f) == filepath.Clean(in.Path) {
+					found = true
+				}
+			}
+			if !found {
+				*l = append(*l, in.Path)
+			}
+		} else if s, ok := ss[in.Kind]; ok {
+			if *s == "" {
+				*s = in.Path
+			} else if filepath.Clean(*s) != filepath.Clean(in.Path) {
+				log.Println(SOURCE, "Ignoring", in.Path, "as", in.Kind, "is already", *s)
+			}
+		}
+	}
+}
@@ -20,14 +20,25 @@ type api struct {
 	doneTok        chan error
 	clientNft      *resty.Client
 	doneNft        chan error
+	client1155     *resty.Client
+	done1155       chan error
 	basePath       string
 	apiKey         string
+	node           *rpc
+	filter         *tokenFilter
+	chain          string
+	name           string
+	native         string
+	tokenPrefix    string
+	cachePrefix    string
+	cacheDir       string
 	firstTimeUsed  time.Time
 	timeBetweenReq time.Duration
 	normalTXs      []normalTX
 	internalTXs    []internalTX
 	tokenTXs       []tokenTX
 	nftTXs         []nftTX
+	erc1155TXs     []erc1155TX
 	txsByCategory  wallet.TXsByCategory
 }
 
@@ -49,21 +60,40 @@ func (ethsc *Etherscan) NewAPI(apiKey string, debug bool) {
 	ethsc.api.clientNft.SetRetryCount(3).SetRetryWaitTime(1 * time.Second)
 	ethsc.api.clientNft.SetDebug(debug)
 	ethsc.api.doneNft = make(chan error)
+	ethsc.api.client1155 = resty.New()
+	ethsc.api.client1155.SetRetryCount(3).SetRetryWaitTime(1 * time.Second)
+	ethsc.api.client1155.SetDebug(debug)
+	ethsc.api.done1155 = make(chan error)
 	ethsc.api.basePath = "https://api.etherscan.io/api"
 	ethsc.api.apiKey = apiKey
+	ethsc.api.chain = "ETH"
+	ethsc.api.name = "Etherscan API"
+	ethsc.api.native = "ETH"
+	ethsc.api.cachePrefix = "Etherscan.io"
+	ethsc.api.cacheDir = "./Cache"
 	ethsc.api.firstTimeUsed = time.Now()
 	ethsc.api.timeBetweenReq = 200 * time.Millisecond
 }
 
 func (api *api) getAllTXs(addresses []string, cat category.Category) (err error) {
-	go api.getNormalTXs(addresses)
-	go api.getInternalTXs(addresses)
-	go api.getTokenTXs(addresses)
-	go api.getNftTXs(addresses)
-	<-api.doneNor
-	<-api.doneInt
-	<-api.doneTok
-	<-api.doneNft
+	if api.node != nil {
+		err = api.getRPCTXs(addresses)
+		if err != nil {
+			return
+		}
+	} else {
+		go api.getNormalTXs(addresses)
+		go api.getInternalTXs(addresses)
+		go api.getTokenTXs(addresses)
+		go api.getNftTXs(addresses)
+		go api.get1155TXs(addresses)
+		<-api.doneNor
+		<-api.doneInt
+		<-api.doneTok
+		<-api.doneNft
+		<-api.done1155
+	}
+	api.filterSpam(addresses)
 	api.categorize(addresses, cat)
 	return
 }
@@ -80,23 +110,23 @@ func (api *api) categorize(addresses []string, cat category.Category) {
 				api.nftTXs[i].used = true
 			} else {
 				if api.ownAddress(tx.To, addresses) && api.ownAddress(tx.From, addresses) {
-					alreadyAsked = wallet.AskForHelp("Etherscan API ERC721 Self TX", tx, alreadyAsked)
+					alreadyAsked = wallet.AskForHelp(api.name+" ERC721 Self TX", tx, alreadyAsked)
 				} else if api.ownAddress(tx.To, addresses) || api.ownAddress(tx.From, addresses) {
-					t := wallet.TX{Timestamp: tx.TimeStamp, ID: tx.Hash, Note: "Etherscan API : " + strconv.Itoa(tx.BlockNumber) + " " + tx.To}
+					t := wallet.TX{Timestamp: tx.TimeStamp, ID: tx.Hash, Note: api.name + " : " + strconv.Itoa(tx.BlockNumber) + " " + tx.To}
 					t.Items = make(map[string]wallet.Currencies)
 					t.Nfts = make(map[string]wallet.Nfts)
 					if api.ownAddress(tx.From, addresses) {
 						if !tx.GasPrice.IsZero() && !tx.GasUsed.IsZero() {
-							t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: "ETH", Amount: tx.GasPrice.Mul(tx.GasUsed)})
+							t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: api.native, Amount: tx.GasPrice.Mul(tx.GasUsed)})
 						}
-						t.Nfts["From"] = append(t.Nfts["From"], wallet.Nft{ID: tx.TokenID, Name: tx.TokenName, Symbol: tx.TokenSymbol})
+						t.Nfts["From"] = append(t.Nfts["From"], wallet.Nft{ID: tx.TokenID, Name: tx.TokenName, Symbol: api.tokenPrefix + tx.TokenSymbol})
 					} else {
-						t.Nfts["To"] = append(t.Nfts["To"], wallet.Nft{ID: tx.TokenID, Name: tx.TokenName, Symbol: tx.TokenSymbol})
+						t.Nfts["To"] = append(t.Nfts["To"], wallet.Nft{ID: tx.TokenID, Name: tx.TokenName, Symbol: api.tokenPrefix + tx.TokenSymbol})
 					}
 					for j, ntx := range api.normalTXs {
 						if ntx.Hash == tx.Hash {
 							if !ntx.GasPrice.IsZero() && !ntx.GasUsed.IsZero() {
-								t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: "ETH", Amount: ntx.GasPrice.Mul(ntx.GasUsed)})
+								t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: api.native, Amount: ntx.GasPrice.Mul(ntx.GasUsed)})
 							}
 							api.normalTXs[j].used = true
 							break
@@ -108,7 +138,7 @@ func (api *api) categorize(addresses []string, cat category.Category) {
 								if ntx2.Hash == fee {
 									if !ntx2.GasPrice.IsZero() && !ntx2.GasUsed.IsZero() &&
 										(!ntx2.GasPrice.Equal(tx.GasPrice) || !ntx2.GasUsed.Equal(tx.GasUsed)) {
-										t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: "ETH", Amount: ntx2.GasPrice.Mul(ntx2.GasUsed)})
+										t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: api.native, Amount: ntx2.GasPrice.Mul(ntx2.GasUsed)})
 									}
 									api.normalTXs[k].used = true
 								}
@@ -118,8 +148,45 @@ func (api *api) categorize(addresses []string, cat category.Category) {
 					api.txsByCategory["NFTs"] = append(api.txsByCategory["NFTs"], t)
 					api.nftTXs[i].used = true
 				} else {
-					alreadyAsked = wallet.AskForHelp("Etherscan API ERC721 TX", tx, alreadyAsked)
+					alreadyAsked = wallet.AskForHelp(api.name+" ERC721 TX", tx, alreadyAsked)
+				}
+			}
+		}
+	}
+	for i, tx := range api.erc1155TXs {
+		if !tx.used {
+			if is, _, _, _ := cat.IsTxShit(tx.Hash); is {
+				api.erc1155TXs[i].used = true
+			} else if api.ownAddress(tx.To, addresses) && api.ownAddress(tx.From, addresses) {
+				alreadyAsked = wallet.AskForHelp(api.name+" ERC1155 Self TX", tx, alreadyAsked)
+			} else if api.ownAddress(tx.To, addresses) || api.ownAddress(tx.From, addresses) {
+				t := wallet.TX{Timestamp: tx.TimeStamp, ID: tx.Hash, Note: api.name + " : " + strconv.Itoa(tx.BlockNumber) + " " + tx.ContractAddress}
+				t.Items = make(map[string]wallet.Currencies)
+				t.Nfts = make(map[string]wallet.Nfts)
+				// a batch transfer gives one line per token ID with the same Hash
+				for j, mtx := range api.erc1155TXs {
+					if !mtx.used && mtx.Hash == tx.Hash {
+						nft := wallet.Nft{ID: mtx.TokenID, Name: mtx.TokenName, Symbol: api.tokenPrefix + mtx.TokenSymbol, Quantity: mtx.TokenValue}
+						if api.ownAddress(mtx.From, addresses) && !api.ownAddress(mtx.To, addresses) {
+							t.Nfts["From"] = append(t.Nfts["From"], nft)
+						} else if api.ownAddress(mtx.To, addresses) && !api.ownAddress(mtx.From, addresses) {
+							t.Nfts["To"] = append(t.Nfts["To"], nft)
+						}
+						api.erc1155TXs[j].used = true
+					}
 				}
+				for j, ntx := range api.normalTXs {
+					if ntx.Hash == tx.Hash {
+						if !ntx.GasPrice.IsZero() && !ntx.GasUsed.IsZero() {
+							t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: api.native, Amount: ntx.GasPrice.Mul(ntx.GasUsed)})
+						}
+						api.normalTXs[j].used = true
+						break
+					}
+				}
+				api.txsByCategory["NFTs"] = append(api.txsByCategory["NFTs"], t)
+			} else {
+				alreadyAsked = wallet.AskForHelp(api.name+" ERC1155 TX", tx, alreadyAsked)
 			}
 		}
 	}
@@ -129,11 +196,11 @@ func (api *api) categorize(addresses []string, cat category.Category) {
 				api.tokenTXs[i].used = true
 			} else {
 				if api.ownAddress(tx.To, addresses) && api.ownAddress(tx.From, addresses) {
-					alreadyAsked = wallet.AskForHelp("Etherscan API ERC20 Self TX", tx, alreadyAsked)
+					alreadyAsked = wallet.AskForHelp(api.name+" ERC20 Self TX", tx, alreadyAsked)
 				} else if api.ownAddress(tx.To, addresses) {
-					t := wallet.TX{Timestamp: tx.TimeStamp, ID: tx.Hash, Note: "Etherscan API : " + strconv.Itoa(tx.BlockNumber) + " " + tx.To}
+					t := wallet.TX{Timestamp: tx.TimeStamp, ID: tx.Hash, Note: api.name + " : " + strconv.Itoa(tx.BlockNumber) + " " + tx.To}
 					t.Items = make(map[string]wallet.Currencies)
-					t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: tx.TokenSymbol, Amount: tx.Value})
+					t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: api.tokenPrefix + tx.TokenSymbol, Amount: tx.Value})
 					api.tokenTXs[i].used = true
 					// Add declared Fee if any
 					if is, feeHash := cat.IsTxFee(tx.Hash); is {
@@ -141,7 +208,7 @@ func (api *api) categorize(addresses []string, cat category.Category) {
 							for _, fee := range strings.Split(feeHash, ";") {
 								if ntx2.Hash == fee {
 									api.normalTXs[k].used = true
-									t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: "ETH", Amount: ntx2.GasPrice.Mul(ntx2.GasUsed)})
+									t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: api.native, Amount: ntx2.GasPrice.Mul(ntx2.GasUsed)})
 								}
 							}
 						}
@@ -151,7 +218,7 @@ func (api *api) categorize(addresses []string, cat category.Category) {
 					for j, ntx := range api.normalTXs {
 						if ntx.Hash == tx.Hash {
 							found = true
-							t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: "ETH", Amount: ntx.GasPrice.Mul(ntx.GasUsed)})
+							t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: api.native, Amount: ntx.GasPrice.Mul(ntx.GasUsed)})
 							if tx.From == "0x0000000000000000000000000000000000000000" {
 								found2 := false
 								if is, buyHash := cat.IsTxTokenSale(tx.Hash); is {
@@ -159,8 +226,8 @@ func (api *api) categorize(addresses []string, cat category.Category) {
 										if ntx2.Hash == buyHash {
 											found2 = true
 											api.normalTXs[k].used = true
-											t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: "ETH", Amount: ntx2.Value})
-											t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: "ETH", Amount: ntx2.GasPrice.Mul(ntx2.GasUsed)})
+											t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: api.native, Amount: ntx2.Value})
+											t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: api.native, Amount: ntx2.GasPrice.Mul(ntx2.GasUsed)})
 										}
 									}
 								}
@@ -178,7 +245,7 @@ func (api *api) categorize(addresses []string, cat category.Category) {
 										api.txsByCategory["Deposits"] = append(api.txsByCategory["Deposits"], t)
 									}
 								} else {
-									t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: "ETH", Amount: ntx.Value})
+									t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: api.native, Amount: ntx.Value})
 									api.txsByCategory["Swaps"] = append(api.txsByCategory["Swaps"], t)
 								}
 							}
@@ -194,12 +261,12 @@ func (api *api) categorize(addresses []string, cat category.Category) {
 								if ttx.Hash == tx.Hash {
 									api.tokenTXs[j].used = true
 									if api.ownAddress(ttx.To, addresses) && api.ownAddress(ttx.From, addresses) {
-										alreadyAsked = wallet.AskForHelp("Etherscan API ERC20 Self TX", ttx, alreadyAsked)
+										alreadyAsked = wallet.AskForHelp(api.name+" ERC20 Self TX", ttx, alreadyAsked)
 									} else if api.ownAddress(ttx.To, addresses) {
-										t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: ttx.TokenSymbol, Amount: ttx.Value})
+										t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: api.tokenPrefix + ttx.TokenSymbol, Amount: ttx.Value})
 									} else if api.ownAddress(ttx.From, addresses) {
 										found = true
-										t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: ttx.TokenSymbol, Amount: ttx.Value})
+										t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: api.tokenPrefix + ttx.TokenSymbol, Amount: ttx.Value})
 									}
 								}
 							}
@@ -217,10 +284,10 @@ func (api *api) categorize(addresses []string, cat category.Category) {
 						}
 					}
 				} else if api.ownAddress(tx.From, addresses) {
-					t := wallet.TX{Timestamp: tx.TimeStamp, ID: tx.Hash, Note: "Etherscan API : " + strconv.Itoa(tx.BlockNumber) + " " + tx.To}
+					t := wallet.TX{Timestamp: tx.TimeStamp, ID: tx.Hash, Note: api.name + " : " + strconv.Itoa(tx.BlockNumber) + " " + tx.To}
 					t.Items = make(map[string]wallet.Currencies)
-					t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: tx.TokenSymbol, Amount: tx.Value})
-					t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: "ETH", Amount: tx.GasPrice.Mul(tx.GasUsed)})
+					t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: api.tokenPrefix + tx.TokenSymbol, Amount: tx.Value})
+					t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: api.native, Amount: tx.GasPrice.Mul(tx.GasUsed)})
 					api.tokenTXs[i].used = true
 					// Add declared Fee if any
 					if is, feeHash := cat.IsTxFee(tx.Hash); is {
@@ -228,7 +295,7 @@ func (api *api) categorize(addresses []string, cat category.Category) {
 							for _, fee := range strings.Split(feeHash, ";") {
 								if ntx2.Hash == fee {
 									api.normalTXs[k].used = true
-									t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: "ETH", Amount: ntx2.GasPrice.Mul(ntx2.GasUsed)})
+									t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: api.native, Amount: ntx2.GasPrice.Mul(ntx2.GasUsed)})
 								}
 							}
 						}
@@ -243,7 +310,7 @@ func (api *api) categorize(addresses []string, cat category.Category) {
 								itx.BlockNumber == tx.BlockNumber &&
 								itx.Hash == tx.Hash {
 								found = true
-								t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: "ETH", Amount: itx.Value})
+								t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: api.native, Amount: itx.Value})
 								api.txsByCategory["Swaps"] = append(api.txsByCategory["Swaps"], t)
 								api.internalTXs[j].used = true
 								api.tokenTXs[i].used = true
@@ -256,12 +323,12 @@ func (api *api) categorize(addresses []string, cat category.Category) {
 								if !ttx.used && ttx.Hash == tx.Hash {
 									api.tokenTXs[j].used = true
 									if api.ownAddress(ttx.To, addresses) && api.ownAddress(ttx.From, addresses) {
-										alreadyAsked = wallet.AskForHelp("Etherscan API ERC20 Self TX", ttx, alreadyAsked)
+										alreadyAsked = wallet.AskForHelp(api.name+" ERC20 Self TX", ttx, alreadyAsked)
 									} else if api.ownAddress(ttx.To, addresses) {
 										found = true
-										t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: ttx.TokenSymbol, Amount: ttx.Value})
+										t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: api.tokenPrefix + ttx.TokenSymbol, Amount: ttx.Value})
 									} else if api.ownAddress(ttx.From, addresses) {
-										t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: ttx.TokenSymbol, Amount: ttx.Value})
+										t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: api.tokenPrefix + ttx.TokenSymbol, Amount: ttx.Value})
 									}
 								}
 							}
@@ -279,7 +346,7 @@ func (api *api) categorize(addresses []string, cat category.Category) {
 						}
 					}
 				} else {
-					alreadyAsked = wallet.AskForHelp("Etherscan API ERC20 TX", tx, alreadyAsked)
+					alreadyAsked = wallet.AskForHelp(api.name+" ERC20 TX", tx, alreadyAsked)
 				}
 			}
 		}
@@ -290,17 +357,17 @@ func (api *api) categorize(addresses []string, cat category.Category) {
 				api.internalTXs[i].used = true
 			} else {
 				if api.ownAddress(tx.To, addresses) && api.ownAddress(tx.From, addresses) {
-					alreadyAsked = wallet.AskForHelp("Etherscan API Internal Self TX", tx, alreadyAsked)
+					alreadyAsked = wallet.AskForHelp(api.name+" Internal Self TX", tx, alreadyAsked)
 				} else if api.ownAddress(tx.To, addresses) {
-					t := wallet.TX{Timestamp: tx.TimeStamp, ID: tx.Hash, Note: "Etherscan API : " + strconv.Itoa(tx.BlockNumber) + " " + tx.From}
+					t := wallet.TX{Timestamp: tx.TimeStamp, ID: tx.Hash, Note: api.name + " : " + strconv.Itoa(tx.BlockNumber) + " " + tx.From}
 					t.Items = make(map[string]wallet.Currencies)
-					t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: "ETH", Amount: tx.Value})
+					t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: api.native, Amount: tx.Value})
 					if is, feeHash := cat.IsTxFee(tx.Hash); is {
 						for k, ntx2 := range api.normalTXs {
 							for _, fee := range strings.Split(feeHash, ";") {
 								if ntx2.Hash == fee {
 									api.normalTXs[k].used = true
-									t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: "ETH", Amount: ntx2.GasPrice.Mul(ntx2.GasUsed)})
+									t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: api.native, Amount: ntx2.GasPrice.Mul(ntx2.GasUsed)})
 								}
 							}
 						}
@@ -308,13 +375,13 @@ func (api *api) categorize(addresses []string, cat category.Category) {
 					isExchange := false
 					for j, ntx := range api.normalTXs {
 						if ntx.Hash == tx.Hash {
-							t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: "ETH", Amount: ntx.GasPrice.Mul(ntx.GasUsed)})
+							t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: api.native, Amount: ntx.GasPrice.Mul(ntx.GasUsed)})
 							if !ntx.Value.IsZero() {
 								if api.ownAddress(ntx.From, addresses) {
-									t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: "ETH", Amount: ntx.Value})
+									t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: api.native, Amount: ntx.Value})
 									isExchange = true
 								} else {
-									alreadyAsked = wallet.AskForHelp("Etherscan API Internal Deposits TX with Normal Deposits TX associated", tx, alreadyAsked)
+									alreadyAsked = wallet.AskForHelp(api.name+" Internal Deposits TX with Normal Deposits TX associated", tx, alreadyAsked)
 								}
 							}
 							api.normalTXs[j].used = true
@@ -332,9 +399,9 @@ func (api *api) categorize(addresses []string, cat category.Category) {
 					}
 					api.internalTXs[i].used = true
 				} else if api.ownAddress(tx.From, addresses) {
-					alreadyAsked = wallet.AskForHelp("Etherscan API Internal Withdrawal TX", tx, alreadyAsked)
+					alreadyAsked = wallet.AskForHelp(api.name+" Internal Withdrawal TX", tx, alreadyAsked)
 				} else {
-					alreadyAsked = wallet.AskForHelp("Etherscan API Internal TX", tx, alreadyAsked)
+					alreadyAsked = wallet.AskForHelp(api.name+" Internal TX", tx, alreadyAsked)
 				}
 			}
 		}
@@ -348,18 +415,18 @@ func (api *api) categorize(addresses []string, cat category.Category) {
 				api.normalTXs[i].used = true
 			} else {
 				if api.ownAddress(tx.To, addresses) && api.ownAddress(tx.From, addresses) {
-					t := wallet.TX{Timestamp: tx.TimeStamp, ID: tx.Hash, Note: "Etherscan API : " + strconv.Itoa(tx.BlockNumber) + " "}
+					t := wallet.TX{Timestamp: tx.TimeStamp, ID: tx.Hash, Note: api.name + " : " + strconv.Itoa(tx.BlockNumber) + " "}
 					t.Items = make(map[string]wallet.Currencies)
-					t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: "ETH", Amount: tx.GasPrice.Mul(tx.GasUsed)})
+					t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: api.native, Amount: tx.GasPrice.Mul(tx.GasUsed)})
 					if tx.To == tx.From {
 						if !tx.Value.IsZero() {
-							alreadyAsked = wallet.AskForHelp("Etherscan API NonZero Self TX", tx, alreadyAsked)
+							alreadyAsked = wallet.AskForHelp(api.name+" NonZero Self TX", tx, alreadyAsked)
 						}
 						api.txsByCategory["Fees"] = append(api.txsByCategory["Fees"], t)
 						api.normalTXs[i].used = true
 					} else {
-						t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: "ETH", Amount: tx.Value})
-						t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: "ETH", Amount: tx.Value})
+						t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: api.native, Amount: tx.Value})
+						t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: api.native, Amount: tx.Value})
 						api.txsByCategory["Transfers"] = append(api.txsByCategory["Transfers"], t)
 						api.normalTXs[i].used = true
 						for j, ntx := range api.normalTXs {
@@ -372,9 +439,9 @@ func (api *api) categorize(addresses []string, cat category.Category) {
 					}
 				} else if api.ownAddress(tx.To, addresses) {
 					if !tx.Value.IsZero() {
-						t := wallet.TX{Timestamp: tx.TimeStamp, ID: tx.Hash, Note: "Etherscan API : " + strconv.Itoa(tx.BlockNumber) + " " + tx.From}
+						t := wallet.TX{Timestamp: tx.TimeStamp, ID: tx.Hash, Note: api.name + " : " + strconv.Itoa(tx.BlockNumber) + " " + tx.From}
 						t.Items = make(map[string]wallet.Currencies)
-						t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: "ETH", Amount: tx.Value})
+						t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: api.native, Amount: tx.Value})
 						if is, desc, val, curr := cat.IsTxExchange(tx.Hash); is {
 							t.Note += " crypto_exchange " + desc
 							t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: curr, Amount: val})
@@ -385,11 +452,11 @@ func (api *api) categorize(addresses []string, cat category.Category) {
 						api.normalTXs[i].used = true
 					}
 				} else if api.ownAddress(tx.From, addresses) {
-					t := wallet.TX{Timestamp: tx.TimeStamp, ID: tx.Hash, Note: "Etherscan API : " + strconv.Itoa(tx.BlockNumber) + " " + tx.To}
+					t := wallet.TX{Timestamp: tx.TimeStamp, ID: tx.Hash, Note: api.name + " : " + strconv.Itoa(tx.BlockNumber) + " " + tx.To}
 					t.Items = make(map[string]wallet.Currencies)
-					t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: "ETH", Amount: tx.GasPrice.Mul(tx.GasUsed)})
+					t.Items["Fee"] = append(t.Items["Fee"], wallet.Currency{Code: api.native, Amount: tx.GasPrice.Mul(tx.GasUsed)})
 					if !tx.Value.IsZero() {
-						t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: "ETH", Amount: tx.Value})
+						t.Items["From"] = append(t.Items["From"], wallet.Currency{Code: api.native, Amount: tx.Value})
 						// Is declared Exchanges
 						if is, desc, val, curr := cat.IsTxExchange(tx.Hash); is {
 							t.Note += " crypto_exchange " + desc
@@ -410,7 +477,7 @@ func (api *api) categorize(addresses []string, cat category.Category) {
 						api.normalTXs[i].used = true
 					}
 				} else {
-					alreadyAsked = wallet.AskForHelp("Etherscan API TX", tx, alreadyAsked)
+					alreadyAsked = wallet.AskForHelp(api.name+" TX", tx, alreadyAsked)
 				}
 			}
 		}
@@ -0,0 +1,146 @@
+package etherscan
+
+import (
+	"errors"
+	"time"
+
+	"github.com/nanobox-io/golang-scribble"
+	"github.com/shopspring/decimal"
+)
+
+type erc1155TX struct {
+	used              bool
+	BlockNumber       int
+	TimeStamp         time.Time
+	Hash              string
+	Nonce             int
+	BlockHash         string
+	From              string
+	ContractAddress   string
+	To                string
+	TokenID           string
+	TokenValue        decimal.Decimal
+	TokenName         string
+	TokenSymbol       string
+	TransactionIndex  int
+	Gas               int
+	GasPrice          decimal.Decimal
+	GasUsed           decimal.Decimal
+	CumulativeGasUsed int
+	Input             string
+	Confirmations     int
+}
+
+func (api *api) get1155TXs(addresses []string) {
+	for _, eth := range addresses {
+		acc1155TX, err := api.getAccount1155TX(eth, "", false)
+		if err != nil {
+			api.done1155 <- err
+			return
+		}
+		for _, mtTX := range acc1155TX.Result {
+			tx := erc1155TX{
+				used:              false,
+				BlockNumber:       mtTX.BlockNumber,
+				TimeStamp:         time.Unix(mtTX.TimeStamp, 0),
+				Hash:              mtTX.Hash,
+				Nonce:             mtTX.Nonce,
+				BlockHash:         mtTX.BlockHash,
+				From:              mtTX.From,
+				ContractAddress:   mtTX.ContractAddress,
+				To:                mtTX.To,
+				TokenID:           mtTX.TokenID,
+				TokenValue:        decimal.NewFromBigInt(mtTX.TokenValue.Int(), 0),
+				TokenName:         mtTX.TokenName,
+				TokenSymbol:       mtTX.TokenSymbol,
+				TransactionIndex:  mtTX.TransactionIndex,
+				Gas:               mtTX.Gas,
+				GasPrice:          decimal.NewFromBigInt(mtTX.GasPrice.Int(), -18),
+				GasUsed:           decimal.NewFromInt(mtTX.GasUsed),
+				CumulativeGasUsed: mtTX.CumulativeGasUsed,
+				Input:             mtTX.Input,
+				Confirmations:     mtTX.Confirmations,
+			}
+			api.erc1155TXs = append(api.erc1155TXs, tx)
+		}
+	}
+	api.done1155 <- nil
+}
+
+type Result1155TX struct {
+	BlockNumber       int     `json:"blockNumber,string"`
+	TimeStamp         int64   `json:"timeStamp,string"`
+	Hash              string  `json:"hash"`
+	Nonce             int     `json:"nonce,string"`
+	BlockHash         string  `json:"blockHash"`
+	From              string  `json:"from"`
+	ContractAddress   string  `json:"contractAddress"`
+	To                string  `json:"to"`
+	TokenID           string  `json:"tokenID"`
+	TokenValue        *bigInt `json:"tokenValue"`
+	TokenName         string  `json:"tokenName"`
+	TokenSymbol       string  `json:"tokenSymbol"`
+	TransactionIndex  int     `json:"transactionIndex,string"`
+	Gas               int     `json:"gas,string"`
+	GasPrice          *bigInt `json:"gasPrice"`
+	GasUsed           int64   `json:"gasUsed,string"`
+	CumulativeGasUsed int     `json:"cumulativeGasUsed,string"`
+	Input             string  `json:"input"`
+	Confirmations     int     `json:"confirmations,string"`
+}
+
+type GetAccount1155TXResp struct {
+	Status  string         `json:"status"`
+	Message string         `json:"message"`
+	Result  []Result1155TX `json:"result"`
+}
+
+func (api *api) getAccount1155TX(address, contractAddress string, desc bool) (acc1155TX GetAccount1155TXResp, err error) {
+	const SOURCE = "Etherscan API ERC1155 TX :"
+	ident := "a" + address + "-c" + contractAddress
+	useCache := true
+	db, err := scribble.New(api.cacheDir, nil)
+	if err != nil {
+		useCache = false
+	}
+	if useCache {
+		err = db.Read(api.cachePrefix+"/account/token1155tx", ident, &acc1155TX)
+	}
+	if !useCache || err != nil {
+		params := map[string]string{
+			"module": "account",
+			"action": "token1155tx",
+			"apikey": api.apiKey,
+		}
+		if address != "" {
+			params["address"] = address
+		}
+		if contractAddress != "" {
+			params["contractaddress"] = contractAddress
+		}
+		if desc {
+			params["sort"] = "desc"
+		} else {
+			params["sort"] = "asc"
+		}
+		resp, err := api.client1155.R().
+			SetQueryParams(params).
+			SetHeader("Accept", "application/json").
+			SetResult(&GetAccount1155TXResp{}).
+			Get(api.basePath)
+		if err != nil {
+			return acc1155TX, errors.New(SOURCE + " Error Requesting " + ident)
+		}
+		acc1155TX = *resp.Result().(*GetAccount1155TXResp)
+		if useCache {
+			err = db.Write(api.cachePrefix+"/account/token1155tx", ident, acc1155TX)
+			if err != nil {
+				return acc1155TX, errors.New(SOURCE + " Error Caching " + ident)
+			}
+		}
+		if acc1155TX.Message == "OK" {
+			time.Sleep(api.timeBetweenReq)
+		}
+	}
+	return acc1155TX, nil
+}
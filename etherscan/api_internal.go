@@ -85,12 +85,12 @@ type GetAccountTXListInternalResp struct {
 func (api *api) getAccountTXListInternal(address string, desc bool) (accTXListInternal GetAccountTXListInternalResp, err error) {
 	const SOURCE = "Etherscan API TX List Internal :"
 	useCache := true
-	db, err := scribble.New("./Cache", nil)
+	db, err := scribble.New(api.cacheDir, nil)
 	if err != nil {
 		useCache = false
 	}
 	if useCache {
-		err = db.Read("Etherscan.io/account/txlistinternal", address, &accTXListInternal)
+		err = db.Read(api.cachePrefix+"/account/txlistinternal", address, &accTXListInternal)
 	}
 	if !useCache || err != nil {
 		params := map[string]string{
@@ -114,7 +114,7 @@ func (api *api) getAccountTXListInternal(address string, desc bool) (accTXListIn
 		}
 		accTXListInternal = *resp.Result().(*GetAccountTXListInternalResp)
 		if useCache {
-			err = db.Write("Etherscan.io/account/txlistinternal", address, accTXListInternal)
+			err = db.Write(api.cachePrefix+"/account/txlistinternal", address, accTXListInternal)
 			if err != nil {
 				return accTXListInternal, errors.New(SOURCE + " Error Caching " + address)
 			}
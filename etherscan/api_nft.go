@@ -99,12 +99,12 @@ func (api *api) getAccountNftTX(address, contractAddress string, desc bool) (acc
 	const SOURCE = "Etherscan API Nft TX :"
 	ident := "a" + address + "-c" + contractAddress
 	useCache := true
-	db, err := scribble.New("./Cache", nil)
+	db, err := scribble.New(api.cacheDir, nil)
 	if err != nil {
 		useCache = false
 	}
 	if useCache {
-		err = db.Read("Etherscan.io/account/tokennfttx", ident, &accNftTX)
+		err = db.Read(api.cachePrefix+"/account/tokennfttx", ident, &accNftTX)
 	}
 	if !useCache || err != nil {
 		params := map[string]string{
@@ -133,7 +133,7 @@ func (api *api) getAccountNftTX(address, contractAddress string, desc bool) (acc
 		}
 		accNftTX = *resp.Result().(*GetAccountNftTXResp)
 		if useCache {
-			err = db.Write("Etherscan.io/account/tokennfttx", ident, accNftTX)
+			err = db.Write(api.cachePrefix+"/account/tokennfttx", ident, accNftTX)
 			if err != nil {
 				return accNftTX, errors.New(SOURCE + " Error Caching " + ident)
 			}
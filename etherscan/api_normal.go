@@ -97,12 +97,12 @@ type GetAccountTXListResp struct {
 func (api *api) getAccountTXList(address string, desc bool) (accTXList GetAccountTXListResp, err error) {
 	const SOURCE = "Etherscan API TX List :"
 	useCache := true
-	db, err := scribble.New("./Cache", nil)
+	db, err := scribble.New(api.cacheDir, nil)
 	if err != nil {
 		useCache = false
 	}
 	if useCache {
-		err = db.Read("Etherscan.io/account/txlist", address, &accTXList)
+		err = db.Read(api.cachePrefix+"/account/txlist", address, &accTXList)
 	}
 	if !useCache || err != nil {
 		params := map[string]string{
@@ -126,7 +126,7 @@ func (api *api) getAccountTXList(address string, desc bool) (accTXList GetAccoun
 		}
 		accTXList = *resp.Result().(*GetAccountTXListResp)
 		if useCache {
-			err = db.Write("Etherscan.io/account/txlist", address, accTXList)
+			err = db.Write(api.cachePrefix+"/account/txlist", address, accTXList)
 			if err != nil {
 				return accTXList, errors.New(SOURCE + " Error Caching " + address)
 			}
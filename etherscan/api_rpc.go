@@ -0,0 +1,422 @@
+package etherscan
+
+import (
+	"encoding/hex"
+	"errors"
+	"log"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// keccak256("Transfer(address,address,uint256)"), shared by ERC20 and ERC721
+const transferTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+
+// ERC1155 TransferSingle(address,address,address,uint256,uint256) and TransferBatch(address,address,address,uint256[],uint256[])
+const (
+	transferSingleTopic = "0xc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62"
+	transferBatchTopic  = "0x4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb"
+)
+
+type rpcTraceFilter struct {
+	FromBlock   string   `json:"fromBlock"`
+	ToBlock     string   `json:"toBlock"`
+	FromAddress []string `json:"fromAddress,omitempty"`
+	ToAddress   []string `json:"toAddress,omitempty"`
+}
+
+type rpcTrace struct {
+	Action struct {
+		From          string `json:"from"`
+		To            string `json:"to"`
+		Value         string `json:"value"`
+		Address       string `json:"address"`
+		RefundAddress string `json:"refundAddress"`
+		Balance       string `json:"balance"`
+	} `json:"action"`
+	Result struct {
+		Address string `json:"address"`
+	} `json:"result"`
+	BlockNumber     int    `json:"blockNumber"`
+	TraceAddress    []int  `json:"traceAddress"`
+	TransactionHash string `json:"transactionHash"`
+	Type            string `json:"type"`
+	Error           string `json:"error"`
+}
+
+type rpcLogFilter struct {
+	FromBlock string        `json:"fromBlock"`
+	ToBlock   string        `json:"toBlock"`
+	Topics    []interface{} `json:"topics"`
+}
+
+type rpcLog struct {
+	Address         string   `json:"address"`
+	Topics          []string `json:"topics"`
+	Data            string   `json:"data"`
+	BlockNumber     string   `json:"blockNumber"`
+	TransactionHash string   `json:"transactionHash"`
+	LogIndex        string   `json:"logIndex"`
+	Removed         bool     `json:"removed"`
+}
+
+type rpcReceipt struct {
+	GasUsed           string `json:"gasUsed"`
+	EffectiveGasPrice string `json:"effectiveGasPrice"`
+	Status            string `json:"status"`
+}
+
+type rpcTransaction struct {
+	GasPrice string `json:"gasPrice"`
+}
+
+type rpcBlock struct {
+	Timestamp string `json:"timestamp"`
+}
+
+type rpcCallMsg struct {
+	To   string `json:"to"`
+	Data string `json:"data"`
+}
+
+type rpcToken struct {
+	name     string
+	symbol   string
+	decimals uint8
+}
+
+type rpcGas struct {
+	price decimal.Decimal
+	used  decimal.Decimal
+}
+
+func (api *api) getRPCTXs(addresses []string) (err error) {
+	const SOURCE = "Ethereum RPC :"
+	blocks := make(map[int]time.Time)
+	gas := make(map[string]rpcGas)
+	tokens := make(map[string]rpcToken)
+	seen := make(map[string]bool)
+	for _, add := range addresses {
+		var traces []rpcTrace
+		for _, filter := range []rpcTraceFilter{
+			{FromBlock: "0x0", ToBlock: "latest", FromAddress: []string{add}},
+			{FromBlock: "0x0", ToBlock: "latest", ToAddress: []string{add}},
+		} {
+			var res []rpcTrace
+			err = api.node.call("trace_filter", &res, filter)
+			if err != nil {
+				return errors.New(err.Error() + " (trace_filter needs an Erigon or Nethermind archive node, Geth does not provide it)")
+			}
+			traces = append(traces, res...)
+		}
+		for _, tr := range traces {
+			id := tr.TransactionHash + fmtTraceAddress(tr.TraceAddress)
+			if tr.Error != "" || tr.Type == "reward" || seen[id] {
+				continue
+			}
+			seen[id] = true
+			from, to, value := strings.ToLower(tr.Action.From), strings.ToLower(tr.Action.To), tr.Action.Value
+			if tr.Type == "create" {
+				to = strings.ToLower(tr.Result.Address)
+			} else if tr.Type == "suicide" {
+				from, to, value = strings.ToLower(tr.Action.Address), strings.ToLower(tr.Action.RefundAddress), tr.Action.Balance
+			}
+			timestamp, err := api.rpcBlockTime(tr.BlockNumber, blocks)
+			if err != nil {
+				return err
+			}
+			if len(tr.TraceAddress) == 0 {
+				g, err := api.rpcTXGas(tr.TransactionHash, gas)
+				if err != nil {
+					return err
+				}
+				api.normalTXs = append(api.normalTXs, normalTX{
+					BlockNumber: tr.BlockNumber,
+					TimeStamp:   timestamp,
+					Hash:        tr.TransactionHash,
+					From:        from,
+					To:          to,
+					Value:       decimal.NewFromBigInt(hexToBig(value), -18),
+					GasPrice:    g.price,
+					GasUsed:     g.used,
+				})
+			} else if hexToBig(value).Sign() > 0 {
+				api.internalTXs = append(api.internalTXs, internalTX{
+					BlockNumber: tr.BlockNumber,
+					TimeStamp:   timestamp,
+					Hash:        tr.TransactionHash,
+					From:        from,
+					To:          to,
+					Value:       decimal.NewFromBigInt(hexToBig(value), -18),
+					Type:        tr.Type,
+					TraceID:     fmtTraceAddress(tr.TraceAddress),
+				})
+			}
+		}
+		var logs []rpcLog
+		padded := "0x000000000000000000000000" + strings.TrimPrefix(add, "0x")
+		for _, filter := range []rpcLogFilter{
+			{FromBlock: "0x0", ToBlock: "latest", Topics: []interface{}{transferTopic, padded}},
+			{FromBlock: "0x0", ToBlock: "latest", Topics: []interface{}{transferTopic, nil, padded}},
+		} {
+			var res []rpcLog
+			err = api.node.call("eth_getLogs", &res, filter)
+			if err != nil {
+				return
+			}
+			logs = append(logs, res...)
+		}
+		for _, l := range logs {
+			id := l.TransactionHash + "-" + l.LogIndex
+			if l.Removed || seen[id] || len(l.Topics) < 3 {
+				continue
+			}
+			if !validTopics(l.Topics) {
+				log.Println(SOURCE, "Skipping Log with malformed Topics", id)
+				continue
+			}
+			seen[id] = true
+			blockNumber := int(hexToBig(l.BlockNumber).Int64())
+			timestamp, err := api.rpcBlockTime(blockNumber, blocks)
+			if err != nil {
+				return err
+			}
+			g, err := api.rpcTXGas(l.TransactionHash, gas)
+			if err != nil {
+				return err
+			}
+			contract := strings.ToLower(l.Address)
+			tok, ok := tokens[contract]
+			if !ok {
+				tok = api.rpcTokenInfo(contract)
+				tokens[contract] = tok
+			}
+			from := "0x" + strings.ToLower(l.Topics[1][26:])
+			to := "0x" + strings.ToLower(l.Topics[2][26:])
+			if len(l.Topics) == 4 {
+				api.nftTXs = append(api.nftTXs, nftTX{
+					BlockNumber:     blockNumber,
+					TimeStamp:       timestamp,
+					Hash:            l.TransactionHash,
+					From:            from,
+					ContractAddress: contract,
+					To:              to,
+					TokenID:         hexToBig(l.Topics[3]).String(),
+					TokenName:       tok.name,
+					TokenSymbol:     tok.symbol,
+					GasPrice:        g.price,
+					GasUsed:         g.used,
+				})
+			} else {
+				api.tokenTXs = append(api.tokenTXs, tokenTX{
+					BlockNumber:     blockNumber,
+					TimeStamp:       timestamp,
+					Hash:            l.TransactionHash,
+					From:            from,
+					ContractAddress: contract,
+					To:              to,
+					Value:           decimal.NewFromBigInt(hexToBig(l.Data), -int32(tok.decimals)),
+					TokenName:       tok.name,
+					TokenSymbol:     tok.symbol,
+					TokenDecimal:    tok.decimals,
+					GasPrice:        g.price,
+					GasUsed:         g.used,
+				})
+			}
+		}
+		var mtLogs []rpcLog
+		mtTopics := []string{transferSingleTopic, transferBatchTopic}
+		for _, filter := range []rpcLogFilter{
+			{FromBlock: "0x0", ToBlock: "latest", Topics: []interface{}{mtTopics, nil, padded}},
+			{FromBlock: "0x0", ToBlock: "latest", Topics: []interface{}{mtTopics, nil, nil, padded}},
+		} {
+			var res []rpcLog
+			err = api.node.call("eth_getLogs", &res, filter)
+			if err != nil {
+				return
+			}
+			mtLogs = append(mtLogs, res...)
+		}
+		for _, l := range mtLogs {
+			id := l.TransactionHash + "-" + l.LogIndex
+			if l.Removed || seen[id] || len(l.Topics) < 4 {
+				continue
+			}
+			if !validTopics(l.Topics) {
+				log.Println(SOURCE, "Skipping Log with malformed Topics", id)
+				continue
+			}
+			seen[id] = true
+			blockNumber := int(hexToBig(l.BlockNumber).Int64())
+			timestamp, err := api.rpcBlockTime(blockNumber, blocks)
+			if err != nil {
+				return err
+			}
+			g, err := api.rpcTXGas(l.TransactionHash, gas)
+			if err != nil {
+				return err
+			}
+			contract := strings.ToLower(l.Address)
+			tok, ok := tokens[contract]
+			if !ok {
+				tok = api.rpcTokenInfo(contract)
+				tokens[contract] = tok
+			}
+			ids, values := decode1155(l.Topics[0], l.Data)
+			for k := range ids {
+				api.erc1155TXs = append(api.erc1155TXs, erc1155TX{
+					BlockNumber:     blockNumber,
+					TimeStamp:       timestamp,
+					Hash:            l.TransactionHash,
+					From:            "0x" + strings.ToLower(l.Topics[2][26:]),
+					ContractAddress: contract,
+					To:              "0x" + strings.ToLower(l.Topics[3][26:]),
+					TokenID:         ids[k].String(),
+					TokenValue:      decimal.NewFromBigInt(values[k], 0),
+					TokenName:       tok.name,
+					TokenSymbol:     tok.symbol,
+					GasPrice:        g.price,
+					GasUsed:         g.used,
+				})
+			}
+		}
+	}
+	return api.node.saveDump()
+}
+
+// validTopics checks that each Topic is a 32 bytes word before its address or ID is sliced out
+func validTopics(topics []string) bool {
+	for _, t := range topics {
+		if len(t) != 66 || !strings.HasPrefix(t, "0x") {
+			return false
+		}
+	}
+	return true
+}
+
+// decode1155 returns the token IDs and values of a TransferSingle or TransferBatch log
+func decode1155(topic, data string) (ids, values []*big.Int) {
+	b, err := hex.DecodeString(strings.TrimPrefix(data, "0x"))
+	if err != nil || len(b) < 64 {
+		return
+	}
+	first, second := new(big.Int).SetBytes(b[:32]), new(big.Int).SetBytes(b[32:64])
+	if topic == transferSingleTopic {
+		return []*big.Int{first}, []*big.Int{second}
+	}
+	ids = decodeUintArray(b, first)
+	values = decodeUintArray(b, second)
+	if len(ids) != len(values) {
+		return nil, nil
+	}
+	return
+}
+
+func decodeUintArray(b []byte, offset *big.Int) (arr []*big.Int) {
+	if !offset.IsInt64() || offset.Int64()+32 > int64(len(b)) {
+		return
+	}
+	o := offset.Int64()
+	n := new(big.Int).SetBytes(b[o : o+32])
+	if !n.IsInt64() || n.Int64() > int64(len(b)) || o+32+32*n.Int64() > int64(len(b)) {
+		return
+	}
+	for i := int64(0); i < n.Int64(); i++ {
+		start := o + 32 + 32*i
+		arr = append(arr, new(big.Int).SetBytes(b[start:start+32]))
+	}
+	return
+}
+
+func (api *api) rpcBlockTime(number int, blocks map[int]time.Time) (time.Time, error) {
+	if t, ok := blocks[number]; ok {
+		return t, nil
+	}
+	var b rpcBlock
+	err := api.node.call("eth_getBlockByNumber", &b, "0x"+strconv.FormatInt(int64(number), 16), false)
+	if err != nil {
+		return time.Time{}, err
+	}
+	blocks[number] = time.Unix(hexToBig(b.Timestamp).Int64(), 0)
+	return blocks[number], nil
+}
+
+func (api *api) rpcTXGas(hash string, gas map[string]rpcGas) (rpcGas, error) {
+	if g, ok := gas[hash]; ok {
+		return g, nil
+	}
+	var r rpcReceipt
+	err := api.node.call("eth_getTransactionReceipt", &r, hash)
+	if err != nil {
+		return rpcGas{}, err
+	}
+	price := r.EffectiveGasPrice
+	if price == "" {
+		// nodes before London do not give effectiveGasPrice
+		var tx rpcTransaction
+		err = api.node.call("eth_getTransactionByHash", &tx, hash)
+		if err != nil {
+			return rpcGas{}, err
+		}
+		price = tx.GasPrice
+	}
+	gas[hash] = rpcGas{
+		price: decimal.NewFromBigInt(hexToBig(price), -18),
+		used:  decimal.NewFromBigInt(hexToBig(r.GasUsed), 0),
+	}
+	return gas[hash], nil
+}
+
+// rpcTokenInfo reads name(), symbol() and decimals() of a token contract,
+// missing values (ERC721 has no decimals) are left empty
+func (api *api) rpcTokenInfo(contract string) (tok rpcToken) {
+	var res string
+	if api.node.call("eth_call", &res, rpcCallMsg{To: contract, Data: "0x06fdde03"}, "latest") == nil {
+		tok.name = decodeABIString(res)
+	}
+	if api.node.call("eth_call", &res, rpcCallMsg{To: contract, Data: "0x95d89b41"}, "latest") == nil {
+		tok.symbol = decodeABIString(res)
+	}
+	if api.node.call("eth_call", &res, rpcCallMsg{To: contract, Data: "0x313ce567"}, "latest") == nil {
+		tok.decimals = uint8(hexToBig(res).Uint64())
+	}
+	return
+}
+
+func fmtTraceAddress(traceAddress []int) string {
+	id := ""
+	for _, i := range traceAddress {
+		id += "_" + strconv.Itoa(i)
+	}
+	return id
+}
+
+func hexToBig(h string) *big.Int {
+	i, ok := new(big.Int).SetString(strings.TrimPrefix(h, "0x"), 16)
+	if !ok {
+		return new(big.Int)
+	}
+	return i
+}
+
+// decodeABIString decodes an ABI encoded string, or a bytes32 for old tokens like MKR
+func decodeABIString(h string) string {
+	b, err := hex.DecodeString(strings.TrimPrefix(h, "0x"))
+	if err != nil {
+		return ""
+	}
+	if len(b) >= 64 {
+		offset := new(big.Int).SetBytes(b[:32])
+		if offset.IsInt64() && offset.Int64()+32 <= int64(len(b)) {
+			o := offset.Int64()
+			length := new(big.Int).SetBytes(b[o : o+32])
+			if length.IsInt64() && o+32+length.Int64() <= int64(len(b)) {
+				return string(b[o+32 : o+32+length.Int64()])
+			}
+		}
+	}
+	return strings.TrimRight(string(b), "\x00")
+}
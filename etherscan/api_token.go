@@ -99,12 +99,12 @@ func (api *api) getAccountTokenTX(address, contractAddress string, desc bool) (a
 	const SOURCE = "Etherscan API TokenTX :"
 	ident := "a" + address + "-c" + contractAddress
 	useCache := true
-	db, err := scribble.New("./Cache", nil)
+	db, err := scribble.New(api.cacheDir, nil)
 	if err != nil {
 		useCache = false
 	}
 	if useCache {
-		err = db.Read("Etherscan.io/account/tokentx", ident, &accTokTX)
+		err = db.Read(api.cachePrefix+"/account/tokentx", ident, &accTokTX)
 	}
 	if !useCache || err != nil {
 		params := map[string]string{
@@ -133,7 +133,7 @@ func (api *api) getAccountTokenTX(address, contractAddress string, desc bool) (a
 		}
 		accTokTX = *resp.Result().(*GetAccountTokenTXResp)
 		if useCache {
-			err = db.Write("Etherscan.io/account/tokentx", ident, accTokTX)
+			err = db.Write(api.cachePrefix+"/account/tokentx", ident, accTokTX)
 			if err != nil {
 				return accTokTX, errors.New(SOURCE + " Error Caching " + ident)
 			}
@@ -0,0 +1,56 @@
+package etherscan
+
+import (
+	"errors"
+	"strings"
+)
+
+type explorer struct {
+	name      string
+	basePath  string
+	native    string
+	coingecko string // asset platform of the token contracts on CoinGecko
+}
+
+// Etherscan-family explorers sharing the same API
+var explorers = map[string]explorer{
+	"ETH":      {name: "Etherscan", basePath: "https://api.etherscan.io/api", native: "ETH", coingecko: "ethereum"},
+	"BSC":      {name: "BscScan", basePath: "https://api.bscscan.com/api", native: "BNB", coingecko: "binance-smart-chain"},
+	"POLYGON":  {name: "PolygonScan", basePath: "https://api.polygonscan.com/api", native: "MATIC", coingecko: "polygon-pos"},
+	"ARBITRUM": {name: "Arbiscan", basePath: "https://api.arbiscan.io/api", native: "ETH", coingecko: "arbitrum-one"},
+	"OPTIMISM": {name: "Optimistic Etherscan", basePath: "https://api-optimistic.etherscan.io/api", native: "ETH", coingecko: "optimistic-ethereum"},
+	"AVAX":     {name: "Snowtrace", basePath: "https://api.snowtrace.io/api", native: "AVAX", coingecko: "avalanche"},
+	"FTM":      {name: "FtmScan", basePath: "https://api.ftmscan.com/api", native: "FTM", coingecko: "fantom"},
+}
+
+func IsKnownChain(chain string) bool {
+	_, ok := explorers[chain]
+	return ok
+}
+
+func (ethsc *Etherscan) NewChainAPI(chain, basePath, apiKey, native string, debug bool) error {
+	const SOURCE = "Etherscan API :"
+	exp, ok := explorers[chain]
+	if !ok {
+		if basePath == "" || native == "" {
+			return errors.New(SOURCE + " Unknown chain " + chain + ", please provide its explorer URL and native token")
+		}
+		exp.name = chain + " Explorer"
+	}
+	if basePath != "" {
+		exp.basePath = strings.TrimSuffix(basePath, "/")
+	}
+	if native != "" {
+		exp.native = native
+	}
+	ethsc.NewAPI(apiKey, debug)
+	ethsc.api.chain = chain
+	ethsc.api.name = exp.name + " API"
+	ethsc.api.basePath = exp.basePath
+	ethsc.api.native = exp.native
+	if chain != "ETH" {
+		ethsc.api.tokenPrefix = chain + ":"
+		ethsc.api.cachePrefix = "Etherscan/" + chain
+	}
+	return nil
+}
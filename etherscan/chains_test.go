@@ -0,0 +1,78 @@
+package etherscan
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/category"
+	"github.com/shopspring/decimal"
+)
+
+// fakeExplorer answers like BscScan for an address receiving BNB and USDT
+func fakeExplorer(t *testing.T) *httptest.Server {
+	empty := `{"status":"0","message":"No transactions found","result":[]}`
+	responses := map[string]string{
+		"txlist":         `{"status":"1","message":"OK","result":[{"blockNumber":"100","timeStamp":"1620000000","hash":"0xa","from":"0xext","to":"0xme","value":"1500000000000000000","gasPrice":"5000000000","isError":"0","gasUsed":"21000"}]}`,
+		"txlistinternal": empty,
+		"tokentx":        `{"status":"1","message":"OK","result":[{"blockNumber":"101","timeStamp":"1620001000","hash":"0xb","from":"0xext","contractAddress":"0x55d3","to":"0xme","value":"250000000000000000000","tokenName":"Tether USD","tokenSymbol":"USDT","tokenDecimal":"18","gasPrice":"5000000000","gasUsed":"50000"}]}`,
+		"tokennfttx":     empty,
+		"token1155tx":    empty,
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("apikey") != "key" {
+			t.Errorf("missing apikey on %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(responses[r.URL.Query().Get("action")]))
+	}))
+}
+
+func TestEtherscan_NewChainAPI(t *testing.T) {
+	ethsc := New()
+	err := ethsc.NewChainAPI("POLYGON", "", "key", "", false)
+	if err != nil || ethsc.api.basePath != "https://api.polygonscan.com/api" || ethsc.api.native != "MATIC" {
+		t.Errorf("NewChainAPI(POLYGON) = %v %v %v", err, ethsc.api.basePath, ethsc.api.native)
+	}
+	err = ethsc.NewChainAPI("CRONOS", "", "key", "", false)
+	if err == nil {
+		t.Errorf("NewChainAPI(CRONOS) should fail without URL and native token")
+	}
+	err = ethsc.NewChainAPI("CRONOS", "https://api.cronoscan.com/api/", "key", "CRO", false)
+	if err != nil || ethsc.api.basePath != "https://api.cronoscan.com/api" || ethsc.api.tokenPrefix != "CRONOS:" {
+		t.Errorf("NewChainAPI(CRONOS) = %v %v %v", err, ethsc.api.basePath, ethsc.api.tokenPrefix)
+	}
+}
+
+func TestEtherscan_GetAPITXsOtherChain(t *testing.T) {
+	srv := fakeExplorer(t)
+	defer srv.Close()
+	ethsc := New()
+	ethsc.AddListAddresses([]string{"0xME"})
+	err := ethsc.NewChainAPI("BSC", srv.URL, "key", "", false)
+	if err != nil {
+		t.Fatalf("NewChainAPI() error = %v", err)
+	}
+	ethsc.api.cacheDir = t.TempDir()
+	ethsc.api.timeBetweenReq = 0
+	go ethsc.GetAPITXs(*category.New())
+	err = ethsc.WaitFinish()
+	if err != nil {
+		t.Fatalf("GetAPITXs() error = %v", err)
+	}
+	deps := ethsc.TXsByCategory["Deposits"]
+	if len(deps) != 2 {
+		t.Fatalf("Deposits = %v, want 2 TXs", deps)
+	}
+	want := map[string]string{"BNB": "1.5", "BSC:USDT": "250"}
+	for _, tx := range deps {
+		to := tx.Items["To"][0]
+		if w, ok := want[to.Code]; !ok || !to.Amount.Equal(decimal.RequireFromString(w)) {
+			t.Errorf("Deposit To = %v %v", to.Amount, to.Code)
+		}
+		if !strings.HasPrefix(tx.Note, "BscScan API :") {
+			t.Errorf("Deposit Note = %v", tx.Note)
+		}
+	}
+}
@@ -4,17 +4,31 @@ import (
 	"encoding/csv"
 	"io"
 	"strings"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/utils"
 )
 
+var CSVFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"Address", "Description"}, Positional: true},
+}
+
 func (ethsc *Etherscan) ParseCSVAddresses(reader io.Reader) (err error) {
+	const SOURCE = "Etherscan Addresses CSV :"
 	csvReader := csv.NewReader(reader)
 	records, err := csvReader.ReadAll()
 	if err == nil {
-		for _, r := range records {
-			if r[0] != "Address" {
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVFormats...)
+				if err != nil {
+					return
+				}
+			}
+			if !l.IsHeader(r) {
 				a := address{}
-				a.address = strings.ToLower(r[0])
-				a.description = r[1]
+				a.address = strings.ToLower(l.Get(r, "Address"))
+				a.description = l.Get(r, "Description")
 				ethsc.addresses = append(ethsc.addresses, a)
 			}
 		}
@@ -0,0 +1,134 @@
+package etherscan
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/go-resty/resty/v2"
+)
+
+type rpcCall struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+}
+
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int           `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// rpc talks to a JSON-RPC node, or replays a dump of a previous session
+// when no node URL is given. With both, the session is recorded in the dump.
+type rpc struct {
+	client   *resty.Client
+	url      string
+	dumpFile string
+	calls    map[string]json.RawMessage
+	record   []rpcCall
+}
+
+func newRPC(url, dumpFile string, debug bool) (*rpc, error) {
+	const SOURCE = "Ethereum RPC :"
+	r := &rpc{url: url, dumpFile: dumpFile}
+	r.calls = make(map[string]json.RawMessage)
+	if url != "" {
+		r.client = resty.New()
+		r.client.SetRetryCount(3)
+		r.client.SetDebug(debug)
+		return r, nil
+	}
+	if dumpFile == "" {
+		return nil, errors.New(SOURCE + " Node URL or Dump file needed")
+	}
+	raw, err := ioutil.ReadFile(dumpFile)
+	if err != nil {
+		return nil, errors.New(SOURCE + " Error Reading Dump " + dumpFile)
+	}
+	var dump []rpcCall
+	err = json.Unmarshal(raw, &dump)
+	if err != nil {
+		return nil, errors.New(SOURCE + " Error Parsing Dump " + dumpFile)
+	}
+	for _, c := range dump {
+		var p bytes.Buffer
+		err = json.Compact(&p, c.Params)
+		if err != nil {
+			return nil, errors.New(SOURCE + " Error Parsing Dump " + dumpFile)
+		}
+		r.calls[c.Method+p.String()] = c.Result
+	}
+	return r, nil
+}
+
+func (r *rpc) call(method string, result interface{}, params ...interface{}) error {
+	const SOURCE = "Ethereum RPC :"
+	if params == nil {
+		params = []interface{}{}
+	}
+	p, err := json.Marshal(params)
+	if err != nil {
+		return errors.New(SOURCE + " Error Encoding " + method)
+	}
+	raw, ok := r.calls[method+string(p)]
+	if !ok {
+		if r.client == nil {
+			return errors.New(SOURCE + " Missing " + method + " " + string(p) + " in Dump " + r.dumpFile)
+		}
+		resp, err := r.client.R().
+			SetHeader("Content-Type", "application/json").
+			SetBody(rpcRequest{JSONRPC: "2.0", ID: len(r.record) + 1, Method: method, Params: params}).
+			SetResult(&rpcResponse{}).
+			Post(r.url)
+		if err != nil {
+			return errors.New(SOURCE + " Error Requesting " + method)
+		}
+		if !resp.IsSuccess() {
+			return errors.New(SOURCE + " Error StatusCode " + strconv.Itoa(resp.StatusCode()) + " for " + method)
+		}
+		res := resp.Result().(*rpcResponse)
+		if res.Error != nil {
+			return errors.New(SOURCE + " " + method + " " + res.Error.Message)
+		}
+		raw = res.Result
+		r.calls[method+string(p)] = raw
+		r.record = append(r.record, rpcCall{Method: method, Params: p, Result: raw})
+	}
+	return json.Unmarshal(raw, result)
+}
+
+func (r *rpc) saveDump() error {
+	const SOURCE = "Ethereum RPC :"
+	if r.client == nil || r.dumpFile == "" {
+		return nil
+	}
+	raw, err := json.MarshalIndent(r.record, "", "  ")
+	if err != nil {
+		return errors.New(SOURCE + " Error Encoding Dump")
+	}
+	err = ioutil.WriteFile(r.dumpFile, raw, 0600)
+	if err != nil {
+		return errors.New(SOURCE + " Error Writing Dump " + r.dumpFile)
+	}
+	return nil
+}
+
+// UseRPC replaces the explorer by a local node and/or a dump, must be called after NewAPI or NewChainAPI
+func (ethsc *Etherscan) UseRPC(url, dumpFile string, debug bool) (err error) {
+	ethsc.api.node, err = newRPC(url, dumpFile, debug)
+	ethsc.api.name = ethsc.api.chain + " Node"
+	return
+}
@@ -0,0 +1,203 @@
+package etherscan
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/category"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	me     = "0x00000000000000000000000000000000000000aa"
+	padMe  = "0x00000000000000000000000000000000000000000000000000000000000000aa"
+	padExt = "0x00000000000000000000000000000000000000000000000000000000000000bb"
+)
+
+// fakeNode answers like an archive node with the trace module for "me"
+func fakeNode(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		result := "null"
+		switch req.Method {
+		case "trace_filter":
+			var f rpcTraceFilter
+			json.Unmarshal(req.Params[0], &f)
+			if len(f.FromAddress) > 0 {
+				result = `[{"action":{"from":"` + me + `","to":"0xbb","value":"0xde0b6b3a7640000"},"blockNumber":10,"traceAddress":[],"transactionHash":"0xa","type":"call"},
+					{"action":{"from":"` + me + `","to":"0xbb","value":"0x1"},"blockNumber":10,"traceAddress":[],"transactionHash":"0xf","type":"call","error":"Reverted"}]`
+			} else {
+				result = `[{"action":{"from":"0xbb","to":"` + me + `","value":"0x1bc16d674ec80000"},"blockNumber":11,"traceAddress":[],"transactionHash":"0xb","type":"call"},
+					{"action":{"from":"0xcc","to":"` + me + `","value":"0x6f05b59d3b20000"},"blockNumber":12,"traceAddress":[0],"transactionHash":"0xc","type":"call"}]`
+			}
+		case "eth_getLogs":
+			var f struct {
+				Topics []json.RawMessage `json:"topics"`
+			}
+			json.Unmarshal(req.Params[0], &f)
+			result = "[]"
+			if len(f.Topics) == 4 {
+				// a batch of 5 ID 1 and 10 ID 2, then a single ID 3
+				result = `[{"address":"0xgame","topics":["` + transferBatchTopic + `","` + padExt + `","` + padExt + `","` + padMe + `"],"data":"0x` +
+					word(64) + word(160) + word(2) + word(1) + word(2) + word(2) + word(5) + word(10) + `","blockNumber":"0x10","transactionHash":"0x10","logIndex":"0x0"},
+					{"address":"0xgame","topics":["` + transferSingleTopic + `","` + padExt + `","` + padExt + `","` + padMe + `"],"data":"0x` +
+					word(3) + word(1) + `","blockNumber":"0x11","transactionHash":"0x11","logIndex":"0x0"}]`
+			} else if len(f.Topics) == 3 && string(f.Topics[0]) == `"`+transferTopic+`"` {
+				result = `[{"address":"0xUSDC","topics":["` + transferTopic + `","` + padExt + `","` + padMe + `"],"data":"0x0000000000000000000000000000000000000000000000000000000005f5e100","blockNumber":"0xd","transactionHash":"0xd","logIndex":"0x0"},
+					{"address":"0xpunk","topics":["` + transferTopic + `","` + padExt + `","` + padMe + `","0x` + word(7) + `"],"data":"0x","blockNumber":"0xe","transactionHash":"0xe","logIndex":"0x1"},
+					{"address":"0xUSDC","topics":["` + transferTopic + `","0xbb","` + padMe + `"],"data":"0x` + word(1) + `","blockNumber":"0xe","transactionHash":"0xe","logIndex":"0x2"}]`
+			}
+		case "eth_getTransactionReceipt":
+			result = `{"gasUsed":"0x5208","effectiveGasPrice":"0x3b9aca00","status":"0x1"}`
+		case "eth_getBlockByNumber":
+			result = `{"timestamp":"0x60000000"}`
+		case "eth_call":
+			var c rpcCallMsg
+			json.Unmarshal(req.Params[0], &c)
+			switch c.To + c.Data {
+			case "0xusdc0x95d89b41":
+				result = `"0x0000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000455534443` + "00000000000000000000000000000000000000000000000000000000" + `"`
+			case "0xusdc0x313ce567":
+				result = `"0x0000000000000000000000000000000000000000000000000000000000000006"`
+			case "0xpunk0x95d89b41":
+				result = `"0x50554e4b00000000000000000000000000000000000000000000000000000000"`
+			default:
+				w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"execution reverted"}}`))
+				return
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":` + result + `}`))
+	}))
+}
+
+func word(i int64) string {
+	return fmt.Sprintf("%064x", i)
+}
+
+func checkRPCTXs(t *testing.T, ethsc *Etherscan) {
+	t.Helper()
+	count := map[string]int{"Deposits": 3, "Withdrawals": 1, "NFTs": 3}
+	for categ, want := range count {
+		if len(ethsc.TXsByCategory[categ]) != want {
+			t.Errorf("%s = %v, want %d TXs", categ, ethsc.TXsByCategory[categ], want)
+		}
+	}
+	wit := ethsc.TXsByCategory["Withdrawals"][0]
+	if !wit.Items["From"][0].Amount.Equal(decimal.NewFromInt(1)) || !wit.Items["Fee"][0].Amount.Equal(decimal.RequireFromString("0.000021")) {
+		t.Errorf("Withdrawal = %v", wit.Items)
+	}
+	found := false
+	for _, dep := range ethsc.TXsByCategory["Deposits"] {
+		if dep.Items["To"][0].Code == "USDC" && dep.Items["To"][0].Amount.Equal(decimal.NewFromInt(100)) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Deposits = %v, want 100 USDC", ethsc.TXsByCategory["Deposits"])
+	}
+	quantities := make(map[string]string)
+	for _, tx := range ethsc.TXsByCategory["NFTs"] {
+		for _, nft := range tx.Nfts["To"] {
+			quantities[nft.ID+nft.Symbol] = nft.Quantity.String()
+		}
+	}
+	want := map[string]string{"7PUNK": "0", "1": "5", "2": "10", "3": "1"}
+	if !reflect.DeepEqual(quantities, want) {
+		t.Errorf("NFTs = %v, want %v", quantities, want)
+	}
+}
+
+func TestEtherscan_UseRPC(t *testing.T) {
+	srv := fakeNode(t)
+	dump := filepath.Join(t.TempDir(), "dump.json")
+	ethsc := New()
+	ethsc.AddListAddresses([]string{me})
+	ethsc.NewAPI("", false)
+	err := ethsc.UseRPC(srv.URL, dump, false)
+	if err != nil {
+		t.Fatalf("UseRPC() error = %v", err)
+	}
+	go ethsc.GetAPITXs(*category.New())
+	err = ethsc.WaitFinish()
+	if err != nil {
+		t.Fatalf("GetAPITXs() error = %v", err)
+	}
+	checkRPCTXs(t, ethsc)
+	srv.Close()
+	// replay offline from the recorded dump
+	offline := New()
+	offline.AddListAddresses([]string{me})
+	offline.NewAPI("", false)
+	err = offline.UseRPC("", dump, false)
+	if err != nil {
+		t.Fatalf("UseRPC() error = %v", err)
+	}
+	go offline.GetAPITXs(*category.New())
+	err = offline.WaitFinish()
+	if err != nil {
+		t.Fatalf("GetAPITXs() offline error = %v", err)
+	}
+	checkRPCTXs(t, offline)
+}
+
+func TestRPC_CallStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+	node, err := newRPC(srv.URL, "", false)
+	if err != nil {
+		t.Fatalf("newRPC() error = %v", err)
+	}
+	node.client.SetRetryCount(0)
+	var block string
+	err = node.call("eth_blockNumber", &block)
+	if err == nil || !strings.Contains(err.Error(), "429") {
+		t.Errorf("rpc.call() error = %v, want the HTTP status", err)
+	}
+}
+
+func TestDecodeABIString(t *testing.T) {
+	tests := []struct {
+		name string
+		hex  string
+		want string
+	}{
+		{name: "string", hex: "0x0000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000455534443" + "00000000000000000000000000000000000000000000000000000000", want: "USDC"},
+		{name: "bytes32", hex: "0x4d4b520000000000000000000000000000000000000000000000000000000000", want: "MKR"},
+		{name: "empty", hex: "0x", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := decodeABIString(tt.hex); got != tt.want {
+				t.Errorf("decodeABIString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecode1155(t *testing.T) {
+	ids, values := decode1155(transferBatchTopic, "0x"+word(64)+word(128)+word(1)+word(42)+word(1)+word(7))
+	if len(ids) != 1 || ids[0].Int64() != 42 || values[0].Int64() != 7 {
+		t.Errorf("decode1155() = %v %v, want [42] [7]", ids, values)
+	}
+	ids, _ = decode1155(transferBatchTopic, "0x"+word(64)+word(128)+word(1000)+word(42))
+	if ids != nil {
+		t.Errorf("decode1155() = %v, want nil for truncated data", ids)
+	}
+}
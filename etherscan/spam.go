@@ -0,0 +1,140 @@
+package etherscan
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
+)
+
+type FilteredToken struct {
+	Contract string
+	Symbol   string
+	Name     string
+	Reason   string
+	TXs      int
+}
+
+type tokenFilter struct {
+	allow      map[string]bool
+	deny       map[string]bool
+	heuristics bool
+	hasPrice   func(platform, contract string) (bool, error)
+	filtered   map[string]*FilteredToken
+}
+
+// SetTokenFilter drops the transfers of denied token contracts, and with heuristics
+// the zero-value transfers and the tokens only ever received that CoinGecko does not know.
+// Allowed contracts are always kept, as well as the tokens whose lookup failed.
+func (ethsc *Etherscan) SetTokenFilter(allow, deny []string, heuristics bool) {
+	f := &tokenFilter{heuristics: heuristics, hasPrice: wallet.CoinGeckoHasContract}
+	f.allow = make(map[string]bool)
+	for _, a := range allow {
+		f.allow[strings.ToLower(a)] = true
+	}
+	f.deny = make(map[string]bool)
+	for _, d := range deny {
+		f.deny[strings.ToLower(d)] = true
+	}
+	f.filtered = make(map[string]*FilteredToken)
+	ethsc.api.filter = f
+}
+
+func (f *tokenFilter) add(contract, symbol, name, reason string) {
+	key := contract + reason
+	if _, ok := f.filtered[key]; !ok {
+		f.filtered[key] = &FilteredToken{Contract: contract, Symbol: symbol, Name: name, Reason: reason}
+	}
+	f.filtered[key].TXs += 1
+}
+
+func (api *api) filterSpam(addresses []string) {
+	f := api.filter
+	if f == nil {
+		return
+	}
+	for i, tx := range api.nftTXs {
+		if !f.allow[tx.ContractAddress] && f.deny[tx.ContractAddress] {
+			f.add(tx.ContractAddress, tx.TokenSymbol, tx.TokenName, "deny")
+			api.nftTXs[i].used = true
+		}
+	}
+	for i, tx := range api.erc1155TXs {
+		if !f.allow[tx.ContractAddress] && f.deny[tx.ContractAddress] {
+			f.add(tx.ContractAddress, tx.TokenSymbol, tx.TokenName, "deny")
+			api.erc1155TXs[i].used = true
+		}
+	}
+	sent := make(map[string]bool)
+	firstSeen := make(map[string]tokenTX)
+	for _, tx := range api.tokenTXs {
+		if api.ownAddress(tx.From, addresses) && !tx.Value.IsZero() {
+			sent[tx.ContractAddress] = true
+		}
+		if _, ok := firstSeen[tx.ContractAddress]; !ok {
+			firstSeen[tx.ContractAddress] = tx
+		}
+	}
+	priced := make(map[string]bool)
+	if f.heuristics {
+		platform := explorers[api.chain].coingecko
+		for contract, tx := range firstSeen {
+			if f.allow[contract] || f.deny[contract] || sent[contract] || platform == "" {
+				priced[contract] = true
+				continue
+			}
+			known, err := f.hasPrice(platform, contract)
+			if err != nil {
+				log.Println(api.name+" :", "Cannot check the price of", tx.TokenSymbol, contract, "kept :", err)
+				known = true
+			}
+			priced[contract] = known
+		}
+	}
+	for i, tx := range api.tokenTXs {
+		if f.allow[tx.ContractAddress] {
+			continue
+		}
+		reason := ""
+		if f.deny[tx.ContractAddress] {
+			reason = "deny"
+		} else if f.heuristics && tx.Value.IsZero() {
+			reason = "zero-value"
+		} else if f.heuristics && !priced[tx.ContractAddress] {
+			reason = "no-price"
+		}
+		if reason != "" {
+			f.add(tx.ContractAddress, tx.TokenSymbol, tx.TokenName, reason)
+			api.tokenTXs[i].used = true
+		}
+	}
+}
+
+func (ethsc *Etherscan) FilteredTokens() (list []FilteredToken) {
+	if ethsc.api.filter == nil {
+		return
+	}
+	for _, ft := range ethsc.api.filter.filtered {
+		list = append(list, *ft)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Contract == list[j].Contract {
+			return list[i].Reason < list[j].Reason
+		}
+		return list[i].Contract < list[j].Contract
+	})
+	return
+}
+
+func (ethsc *Etherscan) PrintFilteredTokens(chain string) {
+	list := ethsc.FilteredTokens()
+	if len(list) == 0 {
+		return
+	}
+	fmt.Println("Tokens", chain, "filtrés comme spam (à ajouter dans allow si c'est une erreur) :")
+	for _, ft := range list {
+		fmt.Println("  ", ft.Contract, ft.Symbol, "("+ft.Name+")", ft.Reason, ft.TXs, "TXs")
+	}
+}
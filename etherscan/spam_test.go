@@ -0,0 +1,59 @@
+package etherscan
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestEtherscan_FilterSpam(t *testing.T) {
+	ethsc := New()
+	ethsc.NewAPI("", false)
+	ethsc.SetTokenFilter([]string{"0xALLOW"}, []string{"0xdeny"}, true)
+	ethsc.api.filter.hasPrice = func(platform, contract string) (bool, error) {
+		if contract == "0xflaky" {
+			return false, errors.New("CoinGecko API replied 500")
+		}
+		return platform == "ethereum" && contract == "0xuni", nil
+	}
+	ts := time.Date(2021, time.May, 1, 0, 0, 0, 0, time.UTC)
+	ethsc.api.tokenTXs = []tokenTX{
+		{Hash: "0x1", ContractAddress: "0xuni", From: "0xext", To: me, Value: decimal.NewFromInt(400), TokenSymbol: "UNI", TimeStamp: ts},
+		{Hash: "0x2", ContractAddress: "0xdeny", From: "0xext", To: me, Value: decimal.NewFromInt(1), TokenSymbol: "UNI", TimeStamp: ts},
+		{Hash: "0x3", ContractAddress: "0xuni", From: me, To: "0xpoison", Value: decimal.Zero, TokenSymbol: "UNI", TimeStamp: ts},
+		{Hash: "0x4", ContractAddress: "0xjunk", From: "0xext", To: me, Value: decimal.NewFromInt(1000), TokenSymbol: "VISIT-WWW", TimeStamp: ts},
+		{Hash: "0x5", ContractAddress: "0xnew", From: "0xext", To: me, Value: decimal.NewFromInt(5), TokenSymbol: "NEW", TimeStamp: ts},
+		{Hash: "0x6", ContractAddress: "0xnew", From: me, To: "0xext", Value: decimal.NewFromInt(2), TokenSymbol: "NEW", TimeStamp: ts},
+		{Hash: "0x7", ContractAddress: "0xallow", From: "0xext", To: me, Value: decimal.NewFromInt(3), TokenSymbol: "MINE", TimeStamp: ts},
+		{Hash: "0x9", ContractAddress: "0xflaky", From: "0xext", To: me, Value: decimal.NewFromInt(3), TokenSymbol: "FLK", TimeStamp: ts},
+	}
+	ethsc.api.nftTXs = []nftTX{
+		{Hash: "0x8", ContractAddress: "0xdeny", From: "0xext", To: me, TokenID: "1"},
+	}
+	ethsc.api.filterSpam([]string{me})
+	var kept []string
+	for _, tx := range ethsc.api.tokenTXs {
+		if !tx.used {
+			kept = append(kept, tx.Hash)
+		}
+	}
+	if want := []string{"0x1", "0x5", "0x6", "0x7", "0x9"}; !reflect.DeepEqual(kept, want) {
+		t.Errorf("filterSpam() kept %v, want %v", kept, want)
+	}
+	if !ethsc.api.nftTXs[0].used {
+		t.Errorf("filterSpam() should drop denied NFTs")
+	}
+	var reasons []string
+	for _, ft := range ethsc.FilteredTokens() {
+		reasons = append(reasons, ft.Contract+" "+ft.Reason)
+	}
+	if want := []string{"0xdeny deny", "0xjunk no-price", "0xuni zero-value"}; !reflect.DeepEqual(reasons, want) {
+		t.Errorf("FilteredTokens() = %v, want %v", reasons, want)
+	}
+	if ft := ethsc.FilteredTokens()[0]; ft.TXs != 2 {
+		t.Errorf("FilteredTokens() deny TXs = %d, want 2", ft.TXs)
+	}
+}
@@ -0,0 +1,115 @@
+package forks
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anaskhan96/base58check"
+	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
+	"github.com/shopspring/decimal"
+)
+
+type Fork struct {
+	Ticker      string
+	Name        string
+	Parent      string          // chain holding the coins at fork time
+	Height      int             // last common block
+	Time        time.Time       // time of the last common block
+	Ratio       decimal.Decimal // coins received for one coin of Parent
+	AddressRule string          // how a Parent address is written on the new chain
+	Category    string          // default tax treatment of the received coins
+}
+
+var Registry = []Fork{
+	{Ticker: "ETC", Name: "Ethereum Classic", Parent: "ETH", Height: 1920000, Time: time.Date(2016, time.July, 20, 13, 20, 40, 0, time.UTC), Ratio: decimal.NewFromInt(1), AddressRule: "same", Category: "Forks"},
+	{Ticker: "BCH", Name: "Bitcoin Cash", Parent: "BTC", Height: 478558, Time: time.Date(2017, time.August, 1, 13, 16, 0, 0, time.UTC), Ratio: decimal.NewFromInt(1), AddressRule: "same", Category: "Forks"},
+	{Ticker: "BTG", Name: "Bitcoin Gold", Parent: "BTC", Height: 491407, Time: time.Date(2017, time.October, 24, 1, 17, 0, 0, time.UTC), Ratio: decimal.NewFromInt(1), AddressRule: "btg", Category: "Forks"},
+	{Ticker: "BCD", Name: "Bitcoin Diamond", Parent: "BTC", Height: 495866, Time: time.Date(2017, time.November, 24, 10, 20, 0, 0, time.UTC), Ratio: decimal.NewFromInt(10), AddressRule: "same", Category: "Forks"},
+	{Ticker: "LBTC", Name: "Lightning Bitcoin", Parent: "BTC", Height: 499999, Time: time.Date(2017, time.December, 18, 18, 34, 0, 0, time.UTC), Ratio: decimal.NewFromInt(1), AddressRule: "same", Category: "Forks"},
+	{Ticker: "BSV", Name: "Bitcoin SV", Parent: "BCH", Height: 556766, Time: time.Date(2018, time.November, 15, 16, 40, 0, 0, time.UTC), Ratio: decimal.NewFromInt(1), AddressRule: "same", Category: "Forks"},
+	{Ticker: "ETHW", Name: "EthereumPoW", Parent: "ETH", Height: 15537393, Time: time.Date(2022, time.September, 15, 6, 42, 0, 0, time.UTC), Ratio: decimal.NewFromInt(1), AddressRule: "same", Category: "Forks"},
+}
+
+func Lookup(ticker string) (f Fork, ok bool) {
+	for _, f := range Registry {
+		if f.Ticker == strings.ToUpper(ticker) {
+			return f, true
+		}
+	}
+	return
+}
+
+// Enabled returns the Forks asked in config, in Registry order so that a Fork of a Fork comes after its Parent
+func Enabled(tickers []string) (list []Fork, err error) {
+	const SOURCE = "Forks :"
+	asked := make(map[string]bool)
+	for _, t := range tickers {
+		if _, ok := Lookup(t); !ok {
+			return nil, errors.New(SOURCE + " Unknown Fork " + t)
+		}
+		asked[strings.ToUpper(t)] = true
+	}
+	for t := range asked {
+		f, _ := Lookup(t)
+		if _, ok := Lookup(f.Parent); ok && !asked[f.Parent] {
+			return nil, errors.New(SOURCE + " Fork " + f.Ticker + " needs Fork " + f.Parent + " of which it is a Fork")
+		}
+	}
+	for _, f := range Registry {
+		for _, t := range tickers {
+			if f.Ticker == strings.ToUpper(t) {
+				list = append(list, f)
+				break
+			}
+		}
+	}
+	return
+}
+
+// IsForkOf tells if the Parent of f is a Fork of chain, its coins are then those
+// of the Parent Fork credited on chain, less the Parent coins moved before f
+func (f Fork) IsForkOf(chain string) bool {
+	p, ok := Lookup(f.Parent)
+	return ok && (p.Parent == chain || p.IsForkOf(chain))
+}
+
+func (f Fork) ConvertAddress(add string) (string, error) {
+	switch f.AddressRule {
+	case "btg":
+		decoded, err := base58check.Decode(add)
+		if err != nil {
+			return "", err
+		}
+		version := "26"
+		if add[0] == '3' {
+			version = "17"
+		}
+		return base58check.Encode(version, decoded[2:])
+	default:
+		return add, nil
+	}
+}
+
+// TX credits the coins received on the new chain, bal is the balance on the Parent chain
+func (f Fork) TX(add string, bal decimal.Decimal, src string) wallet.TX {
+	note := src + " " + strconv.Itoa(f.Height) + " " + f.Name + " Fork on " + add
+	if conv, err := f.ConvertAddress(add); err == nil && conv != add {
+		note = src + " " + strconv.Itoa(f.Height) + " " + f.Name + " Fork from " + add + " to " + conv
+	}
+	t := wallet.TX{Timestamp: f.Time, ID: "fork-" + f.Ticker + "-" + add, Note: note}
+	t.Items = make(map[string]wallet.Currencies)
+	t.Items["To"] = append(t.Items["To"], wallet.Currency{Code: f.Ticker, Amount: bal.Mul(f.Ratio)})
+	return t
+}
+
+// DetectFromTXs credits a Fork from the balance computed from the TXs of a Source
+// when per address balances are not available
+func (f Fork) DetectFromTXs(txs wallet.TXsByCategory, src string) {
+	w := txs.GetWallets(f.Time, false, false)
+	w.Println(f.Parent+" (at time of "+f.Ticker+" Fork)", f.Parent)
+	if bal, ok := w.Currencies[f.Parent]; ok && bal.IsPositive() {
+		txs[f.Category] = append(txs[f.Category], f.TX(f.Parent, bal, src))
+	}
+}
@@ -0,0 +1,85 @@
+package forks
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
+	"github.com/shopspring/decimal"
+)
+
+func TestEnabled(t *testing.T) {
+	list, err := Enabled([]string{"btg", "BCH"})
+	if err != nil {
+		t.Fatalf("Enabled() error = %v", err)
+	}
+	if len(list) != 2 || list[0].Ticker != "BCH" || list[1].Ticker != "BTG" {
+		t.Errorf("Enabled() = %v, want BCH then BTG", list)
+	}
+	if _, err := Enabled([]string{"FOO"}); err == nil {
+		t.Errorf("Enabled(FOO) should fail")
+	}
+}
+
+func TestFork_ForkOfFork(t *testing.T) {
+	bsv, _ := Lookup("BSV")
+	bch, _ := Lookup("BCH")
+	if !bsv.IsForkOf("BTC") || bch.IsForkOf("BTC") || bsv.IsForkOf("ETH") {
+		t.Errorf("IsForkOf() BSV of BTC should be the only Fork of a Fork")
+	}
+	if _, err := Enabled([]string{"BSV"}); err == nil {
+		t.Errorf("Enabled(BSV) should fail without BCH")
+	}
+	list, err := Enabled([]string{"BSV", "BCH"})
+	if err != nil || len(list) != 2 || list[0].Ticker != "BCH" {
+		t.Errorf("Enabled(BSV, BCH) = %v, %v, want BCH then BSV", list, err)
+	}
+	txs := make(wallet.TXsByCategory)
+	txs["Forks"] = append(txs["Forks"], bch.TX("1BoatSLRHtKNngkdXEeobR76b53LETtpyT", decimal.NewFromInt(1), "Blockstream API :"))
+	sold := wallet.TX{Timestamp: time.Date(2018, time.June, 1, 0, 0, 0, 0, time.UTC), Items: make(map[string]wallet.Currencies)}
+	sold.Items["From"] = wallet.Currencies{wallet.Currency{Code: "BCH", Amount: decimal.New(4, -1)}}
+	txs["Withdrawals"] = append(txs["Withdrawals"], sold)
+	bsv.DetectFromTXs(txs, "Blockstream API :")
+	if len(txs["Forks"]) != 2 || !txs["Forks"][1].Items["To"][0].Amount.Equal(decimal.New(6, -1)) {
+		t.Errorf("BSV DetectFromTXs() Forks = %v, want 0.6 BSV from the BCH left at BSV Fork time", txs["Forks"])
+	}
+	bcd, _ := Lookup("BCD")
+	tx := bcd.TX("1BoatSLRHtKNngkdXEeobR76b53LETtpyT", decimal.New(15, -1), "Blockstream API :")
+	if !tx.Items["To"][0].Amount.Equal(decimal.NewFromInt(15)) || tx.Items["To"][0].Code != "BCD" {
+		t.Errorf("BCD TX() = %v, want 15 BCD", tx.Items["To"])
+	}
+}
+
+func TestFork_ConvertAddress(t *testing.T) {
+	btg, _ := Lookup("BTG")
+	got, err := btg.ConvertAddress("1BoatSLRHtKNngkdXEeobR76b53LETtpyT")
+	if err != nil || !strings.HasPrefix(got, "G") {
+		t.Errorf("ConvertAddress(P2PKH) = %v, %v, want a G... address", got, err)
+	}
+	got, err = btg.ConvertAddress("3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy")
+	if err != nil || !strings.HasPrefix(got, "A") {
+		t.Errorf("ConvertAddress(P2SH) = %v, %v, want a A... address", got, err)
+	}
+	bch, _ := Lookup("BCH")
+	if got, _ := bch.ConvertAddress("1BoatSLRHtKNngkdXEeobR76b53LETtpyT"); got != "1BoatSLRHtKNngkdXEeobR76b53LETtpyT" {
+		t.Errorf("BCH ConvertAddress() = %v, want same address", got)
+	}
+}
+
+func TestFork_DetectFromTXs(t *testing.T) {
+	txs := make(wallet.TXsByCategory)
+	dep := wallet.TX{Timestamp: time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC), Items: make(map[string]wallet.Currencies)}
+	dep.Items["To"] = wallet.Currencies{wallet.Currency{Code: "ETH", Amount: decimal.NewFromInt(2)}}
+	txs["Deposits"] = append(txs["Deposits"], dep)
+	ethw, _ := Lookup("ETHW")
+	ethw.DetectFromTXs(txs, "Etherscan API :")
+	if len(txs["Forks"]) != 1 || !txs["Forks"][0].Items["To"][0].Amount.Equal(decimal.NewFromInt(2)) {
+		t.Errorf("DetectFromTXs() Forks = %v, want 2 ETHW", txs["Forks"])
+	}
+	etc, _ := Lookup("ETC")
+	etc.DetectFromTXs(txs, "Etherscan API :")
+	if len(txs["Forks"]) != 1 {
+		t.Errorf("DetectFromTXs() should ignore ETC, no ETH before 2016")
+	}
+}
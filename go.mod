@@ -5,6 +5,8 @@ go 1.17
 require (
 	github.com/360EntSecGroup-Skylar/excelize v1.4.1
 	github.com/anaskhan96/base58check v0.0.0-20181220122047-b05365d494c4
+	github.com/btcsuite/btcd v0.20.1-beta
+	github.com/btcsuite/btcutil v1.0.2
 	github.com/davecgh/go-spew v1.1.1
 	github.com/go-resty/resty/v2 v2.6.0
 	github.com/google/uuid v1.3.0
@@ -15,6 +17,7 @@ require (
 	github.com/spf13/pflag v1.0.5
 	github.com/stretchr/testify v1.6.1 // indirect
 	github.com/superoo7/go-gecko v1.0.0
+	golang.org/x/crypto v0.0.0-20210921155107-089bfa567519
 	golang.org/x/net v0.0.0-20210813160813-60bc85c4be6d // indirect
 	gopkg.in/check.v1 v1.0.0-20180628173108-788fd7840127 // indirect
 	gopkg.in/resty.v1 v1.12.0
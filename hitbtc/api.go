@@ -13,6 +13,7 @@ type api struct {
 	doneAccTrans   chan error
 	clientTrade    *resty.Client
 	doneTrade      chan error
+	clientBal      *resty.Client
 	basePath       string
 	apiKey         string
 	secretKey      string
@@ -41,6 +42,9 @@ func (hb *HitBTC) NewAPI(apiKey, secretKey string, debug bool) {
 	hb.api.clientTrade.SetRetryCount(3).SetRetryWaitTime(1 * time.Second)
 	hb.api.clientTrade.SetDebug(debug)
 	hb.api.doneTrade = make(chan error)
+	hb.api.clientBal = resty.New()
+	hb.api.clientBal.SetRetryCount(3)
+	hb.api.clientBal.SetDebug(debug)
 	hb.api.basePath = "https://api.hitbtc.com/api/2/"
 	hb.api.apiKey = apiKey
 	hb.api.secretKey = secretKey
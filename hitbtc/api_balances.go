@@ -0,0 +1,50 @@
+package hitbtc
+
+import (
+	"errors"
+	"log"
+	"strconv"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
+	"github.com/shopspring/decimal"
+)
+
+type GetBalanceResp []struct {
+	Currency  string `json:"currency"`
+	Available string `json:"available"`
+	Reserved  string `json:"reserved"`
+}
+
+func (hb *HitBTC) GetAPIBalances() (balances wallet.WalletCurrencies, err error) {
+	const SOURCE = "HitBTC API Balances :"
+	balances = make(wallet.WalletCurrencies)
+	// Funds are split between the main Account and the Trading one
+	for _, method := range []string{"account/balance", "trading/balance"} {
+		resp, err := hb.api.clientBal.R().
+			SetBasicAuth(hb.api.apiKey, hb.api.secretKey).
+			SetResult(&GetBalanceResp{}).
+			SetError(&ErrorResp{}).
+			Get(hb.api.basePath + method)
+		if err != nil {
+			return balances, errors.New(SOURCE + " Error Requesting " + method)
+		}
+		if resp.StatusCode() > 300 {
+			return balances, errors.New(SOURCE + " Error StatusCode" + strconv.Itoa(resp.StatusCode()) + " for " + method)
+		}
+		for _, bal := range *resp.Result().(*GetBalanceResp) {
+			available, err := decimal.NewFromString(bal.Available)
+			if err != nil {
+				log.Println(SOURCE, "Error Parsing Available", bal.Available)
+			}
+			reserved, err := decimal.NewFromString(bal.Reserved)
+			if err != nil {
+				log.Println(SOURCE, "Error Parsing Reserved", bal.Reserved)
+			}
+			if !available.Add(reserved).IsZero() {
+				code := apiCurrencyCure(bal.Currency)
+				balances[code] = balances[code].Add(available).Add(reserved)
+			}
+		}
+	}
+	return balances, nil
+}
@@ -3,10 +3,10 @@ package hitbtc
 import (
 	"encoding/csv"
 	"io"
-	"log"
 	"strings"
 	"time"
 
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
 	"github.com/fiscafacile/CryptoFiscaFacile/source"
 	"github.com/fiscafacile/CryptoFiscaFacile/utils"
 	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
@@ -29,6 +29,10 @@ type csvTradeTX struct {
 	Taker      string
 }
 
+var CSVTradesFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"Email", "Date (UTC)", "Instrument", "Trade ID", "Order ID", "Side", "Quantity", "Price", "Volume", "Fee", "Rebate", "Total", "Taker"}, Positional: true, Date: "Date (UTC)", DateLayouts: []string{"2006-01-02 15:04:05"}, Amounts: []string{"Quantity", "Price", "Volume", "Fee"}},
+}
+
 func (hb *HitBTC) ParseCSVTrades(reader io.Reader) (err error) {
 	firstTimeUsed := time.Now()
 	lastTimeUsed := time.Date(2019, time.November, 14, 0, 0, 0, 0, time.UTC)
@@ -37,43 +41,50 @@ func (hb *HitBTC) ParseCSVTrades(reader io.Reader) (err error) {
 	records, err := csvReader.ReadAll()
 	if err == nil {
 		alreadyAsked := []string{}
-		for _, r := range records {
-			if r[0] != "Email" {
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVTradesFormats...)
+				if err != nil {
+					return
+				}
+			}
+			if !l.IsHeader(r) {
 				tx := csvTradeTX{}
-				tx.Email = r[0]
-				tx.Date, err = time.Parse("2006-01-02 15:04:05", r[1])
+				tx.Email = l.Get(r, "Email")
+				tx.Date, err = time.Parse("2006-01-02 15:04:05", l.Get(r, "Date (UTC)"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Date", r[1])
+					diag.Add(SOURCE, n+1, "Date (UTC)", l.Get(r, "Date (UTC)"), "Error Parsing Date")
 				}
-				tx.Instrument = r[2]
-				tx.TradeID = r[3]
-				tx.OrderID = r[4]
-				tx.Side = r[5]
-				tx.Quantity, err = decimal.NewFromString(r[6])
+				tx.Instrument = l.Get(r, "Instrument")
+				tx.TradeID = l.Get(r, "Trade ID")
+				tx.OrderID = l.Get(r, "Order ID")
+				tx.Side = l.Get(r, "Side")
+				tx.Quantity, err = decimal.NewFromString(l.Get(r, "Quantity"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Quantity", r[6])
+					diag.Add(SOURCE, n+1, "Quantity", l.Get(r, "Quantity"), "Error Parsing Quantity")
 				}
-				if r[7] != "" {
-					tx.Price, err = decimal.NewFromString(r[7])
+				if l.Get(r, "Price") != "" {
+					tx.Price, err = decimal.NewFromString(l.Get(r, "Price"))
 					if err != nil {
-						log.Println(SOURCE, "Error Parsing Price", r[7])
+						diag.Add(SOURCE, n+1, "Price", l.Get(r, "Price"), "Error Parsing Price")
 					}
 				}
-				if r[8] != "" {
-					tx.Volume, err = decimal.NewFromString(r[8])
+				if l.Get(r, "Volume") != "" {
+					tx.Volume, err = decimal.NewFromString(l.Get(r, "Volume"))
 					if err != nil {
-						log.Println(SOURCE, "Error Parsing Volume", r[8])
+						diag.Add(SOURCE, n+1, "Volume", l.Get(r, "Volume"), "Error Parsing Volume")
 					}
 				}
-				if r[9] != "" {
-					tx.Fee, err = decimal.NewFromString(r[9])
+				if l.Get(r, "Fee") != "" {
+					tx.Fee, err = decimal.NewFromString(l.Get(r, "Fee"))
 					if err != nil {
-						log.Println(SOURCE, "Error Parsing Fee", r[9])
+						diag.Add(SOURCE, n+1, "Fee", l.Get(r, "Fee"), "Error Parsing Fee")
 					}
 				}
-				tx.Rebate = r[10]
-				tx.Total = r[11]
-				tx.Taker = r[12]
+				tx.Rebate = l.Get(r, "Rebate")
+				tx.Total = l.Get(r, "Total")
+				tx.Taker = l.Get(r, "Taker")
 				hb.csvTradeTXs = append(hb.csvTradeTXs, tx)
 				hb.emails = utils.AppendUniq(hb.emails, tx.Email)
 				// Fill TXsByCategory
@@ -111,15 +122,7 @@ func (hb *HitBTC) ParseCSVTrades(reader io.Reader) (err error) {
 	}
 	for _, e := range hb.emails {
 		if _, ok := hb.Sources["HitBTC_"+e]; !ok {
-			hb.Sources["HitBTC_"+e] = source.Source{
-				Crypto:        true,
-				AccountNumber: e,
-				OpeningDate:   firstTimeUsed,
-				ClosingDate:   lastTimeUsed,
-				LegalName:     "Hit Tech Solutions Development Ltd.",
-				Address:       "Suite 15, Oliaji Trade Centre, Francis Rachel Street,\nVictoria, Mahe,\nSeychelles",
-				URL:           "https://hitbtc.com",
-			}
+			hb.Sources["HitBTC_"+e] = source.New("HitBTC", e, firstTimeUsed, lastTimeUsed)
 		}
 	}
 	return
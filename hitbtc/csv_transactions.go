@@ -3,9 +3,9 @@ package hitbtc
 import (
 	"encoding/csv"
 	"io"
-	"log"
 	"time"
 
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
 	"github.com/fiscafacile/CryptoFiscaFacile/source"
 	"github.com/fiscafacile/CryptoFiscaFacile/utils"
 	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
@@ -23,32 +23,43 @@ type csvTransactionTX struct {
 	Currency           string
 }
 
+var CSVTransactionsFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"Email", "Date (UTC)", "Operation id", "Type", "Amount", "Transaction hash", "Main account balance", "Currency"}, Positional: true, Date: "Date (UTC)", DateLayouts: []string{"2006-01-02 15:04:05"}, Amounts: []string{"Amount", "Main account balance"}},
+}
+
 func (hb *HitBTC) ParseCSVTransactions(reader io.Reader) (err error) {
 	const SOURCE = "HitBTC CSV Transactions :"
 	csvReader := csv.NewReader(reader)
 	records, err := csvReader.ReadAll()
 	if err == nil {
 		alreadyAsked := []string{}
-		for _, r := range records {
-			if r[0] != "Email" {
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVTransactionsFormats...)
+				if err != nil {
+					return
+				}
+			}
+			if !l.IsHeader(r) {
 				tx := csvTransactionTX{}
-				tx.Email = r[0]
-				tx.Date, err = time.Parse("2006-01-02 15:04:05", r[1])
+				tx.Email = l.Get(r, "Email")
+				tx.Date, err = time.Parse("2006-01-02 15:04:05", l.Get(r, "Date (UTC)"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Date", r[1])
+					diag.Add(SOURCE, n+1, "Date (UTC)", l.Get(r, "Date (UTC)"), "Error Parsing Date")
 				}
-				tx.OperationID = r[2]
-				tx.Type = r[3]
-				tx.Amount, err = decimal.NewFromString(r[4])
+				tx.OperationID = l.Get(r, "Operation id")
+				tx.Type = l.Get(r, "Type")
+				tx.Amount, err = decimal.NewFromString(l.Get(r, "Amount"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Amount", r[4])
+					diag.Add(SOURCE, n+1, "Amount", l.Get(r, "Amount"), "Error Parsing Amount")
 				}
-				tx.Hash = r[5]
-				tx.MainAccountBalance, err = decimal.NewFromString(r[6])
+				tx.Hash = l.Get(r, "Transaction hash")
+				tx.MainAccountBalance, err = decimal.NewFromString(l.Get(r, "Main account balance"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing MainAccountBalance", r[6])
+					diag.Add(SOURCE, n+1, "Main account balance", l.Get(r, "Main account balance"), "Error Parsing MainAccountBalance")
 				}
-				tx.Currency = csvCurrencyCure(r[7])
+				tx.Currency = csvCurrencyCure(l.Get(r, "Currency"))
 				hb.csvTransactionTXs = append(hb.csvTransactionTXs, tx)
 				hb.emails = utils.AppendUniq(hb.emails, tx.Email)
 				// Fill TXsByCategory
@@ -73,15 +84,7 @@ func (hb *HitBTC) ParseCSVTransactions(reader io.Reader) (err error) {
 	}
 	for _, e := range hb.emails {
 		if _, ok := hb.Sources["HitBTC_"+e]; !ok {
-			hb.Sources["HitBTC_"+e] = source.Source{
-				Crypto:        true,
-				AccountNumber: e,
-				OpeningDate:   hb.api.firstTimeUsed,
-				ClosingDate:   hb.api.lastTimeUsed,
-				LegalName:     "Hit Tech Solutions Development Ltd.",
-				Address:       "Suite 15, Oliaji Trade Centre, Francis Rachel Street,\nVictoria, Mahe,\nSeychelles",
-				URL:           "https://hitbtc.com",
-			}
+			hb.Sources["HitBTC_"+e] = source.New("HitBTC", e, hb.api.firstTimeUsed, hb.api.lastTimeUsed)
 		}
 	}
 	return
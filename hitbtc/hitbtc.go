@@ -54,15 +54,7 @@ func (hb *HitBTC) WaitFinish(account string) error {
 			hb.Sources["HitBTC"] = src
 		}
 	} else {
-		hb.Sources["HitBTC"] = source.Source{
-			Crypto:        true,
-			AccountNumber: account,
-			OpeningDate:   hb.api.firstTimeUsed,
-			ClosingDate:   hb.api.lastTimeUsed,
-			LegalName:     "Hit Tech Solutions Development Ltd.",
-			Address:       "Suite 15, Oliaji Trade Centre, Francis Rachel Street,\nVictoria, Mahe,\nSeychelles",
-			URL:           "https://hitbtc.com",
-		}
+		hb.Sources["HitBTC"] = source.New("HitBTC", account, hb.api.firstTimeUsed, hb.api.lastTimeUsed)
 	}
 	return err
 }
@@ -0,0 +1,225 @@
+package koinly
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
+	"github.com/fiscafacile/CryptoFiscaFacile/source"
+	"github.com/fiscafacile/CryptoFiscaFacile/utils"
+	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
+	"github.com/shopspring/decimal"
+)
+
+type CsvTX struct {
+	ID               string
+	Date             time.Time
+	Type             string
+	Label            string
+	SendingWallet    string
+	SentAmount       decimal.Decimal
+	SentCurrency     string
+	ReceivingWallet  string
+	ReceivedAmount   decimal.Decimal
+	ReceivedCurrency string
+	FeeAmount        decimal.Decimal
+	FeeCurrency      string
+	TxHash           string
+	Description      string
+}
+
+var CSVFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"Date", "Type"}},
+	{Version: "utc", Columns: []string{"Date (UTC)", "Type"}},
+}
+
+var aliases = map[string][]string{
+	"Date":             {"Date (UTC)"},
+	"Label":            {"Tag"},
+	"Sending Wallet":   {"From Wallet"},
+	"Receiving Wallet": {"To Wallet"},
+	"TxHash":           {"Tx Hash"},
+}
+
+func parseDate(s string) (t time.Time, err error) {
+	for _, layout := range []string{"2006-01-02 15:04:05 MST", "2006-01-02 15:04:05", "2006-01-02 15:04 MST", "2006-01-02 15:04"} {
+		t, err = time.Parse(layout, s)
+		if err == nil {
+			return
+		}
+	}
+	return
+}
+
+func location(wallet string) string {
+	if wallet == "" {
+		return ""
+	}
+	name, _ := source.Lookup(wallet)
+	return name
+}
+
+// ParseCSV reads the Koinly Transactions export, each Koinly wallet becomes a Location
+func (ko *Koinly) ParseCSV(reader io.Reader) (err error) {
+	const SOURCE = "Koinly CSV :"
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return errors.New(SOURCE + " " + err.Error())
+	}
+	var l utils.CSVLayout
+	found := false
+	alreadyAsked := []string{}
+	for n, r := range records {
+		if !found {
+			// Koinly may write a few lines of summary before the header
+			l, err = utils.DetectCSVLayout(SOURCE, r, CSVFormats...)
+			if err == nil {
+				found = true
+				for name, others := range aliases {
+					l.Alias(name, others...)
+				}
+			}
+			continue
+		}
+		get := func(col string) string {
+			return strings.TrimSpace(l.Get(r, col))
+		}
+		amount := func(col string) (d decimal.Decimal) {
+			if s := get(col); s != "" {
+				d, err = decimal.NewFromString(s)
+				if err != nil {
+					diag.Add(SOURCE, n+1, col, s, "Error Parsing "+col)
+				}
+			}
+			return
+		}
+		tx := CsvTX{}
+		tx.Date, err = parseDate(get("Date"))
+		if err != nil {
+			diag.Add(SOURCE, n+1, "Date", get("Date"), "Error Parsing Date")
+			continue
+		}
+		tx.Type = get("Type")
+		tx.Label = get("Label")
+		tx.SendingWallet = get("Sending Wallet")
+		tx.SentAmount = amount("Sent Amount")
+		tx.SentCurrency = get("Sent Currency")
+		tx.ReceivingWallet = get("Receiving Wallet")
+		tx.ReceivedAmount = amount("Received Amount")
+		tx.ReceivedCurrency = get("Received Currency")
+		tx.FeeAmount = amount("Fee Amount")
+		tx.FeeCurrency = get("Fee Currency")
+		tx.TxHash = get("TxHash")
+		tx.Description = get("Description")
+		tx.ID = tx.TxHash
+		if tx.ID == "" {
+			tx.ID = "Koinly-" + tx.Date.UTC().Format("20060102150405") + "-" + strconv.Itoa(n+1)
+		}
+		ko.CsvTXs = append(ko.CsvTXs, tx)
+		err = ko.add(SOURCE, tx, &alreadyAsked)
+		if err != nil {
+			diag.Add(SOURCE, n+1, "", tx.ID, err.Error())
+		}
+	}
+	if !found {
+		return errors.New(SOURCE + " Header not found")
+	}
+	return nil
+}
+
+func (ko *Koinly) add(src string, tx CsvTX, alreadyAsked *[]string) error {
+	kind := strings.ToLower(strings.NewReplacer(" ", "", "_", "").Replace(tx.Type))
+	label := strings.ToLower(tx.Label)
+	from := location(tx.SendingWallet)
+	to := location(tx.ReceivingWallet)
+	noteWallet := from
+	if noteWallet == "" {
+		noteWallet = to
+	}
+	t := wallet.TX{Timestamp: tx.Date, ID: tx.ID, Note: "Koinly CSV " + noteWallet + " : " + tx.Type + " " + tx.Label + " " + tx.Description}
+	t.Items = make(map[string]wallet.Currencies)
+	sent := wallet.Currency{Code: tx.SentCurrency, Amount: tx.SentAmount, Location: from}
+	received := wallet.Currency{Code: tx.ReceivedCurrency, Amount: tx.ReceivedAmount, Location: to}
+	fee := wallet.Currency{Code: tx.FeeCurrency, Amount: tx.FeeAmount, Location: from}
+	if fee.Location == "" {
+		fee.Location = to
+	}
+	hasSent := sent.Code != "" && !sent.Amount.IsZero()
+	hasReceived := received.Code != "" && !received.Amount.IsZero()
+	category := ""
+	if kind == "transfer" && hasSent && hasReceived {
+		// Transfers must have a zero balance, so the difference goes to Fee
+		diff := sent.Amount.Sub(received.Amount)
+		if sent.Code == received.Code && diff.IsPositive() {
+			sent.Amount = received.Amount
+			fee = wallet.Currency{Code: sent.Code, Amount: diff, Location: from}
+		}
+		t.Items["From"] = append(t.Items["From"], sent)
+		t.Items["To"] = append(t.Items["To"], received)
+		category = "Transfers"
+	} else if hasSent && hasReceived {
+		t.Items["From"] = append(t.Items["From"], sent)
+		t.Items["To"] = append(t.Items["To"], received)
+		category = "Exchanges"
+	} else if hasReceived {
+		t.Items["To"] = append(t.Items["To"], received)
+		switch label {
+		case "airdrop":
+			category = "AirDrops"
+		case "fork":
+			category = "Forks"
+		case "mining":
+			category = "Minings"
+		case "loan interest", "lending interest", "staking", "interest":
+			category = "Interests"
+		case "reward", "income", "other income":
+			category = "Referrals"
+		case "cashback":
+			category = "CommercialRebates"
+		case "gift":
+			category = "Gifts"
+		default:
+			category = "Deposits"
+		}
+	} else if hasSent {
+		switch label {
+		case "gift", "donation":
+			t.Items["From"] = append(t.Items["From"], sent)
+			category = "Gifts"
+		case "lost", "stolen":
+			t.Items["Lost"] = append(t.Items["Lost"], sent)
+			category = "Withdrawals"
+		case "cost", "margin fee":
+			t.Items["Fee"] = append(t.Items["Fee"], sent)
+			category = "Fees"
+		default:
+			t.Items["From"] = append(t.Items["From"], sent)
+			category = "Withdrawals"
+		}
+	} else if fee.Code != "" && !fee.Amount.IsZero() {
+		category = "Fees"
+	} else {
+		*alreadyAsked = wallet.AskForHelp(src+" "+tx.Type+" "+tx.Label, tx, *alreadyAsked)
+		return nil
+	}
+	if fee.Code != "" && !fee.Amount.IsZero() {
+		t.Items["Fee"] = append(t.Items["Fee"], fee)
+	}
+	err := t.Validate(category)
+	if err != nil {
+		return err
+	}
+	ko.TXsByCategory[category] = append(ko.TXsByCategory[category], t)
+	for _, l := range []string{from, to} {
+		if l != "" {
+			ko.Sources.AddUsage(l, "", tx.Date)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package koinly
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
+	"github.com/shopspring/decimal"
+)
+
+func Test_CSVParseExemple(t *testing.T) {
+	const csv = `Transactions report 2020
+Date,Type,Label,Sending Wallet,Sent Amount,Sent Currency,Sent Cost Basis,Receiving Wallet,Received Amount,Received Currency,Received Cost Basis,Fee Amount,Fee Currency,Gain (EUR),Net Value (EUR),Fee Value (EUR),TxSrc,TxDest,TxHash,Description
+2020-03-12 10:15:00 UTC,exchange,,Binance,5000,EUR,,Binance,0.5,BTC,,,,,,,,,,
+2020-03-13 10:15:00 UTC,transfer,,Binance,0.5,BTC,,Ledger Nano,0.4995,BTC,,0.0005,BTC,,,,,,0xabc,
+2020-03-14 10:15:00 UTC,crypto_deposit,airdrop,,,,,Ledger Nano,100,UNI,,,,,,,,,,`
+	ko := New()
+	err := ko.ParseCSV(strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("Koinly.ParseCSV() error = %v", err)
+	}
+	if len(ko.TXsByCategory["Exchanges"]) != 1 || len(ko.TXsByCategory["AirDrops"]) != 1 {
+		t.Errorf("Koinly.ParseCSV() = %v, want 1 Exchanges and 1 AirDrops", ko.TXsByCategory)
+	}
+	tr := ko.TXsByCategory["Transfers"]
+	if len(tr) != 1 {
+		t.Fatalf("Koinly.ParseCSV() Transfers = %v, want 1 TX", tr)
+	}
+	if !tr[0].Items["From"][0].Amount.Equal(decimal.RequireFromString("0.4995")) ||
+		!tr[0].Items["Fee"][0].Amount.Equal(decimal.RequireFromString("0.0005")) ||
+		tr[0].Items["To"][0].Location != "Ledger Nano" || tr[0].Items["From"][0].Location != "Binance" {
+		t.Errorf("Koinly.ParseCSV() Transfers = %v, want 0.4995 BTC from Binance to Ledger Nano with 0.0005 BTC Fee", tr[0])
+	}
+	if _, ok := ko.Sources["Binance"]; !ok {
+		t.Errorf("Koinly.ParseCSV() Sources = %v, want Binance", ko.Sources)
+	}
+}
+
+func Test_CSVParseInvalid(t *testing.T) {
+	const csv = `Date,Type,Label,Sending Wallet,Sent Amount,Sent Currency,Receiving Wallet,Received Amount,Received Currency,Fee Amount,Fee Currency,TxHash,Description
+2020-03-12 10:15:00 UTC,exchange,,Binance,-5000,EUR,Binance,0.5,BTC,,,,`
+	diag.Reset()
+	defer diag.Reset()
+	ko := New()
+	err := ko.ParseCSV(strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("Koinly.ParseCSV() error = %v", err)
+	}
+	if len(ko.TXsByCategory["Exchanges"]) != 0 || len(diag.Problems()) != 1 {
+		t.Errorf("Koinly.ParseCSV() = %v, want negative amount reported", ko.TXsByCategory)
+	}
+}
+
+func Test_CSVParseUTC(t *testing.T) {
+	const csv = `Date (UTC),Type,Tag,From Wallet,Sent Amount,Sent Currency,To Wallet,Received Amount,Received Currency,Fee Amount,Fee Currency,Tx Hash,Description
+2020-03-12 10:15:00 UTC,crypto_deposit,mining,,,,Ledger Nano,1,ETH,,,0xdef,`
+	ko := New()
+	err := ko.ParseCSV(strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("Koinly.ParseCSV() error = %v", err)
+	}
+	m := ko.TXsByCategory["Minings"]
+	if len(m) != 1 || m[0].ID != "0xdef" || m[0].Items["To"][0].Location != "Ledger Nano" {
+		t.Errorf("Koinly.ParseCSV() Minings = %v, want 0xdef to Ledger Nano", m)
+	}
+}
@@ -0,0 +1,63 @@
+package koinly
+
+import (
+	"io"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
+)
+
+var labels = map[string]string{
+	"AirDrops":          "airdrop",
+	"CommercialRebates": "reward",
+	"Fees":              "cost",
+	"Forks":             "fork",
+	"Gifts":             "gift",
+	"Interests":         "loan interest",
+	"Minings":           "mining",
+	"Referrals":         "reward",
+}
+
+func row(tx wallet.TX, sent, received, fee *wallet.Currency, label string) []string {
+	sa, sc := wallet.ExportAmount(sent)
+	ra, rc := wallet.ExportAmount(received)
+	fa, fc := wallet.ExportAmount(fee)
+	return []string{tx.Timestamp.UTC().Format("2006-01-02 15:04:05 UTC"), sa, sc, ra, rc, fa, fc, "", "", label, tx.Note, tx.ID}
+}
+
+func rows(tx wallet.TX) (lines [][]string) {
+	label := labels[tx.Category]
+	fees := tx.Items["Fee"]
+	if tx.Category != "Transfers" {
+		n := len(tx.Items["From"])
+		if len(tx.Items["To"]) > n {
+			n = len(tx.Items["To"])
+		}
+		for i := 0; i < n; i++ {
+			var sent, received, fee *wallet.Currency
+			if i < len(tx.Items["From"]) {
+				sent = &tx.Items["From"][i]
+			}
+			if i < len(tx.Items["To"]) {
+				received = &tx.Items["To"][i]
+			}
+			if i == 0 && len(fees) > 0 {
+				fee = &fees[0]
+				fees = fees[1:]
+			}
+			lines = append(lines, row(tx, sent, received, fee, label))
+		}
+	}
+	// Transfers are matched by Koinly itself, only their fees are a cost
+	for i := range fees {
+		lines = append(lines, row(tx, &fees[i], nil, nil, "cost"))
+	}
+	for i := range tx.Items["Lost"] {
+		lines = append(lines, row(tx, &tx.Items["Lost"][i], nil, nil, "lost"))
+	}
+	return
+}
+
+// ExportCSV writes the Koinly universal CSV format
+func ExportCSV(writer io.Writer, txs wallet.TXsByCategory) error {
+	return txs.RowsToCSV(writer, []string{"Date", "Sent Amount", "Sent Currency", "Received Amount", "Received Currency", "Fee Amount", "Fee Currency", "Net Worth Amount", "Net Worth Currency", "Label", "Description", "TxHash"}, rows)
+}
@@ -0,0 +1,45 @@
+package koinly
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
+	"github.com/shopspring/decimal"
+)
+
+func Test_ExportCSV(t *testing.T) {
+	txs := make(wallet.TXsByCategory)
+	txs["Minings"] = wallet.TXs{
+		wallet.TX{
+			Timestamp: time.Date(2020, time.January, 2, 3, 4, 5, 0, time.UTC),
+			ID:        "m1",
+			Items:     map[string]wallet.Currencies{"To": {wallet.Currency{Code: "ETH", Amount: decimal.NewFromInt(1)}}},
+		},
+	}
+	txs["Transfers"] = wallet.TXs{
+		wallet.TX{
+			Timestamp: time.Date(2020, time.January, 3, 0, 0, 0, 0, time.UTC),
+			ID:        "t1",
+			Items: map[string]wallet.Currencies{
+				"From": {wallet.Currency{Code: "BTC", Amount: decimal.NewFromInt(1)}},
+				"To":   {wallet.Currency{Code: "BTC", Amount: decimal.NewFromInt(1)}},
+				"Fee":  {wallet.Currency{Code: "BTC", Amount: decimal.New(1, -4)}},
+			},
+		},
+	}
+	var b bytes.Buffer
+	if err := ExportCSV(&b, txs); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	want := []string{
+		"2020-01-02 03:04:05 UTC,,,1,ETH,,,,,mining,,m1",
+		"2020-01-03 00:00:00 UTC,0.0001,BTC,,,,,,,cost,,t1",
+	}
+	if len(lines) != 3 || lines[1] != want[0] || lines[2] != want[1] {
+		t.Errorf("ExportCSV() = %v, want %v", lines[1:], want)
+	}
+}
@@ -0,0 +1,19 @@
+package koinly
+
+import (
+	"github.com/fiscafacile/CryptoFiscaFacile/source"
+	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
+)
+
+type Koinly struct {
+	CsvTXs        []CsvTX
+	TXsByCategory wallet.TXsByCategory
+	Sources       source.Sources
+}
+
+func New() *Koinly {
+	ko := &Koinly{}
+	ko.TXsByCategory = make(map[string]wallet.TXs)
+	ko.Sources = make(source.Sources)
+	return ko
+}
@@ -17,6 +17,7 @@ type api struct {
 	doneAssets    chan error
 	clientLedgers *resty.Client
 	doneLedgers   chan error
+	clientBal     *resty.Client
 	basePath      string
 	apiKey        string
 	secretKey     string
@@ -43,6 +44,9 @@ func (kr *Kraken) NewAPI(apiKey, secretKey string, debug bool) {
 	kr.api.clientLedgers.SetRetryCount(3).SetRetryWaitTime(1 * time.Second)
 	kr.api.clientLedgers.SetDebug(debug)
 	kr.api.doneLedgers = make(chan error)
+	kr.api.clientBal = resty.New()
+	kr.api.clientBal.SetRetryCount(3)
+	kr.api.clientBal.SetDebug(debug)
 	kr.api.basePath = "https://api.kraken.com"
 	kr.api.apiKey = apiKey
 	kr.api.secretKey = secretKey
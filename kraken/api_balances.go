@@ -0,0 +1,53 @@
+package kraken
+
+import (
+	"errors"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
+	"github.com/shopspring/decimal"
+)
+
+type BalanceResp struct {
+	Error  []string          `json:"error"`
+	Result map[string]string `json:"result"`
+}
+
+func (kr *Kraken) GetAPIBalances() (balances wallet.WalletCurrencies, err error) {
+	const SOURCE = "Kraken API Balances :"
+	balances = make(wallet.WalletCurrencies)
+	resource := "/0/private/Balance"
+	headers := make(map[string]string)
+	headers["API-Key"] = kr.api.apiKey
+	body := url.Values{}
+	body.Set("nonce", strconv.FormatInt(time.Now().UTC().UnixNano()/1e6, 10))
+	kr.api.sign(headers, body, resource)
+	resp, err := kr.api.clientBal.R().
+		SetHeaders(headers).
+		SetFormDataFromValues(body).
+		SetResult(&BalanceResp{}).
+		Post(kr.api.basePath + resource)
+	if err != nil {
+		return balances, errors.New(SOURCE + " Error Requesting")
+	}
+	result := *resp.Result().(*BalanceResp)
+	if len(result.Error) > 0 {
+		return balances, errors.New(SOURCE + " Error Requesting " + strings.Join(result.Error, ""))
+	}
+	for asset, amount := range result.Result {
+		bal, err := decimal.NewFromString(amount)
+		if err != nil {
+			log.Println(SOURCE, "Error Parsing Amount", amount)
+			continue
+		}
+		if !bal.IsZero() {
+			code := ReplaceAssets(asset)
+			balances[code] = balances[code].Add(bal)
+		}
+	}
+	return balances, nil
+}
@@ -3,12 +3,13 @@ package kraken
 import (
 	"encoding/csv"
 	"io"
-	"log"
 	"strings"
 	"time"
 
 	"github.com/fiscafacile/CryptoFiscaFacile/category"
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
 	"github.com/fiscafacile/CryptoFiscaFacile/source"
+	"github.com/fiscafacile/CryptoFiscaFacile/utils"
 	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
 	"github.com/shopspring/decimal"
 )
@@ -26,6 +27,10 @@ type csvTX struct {
 	Balance decimal.Decimal
 }
 
+var CSVFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"txid", "refid", "time", "type", "subtype", "aclass", "asset", "amount", "fee", "balance"}, Positional: true, Date: "time", DateLayouts: []string{"2006-01-02 15:04:05"}, Amounts: []string{"amount", "fee", "balance"}},
+}
+
 func (kr *Kraken) ParseCSV(reader io.Reader, cat category.Category, account string) (err error) {
 	firstTimeUsed := time.Now()
 	lastTimeUsed := time.Date(2009, time.January, 1, 0, 0, 0, 0, time.UTC)
@@ -34,32 +39,38 @@ func (kr *Kraken) ParseCSV(reader io.Reader, cat category.Category, account stri
 	records, err := csvReader.ReadAll()
 	if err == nil {
 		alreadyAsked := []string{}
-		for _, r := range records {
-			// Ignore duplicate when no TxId
-			if r[0] != "" && r[0] != "txid" {
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVFormats...)
+				if err != nil {
+					return
+				}
+			}
+			if !l.IsHeader(r) && l.Get(r, "txid") != "" { // Ignore duplicate when no TxId
 				tx := csvTX{}
-				tx.Time, err = time.Parse("2006-01-02 15:04:05", r[2])
+				tx.Time, err = time.Parse("2006-01-02 15:04:05", l.Get(r, "time"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Time", r[2])
+					diag.Add(SOURCE, n+1, "time", l.Get(r, "time"), "Error Parsing Time")
 				}
-				tx.TxId = r[0]
-				tx.RefId = r[1]
-				tx.Type = r[3]
-				tx.SubType = r[4]
-				tx.Class = r[5]
-				tx.Asset = ReplaceAssets(r[6])
-				tx.Amount, err = decimal.NewFromString(r[7])
+				tx.TxId = l.Get(r, "txid")
+				tx.RefId = l.Get(r, "refid")
+				tx.Type = l.Get(r, "type")
+				tx.SubType = l.Get(r, "subtype")
+				tx.Class = l.Get(r, "aclass")
+				tx.Asset = ReplaceAssets(l.Get(r, "asset"))
+				tx.Amount, err = decimal.NewFromString(l.Get(r, "amount"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Amount", r[7])
+					diag.Add(SOURCE, n+1, "amount", l.Get(r, "amount"), "Error Parsing Amount")
 				}
-				tx.Fee, err = decimal.NewFromString(r[8])
+				tx.Fee, err = decimal.NewFromString(l.Get(r, "fee"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Fee", r[8])
+					diag.Add(SOURCE, n+1, "fee", l.Get(r, "fee"), "Error Parsing Fee")
 				}
 				if tx.TxId == "" {
-					tx.Balance, err = decimal.NewFromString(r[9])
+					tx.Balance, err = decimal.NewFromString(l.Get(r, "balance"))
 					if err != nil {
-						log.Println(SOURCE, "Error Parsing Balance", r[9])
+						diag.Add(SOURCE, n+1, "balance", l.Get(r, "balance"), "Error Parsing Balance")
 					}
 				} else {
 					tx.Balance = decimal.NewFromInt(0)
@@ -199,15 +210,7 @@ func (kr *Kraken) ParseCSV(reader io.Reader, cat category.Category, account stri
 				}
 			}
 		}
-		kr.Sources["Kraken"] = source.Source{
-			Crypto:        true,
-			AccountNumber: account,
-			OpeningDate:   kr.api.firstTimeUsed,
-			ClosingDate:   kr.api.lastTimeUsed,
-			LegalName:     "Payward Ltd.",
-			Address:       "6th Floor,\nOne London Wall,\nLondon, EC2Y 5EB,\nRoyaume-Uni",
-			URL:           "https://www.kraken.com",
-		}
+		kr.Sources["Kraken"] = source.New("Kraken", account, kr.api.firstTimeUsed, kr.api.lastTimeUsed)
 	}
 	return
 }
@@ -1,6 +1,7 @@
 package kraken
 
 import (
+	"github.com/fiscafacile/CryptoFiscaFacile/category"
 	"strings"
 	"testing"
 )
@@ -40,7 +41,7 @@ func Test_CSVParseExemple(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			kr := New()
-			err := kr.ParseCSV(strings.NewReader(tt.csv))
+			err := kr.ParseCSV(strings.NewReader(tt.csv), *category.New(), "")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Kraken.ParseCSV() error = %v, wantErr %v", err, tt.wantErr)
 			}
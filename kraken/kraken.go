@@ -52,15 +52,7 @@ func (kr *Kraken) WaitFinish(account string) error {
 			kr.Sources["Kraken"] = src
 		}
 	} else {
-		kr.Sources["Kraken"] = source.Source{
-			Crypto:        true,
-			AccountNumber: account,
-			OpeningDate:   kr.api.firstTimeUsed,
-			ClosingDate:   kr.api.lastTimeUsed,
-			LegalName:     "Payward Ltd.",
-			Address:       "6th Floor,\nOne London Wall,\nLondon, EC2Y 5EB,\nRoyaume-Uni",
-			URL:           "https://www.kraken.com",
-		}
+		kr.Sources["Kraken"] = source.New("Kraken", account, kr.api.firstTimeUsed, kr.api.lastTimeUsed)
 	}
 	return err
 }
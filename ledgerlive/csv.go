@@ -3,10 +3,10 @@ package ledgerlive
 import (
 	"encoding/csv"
 	"io"
-	"log"
 	"time"
 
 	"github.com/fiscafacile/CryptoFiscaFacile/category"
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
 	"github.com/fiscafacile/CryptoFiscaFacile/utils"
 	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
 	"github.com/shopspring/decimal"
@@ -24,35 +24,46 @@ type CsvTX struct {
 	AccountXpub string
 }
 
+var CSVFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"Operation Date", "Currency Ticker", "Operation Type", "Operation Amount", "Operation Fees", "Operation Hash", "Account Name", "Account xpub"}, Positional: true, Date: "Operation Date", DateLayouts: []string{"2006-01-02T15:04:05.000Z"}, Amounts: []string{"Operation Amount", "Operation Fees"}},
+}
+
 func (ll *LedgerLive) ParseCSV(reader io.Reader, cat category.Category) (err error) {
 	const SOURCE = "LedgerLive CSV"
 	csvReader := csv.NewReader(reader)
 	records, err := csvReader.ReadAll()
 	if err == nil {
 		alreadyAsked := []string{}
-		for _, r := range records {
-			if r[0] != "Operation Date" {
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVFormats...)
+				if err != nil {
+					return
+				}
+			}
+			if !l.IsHeader(r) {
 				tx := CsvTX{}
-				tx.Date, err = time.Parse("2006-01-02T15:04:05.000Z", r[0])
+				tx.Date, err = time.Parse("2006-01-02T15:04:05.000Z", l.Get(r, "Operation Date"))
 				if err != nil {
-					log.Println(SOURCE, ": Error Parsing Date", r[0])
+					diag.Add(SOURCE+" :", n+1, "Operation Date", l.Get(r, "Operation Date"), "Error Parsing Date")
 				}
 				tx.ID = utils.GetUniqueID(SOURCE + tx.Date.String())
-				tx.Currency = r[1]
-				tx.Type = r[2]
-				tx.Amount, err = decimal.NewFromString(r[3])
+				tx.Currency = l.Get(r, "Currency Ticker")
+				tx.Type = l.Get(r, "Operation Type")
+				tx.Amount, err = decimal.NewFromString(l.Get(r, "Operation Amount"))
 				if err != nil {
-					log.Println(SOURCE, ": Error Parsing Amount", r[3])
+					diag.Add(SOURCE+" :", n+1, "Operation Amount", l.Get(r, "Operation Amount"), "Error Parsing Amount")
 				}
-				if r[4] != "" {
-					tx.Fees, err = decimal.NewFromString(r[4])
+				if l.Get(r, "Operation Fees") != "" {
+					tx.Fees, err = decimal.NewFromString(l.Get(r, "Operation Fees"))
 					if err != nil {
-						log.Println(SOURCE, ": Error Parsing Fees", r[4])
+						diag.Add(SOURCE+" :", n+1, "Operation Fees", l.Get(r, "Operation Fees"), "Error Parsing Fees")
 					}
 				}
-				tx.Hash = r[5]
-				tx.AccountName = r[6]
-				tx.AccountXpub = r[7]
+				tx.Hash = l.Get(r, "Operation Hash")
+				tx.AccountName = l.Get(r, "Account Name")
+				tx.AccountXpub = l.Get(r, "Account xpub")
 				ll.CsvTXs = append(ll.CsvTXs, tx)
 			}
 		}
@@ -1,6 +1,7 @@
 package ledgerlive
 
 import (
+	"github.com/fiscafacile/CryptoFiscaFacile/category"
 	"strings"
 	"testing"
 )
@@ -25,7 +26,7 @@ func Test_CSVParseExemple(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ll := New()
-			err := ll.ParseCSV(strings.NewReader(tt.csv))
+			err := ll.ParseCSV(strings.NewReader(tt.csv), *category.New())
 			if (err != nil) != tt.wantErr {
 				t.Errorf("LedgerLive.ParseCSV() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -0,0 +1,45 @@
+package lint
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+)
+
+func (issues Issues) ToJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(issues)
+}
+
+func (issues Issues) ToXlsx(filename string) error {
+	f := excelize.NewFile()
+	sheet := "Lint"
+	f.NewSheet(sheet)
+	f.SetCellValue(sheet, "A1", "Règle")
+	f.SetCellValue(sheet, "B1", "Sévérité")
+	f.SetCellValue(sheet, "C1", "Date (UTC)")
+	f.SetCellValue(sheet, "D1", "Source")
+	f.SetCellValue(sheet, "E1", "Emplacement")
+	f.SetCellValue(sheet, "F1", "Catégorie")
+	f.SetCellValue(sheet, "G1", "ID")
+	f.SetCellValue(sheet, "H1", "Message")
+	for n, i := range issues {
+		row := strconv.Itoa(n + 2)
+		f.SetCellValue(sheet, "A"+row, i.Rule)
+		f.SetCellValue(sheet, "B"+row, i.Severity)
+		f.SetCellValue(sheet, "C"+row, i.Timestamp.UTC().Format("02/01/2006 15:04:05"))
+		f.SetCellValue(sheet, "D"+row, i.Source)
+		f.SetCellValue(sheet, "E"+row, i.Location)
+		f.SetCellValue(sheet, "F"+row, i.Category)
+		f.SetCellValue(sheet, "G"+row, i.TXID)
+		f.SetCellValue(sheet, "H"+row, i.Message)
+	}
+	f.SetColWidth(sheet, "A", "A", 24)
+	f.SetColWidth(sheet, "C", "C", 18)
+	f.SetColWidth(sheet, "H", "H", 80)
+	f.DeleteSheet("Sheet1")
+	return f.SaveAs(filename)
+}
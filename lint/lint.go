@@ -0,0 +1,194 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
+)
+
+type Severity int
+
+const (
+	Info Severity = iota
+	Warning
+	Error
+)
+
+func (s Severity) String() string {
+	if s == Error {
+		return "error"
+	} else if s == Warning {
+		return "warning"
+	}
+	return "info"
+}
+
+func ParseSeverity(s string) Severity {
+	switch strings.ToLower(s) {
+	case "error":
+		return Error
+	case "warning":
+		return Warning
+	}
+	return Info
+}
+
+type Context struct {
+	Location *time.Location
+}
+
+type Rule struct {
+	ID          string
+	Severity    Severity
+	Description string
+	Source      string // only TXs from this Source are checked, all if empty
+	Check       func(ctx Context, txs wallet.TXsByCategory) Issues
+}
+
+type Issue struct {
+	Rule      string     `json:"rule"`
+	Severity  string     `json:"severity"`
+	TXID      string     `json:"tx,omitempty"`
+	Source    string     `json:"source,omitempty"`
+	Location  string     `json:"location,omitempty"`
+	Category  string     `json:"category,omitempty"`
+	Timestamp time.Time  `json:"timestamp"`
+	Message   string     `json:"message"`
+	TX        *wallet.TX `json:"-"`
+}
+
+type Issues []Issue
+
+type Suppression struct {
+	Rule     string
+	TX       string
+	Source   string
+	Location string
+	Reason   string
+}
+
+// Matches returns true when the Suppression covers the Issue, an empty TX, Source and Location silence the whole Rule
+func (s Suppression) Matches(i Issue) bool {
+	return s.Rule == i.Rule &&
+		(s.TX == "" || s.TX == i.TXID) &&
+		(s.Source == "" || s.Source == i.Source) &&
+		(s.Location == "" || s.Location == i.Location)
+}
+
+var registry []Rule
+
+// Register makes a Rule available to every Linter, Source packages can call it from their init()
+func Register(r Rule) {
+	registry = append(registry, r)
+}
+
+type Linter struct {
+	Rules        []Rule
+	Suppressions []Suppression
+	MinSeverity  Severity
+	Context      Context
+}
+
+func New(loc *time.Location) *Linter {
+	l := &Linter{Context: Context{Location: loc}}
+	l.Rules = append(l.Rules, registry...)
+	return l
+}
+
+func (l *Linter) Add(r Rule) {
+	l.Rules = append(l.Rules, r)
+}
+
+func (l *Linter) Suppress(s Suppression) {
+	l.Suppressions = append(l.Suppressions, s)
+}
+
+func (l *Linter) suppressed(i Issue) bool {
+	for _, s := range l.Suppressions {
+		if s.Matches(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// Run checks all Rules against txs and returns the Issues that are neither suppressed nor below MinSeverity
+func (l *Linter) Run(txs wallet.TXsByCategory) (issues Issues) {
+	for _, r := range l.Rules {
+		if r.Severity < l.MinSeverity {
+			continue
+		}
+		checked := txs
+		if r.Source != "" {
+			checked = filterSource(txs, r.Source)
+		}
+		for _, i := range r.Check(l.Context, checked) {
+			i.Rule = r.ID
+			i.Severity = r.Severity.String()
+			if !l.suppressed(i) {
+				issues = append(issues, i)
+			}
+		}
+	}
+	return
+}
+
+func filterSource(txs wallet.TXsByCategory, src string) wallet.TXsByCategory {
+	filtered := make(wallet.TXsByCategory)
+	for k, v := range txs {
+		for _, tx := range v {
+			if tx.Source == src {
+				filtered[k] = append(filtered[k], tx)
+			}
+		}
+	}
+	return filtered
+}
+
+// newIssue fills an Issue with the TX details, Rule and Severity are set by Run
+func newIssue(cat string, tx wallet.TX, msg string) Issue {
+	t := tx
+	return Issue{
+		TXID:      tx.ID,
+		Source:    tx.Source,
+		Category:  cat,
+		Timestamp: tx.Timestamp,
+		Message:   msg,
+		TX:        &t,
+	}
+}
+
+func (issues Issues) SortByRule() {
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Rule != issues[j].Rule {
+			return issues[i].Rule < issues[j].Rule
+		}
+		return issues[i].Timestamp.Before(issues[j].Timestamp)
+	})
+}
+
+func (l *Linter) Println(issues Issues) {
+	byRule := make(map[string]Issues)
+	for _, i := range issues {
+		byRule[i.Rule] = append(byRule[i.Rule], i)
+	}
+	for _, r := range l.Rules {
+		if r.Severity < l.MinSeverity {
+			continue
+		}
+		fmt.Println("--------------------------------------------------------")
+		fmt.Println("| [" + r.Severity.String() + "] " + r.ID + " : " + r.Description)
+		for _, i := range byRule[r.ID] {
+			fmt.Println("--------------------------------------------------------")
+			fmt.Println(i.Message)
+			if i.TX != nil {
+				i.TX.Println("")
+			}
+		}
+	}
+	fmt.Println("--------------------------------------------------------")
+	fmt.Println(len(issues), "issues found")
+}
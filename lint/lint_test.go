@@ -0,0 +1,59 @@
+package lint
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
+	"github.com/shopspring/decimal"
+)
+
+func TestLinter_Run(t *testing.T) {
+	txs := make(wallet.TXsByCategory)
+	txs["Withdrawals"] = wallet.TXs{
+		wallet.TX{
+			Timestamp: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			ID:        "w1",
+			Items:     map[string]wallet.Currencies{"From": {wallet.Currency{Code: "BTC", Amount: decimal.NewFromInt(1)}}},
+		},
+		wallet.TX{
+			Timestamp: time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC),
+			ID:        "w2",
+			Items:     map[string]wallet.Currencies{"From": {wallet.Currency{Code: "BTC", Amount: decimal.NewFromInt(-1)}}},
+		},
+	}
+	l := New(time.UTC)
+	issues := l.Run(txs)
+	count := make(map[string]int)
+	for _, i := range issues {
+		count[i.Rule] += 1
+	}
+	if count["withdrawal-unjustified"] != 2 || count["amount-negative"] != 1 {
+		t.Fatalf("Run() = %v, want 2 withdrawal-unjustified and 1 amount-negative", count)
+	}
+	l.Suppress(Suppression{Rule: "withdrawal-unjustified", TX: "w1"})
+	l.Suppress(Suppression{Rule: "balance-negative"})
+	l.MinSeverity = Warning
+	issues = l.Run(txs)
+	for _, i := range issues {
+		if i.Rule == "withdrawal-unjustified" && i.TXID == "w1" {
+			t.Errorf("Run() should suppress w1 : %v", i)
+		}
+		if i.Rule == "balance-negative" {
+			t.Errorf("Run() should suppress balance-negative : %v", i)
+		}
+	}
+	if len(issues) != 2 {
+		t.Errorf("Run() = %v, want 2 issues", issues)
+	}
+}
+
+func TestSuppression_MatchesSource(t *testing.T) {
+	s := Suppression{Rule: "withdrawal-unjustified", TX: "w1", Source: "Kraken"}
+	if !s.Matches(Issue{Rule: "withdrawal-unjustified", TXID: "w1", Source: "Kraken"}) {
+		t.Error("Matches() = false, want true for the same Source")
+	}
+	if s.Matches(Issue{Rule: "withdrawal-unjustified", TXID: "w1", Source: "Binance"}) {
+		t.Error("Matches() = true, want false for the same ID in another Source")
+	}
+}
@@ -0,0 +1,155 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
+	"github.com/shopspring/decimal"
+)
+
+func init() {
+	Register(Rule{
+		ID:          "withdrawal-unjustified",
+		Severity:    Warning,
+		Description: "Unjustified Withdrawals (after 2019 Jan 1st)",
+		Check:       checkUnjustifiedWithdrawals,
+	})
+	Register(Rule{
+		ID:          "transfer-unbalanced",
+		Severity:    Error,
+		Description: "Non-Zero balance Transfers",
+		Check:       checkUnbalancedTransfers,
+	})
+	Register(Rule{
+		ID:          "amount-negative",
+		Severity:    Error,
+		Description: "Negative Amounts TXs",
+		Check:       checkNegativeAmounts,
+	})
+	Register(Rule{
+		ID:          "income-with-from",
+		Severity:    Warning,
+		Description: "Deposits/AirDrops/CommercialRebates/Interests/Minings/Referrals with some From",
+		Check:       checkIncomesWithFrom,
+	})
+	Register(Rule{
+		ID:          "withdrawal-with-to",
+		Severity:    Error,
+		Description: "Withdrawals with some To",
+		Check:       checkWithdrawalsWithTo,
+	})
+	Register(Rule{
+		ID:          "balance-negative",
+		Severity:    Error,
+		Description: "Negative Balances per Location",
+		Check:       checkNegativeBalances,
+	})
+}
+
+func checkUnjustifiedWithdrawals(ctx Context, txs wallet.TXsByCategory) (issues Issues) {
+	for _, tx := range txs["Withdrawals"] {
+		if tx.Timestamp.After(time.Date(2018, time.December, 31, 23, 59, 59, 999, ctx.Location)) &&
+			len(tx.Items["From"]) > 0 {
+			issues = append(issues, newIssue("Withdrawals", tx, "Withdrawal must be categorized (CashOut, Gifts,...)"))
+		}
+	}
+	return
+}
+
+func checkUnbalancedTransfers(ctx Context, txs wallet.TXsByCategory) (issues Issues) {
+	for _, tx := range txs["Transfers"] {
+		for k, v := range tx.GetBalances(false, false) {
+			if !v.IsZero() {
+				issues = append(issues, newIssue("Transfers", tx, "Transfer balance is "+v.String()+" "+k))
+				break
+			}
+		}
+	}
+	return
+}
+
+func checkNegativeAmounts(ctx Context, txs wallet.TXsByCategory) (issues Issues) {
+	for cat, v := range txs {
+		for _, tx := range v {
+		items:
+			for k, i := range tx.Items {
+				for _, c := range i {
+					if c.Amount.IsNegative() {
+						issues = append(issues, newIssue(cat, tx, k+" amount is negative : "+c.Amount.String()+" "+c.Code))
+						break items
+					}
+				}
+			}
+		}
+	}
+	return
+}
+
+func checkIncomesWithFrom(ctx Context, txs wallet.TXsByCategory) (issues Issues) {
+	for _, cat := range []string{"Deposits", "AirDrops", "CommercialRebates", "Interests", "Minings", "Referrals"} {
+		txsCat := txs[cat]
+		if cat == "CommercialRebates" {
+			txsCat = txsCat.ApplyFromReversal()
+		}
+		for _, tx := range txsCat {
+			if _, ok := tx.Items["From"]; ok {
+				issues = append(issues, newIssue(cat, tx, cat+" should not have some From"))
+			}
+		}
+	}
+	return
+}
+
+func checkWithdrawalsWithTo(ctx Context, txs wallet.TXsByCategory) (issues Issues) {
+	for _, tx := range txs["Withdrawals"] {
+		if _, ok := tx.Items["To"]; ok {
+			issues = append(issues, newIssue("Withdrawals", tx, "Withdrawals should not have some To"))
+		}
+	}
+	return
+}
+
+func checkNegativeBalances(ctx Context, txs wallet.TXsByCategory) (issues Issues) {
+	for _, ne := range txs.FindNegativeBalances(decimal.New(1, -8)) {
+		msg := ne.Location + " : " + ne.Code + " negative since " + ne.Start.UTC().String() + ", lowest balance " + ne.Magnitude.Neg().String()
+		if ne.MissingFrom.IsZero() {
+			msg += ", likely missing exports before " + ne.MissingTo.UTC().String()
+		} else {
+			msg += ", likely missing exports between " + ne.MissingFrom.UTC().String() + " and " + ne.MissingTo.UTC().String()
+		}
+		issues = append(issues, Issue{
+			TXID:      ne.StartID,
+			Location:  ne.Location,
+			Timestamp: ne.Start,
+			Message:   msg,
+		})
+	}
+	return
+}
+
+// CoverageRule reports the periods missing in periodic exports, it needs the Coverage collected while parsing files
+func CoverageRule(cov wallet.Coverage) Rule {
+	return Rule{
+		ID:          "export-gap",
+		Severity:    Warning,
+		Description: "Periods without Exports",
+		Check: func(ctx Context, txs wallet.TXsByCategory) (issues Issues) {
+			for _, g := range cov.FindGaps(txs) {
+				msg := g.Location + " : no data between " + g.Start.Format("2006-01-02") + " and " + g.End.Format("2006-01-02")
+				if g.Activity > 0 {
+					msg += fmt.Sprint(" although balances change (", g.Activity, " TXs from other inputs)")
+				} else {
+					msg += " while holding " + strings.Join(g.Held, ", ")
+				}
+				issues = append(issues, Issue{
+					Location:  g.Location,
+					Timestamp: g.Start,
+					Message:   msg,
+				})
+			}
+			return
+		},
+	}
+}
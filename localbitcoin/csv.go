@@ -3,10 +3,10 @@ package localbitcoin
 import (
 	"encoding/csv"
 	"io"
-	"log"
 	"strings"
 	"time"
 
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
 	"github.com/fiscafacile/CryptoFiscaFacile/source"
 	"github.com/fiscafacile/CryptoFiscaFacile/utils"
 	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
@@ -44,6 +44,17 @@ type CsvTXTransfer struct {
 	Notes    string
 }
 
+var CSVTransferFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"TXID", "Created", "Received", "Sent", "TXtype", "TXdesc", "TXNotes"}, Positional: true, Date: "Created", DateLayouts: []string{"2006-01-02T15:04:05+00:00"}, Amounts: []string{"Received", "Sent"}},
+}
+
+// the traded crypto is part of the column names
+func CSVTradeFormats(coin string) []utils.CSVFormat {
+	return []utils.CSVFormat{
+		{Version: "original", Columns: []string{"id", "created_at", "buyer", "seller", "trade_type", coin + "_amount", coin + "_traded", "fee_" + coin, coin + "_amount_less_fee", coin + "_final", "fiat_amount", "fiat_fee", "fiat_per_" + coin, "currency", "exchange_rate", "transaction_released_at", "online_provider", "reference"}},
+	}
+}
+
 func (lb *LocalBitcoin) ParseTradeCSV(reader io.Reader, account string) (err error) {
 	firstTimeUsed := time.Now()
 	lastTimeUsed := time.Date(2009, time.January, 1, 0, 0, 0, 0, time.UTC)
@@ -53,63 +64,72 @@ func (lb *LocalBitcoin) ParseTradeCSV(reader io.Reader, account string) (err err
 	if err == nil {
 		alreadyAsked := []string{}
 		var curr string
-		for _, r := range records {
-			if r[0] == "id" {
-				curr = strings.Split(r[5], "_")[0]
-				curr = strings.ToUpper(curr)
-			} else {
+		var l utils.CSVLayout
+		var coin string
+		for n, r := range records {
+			if n == 0 {
+				if len(r) > 5 {
+					coin = strings.Split(r[5], "_")[0]
+				}
+				curr = strings.ToUpper(coin)
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVTradeFormats(coin)...)
+				if err != nil {
+					return
+				}
+			}
+			if !l.IsHeader(r) {
 				tx := CsvTXTrade{}
-				tx.ID = r[0]
-				tx.CreatedAt, err = time.Parse("2006-01-02 15:04:05+00:00", r[1])
+				tx.ID = l.Get(r, "id")
+				tx.CreatedAt, err = time.Parse("2006-01-02 15:04:05+00:00", l.Get(r, "created_at"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing CreatedAt : ", r[1])
+					diag.Add(SOURCE, n+1, "created_at", l.Get(r, "created_at"), "Error Parsing CreatedAt")
 				}
-				tx.Buyer = r[2]
-				tx.Seller = r[3]
-				tx.TradeType = r[4]
-				tx.Amount, err = decimal.NewFromString(r[5])
+				tx.Buyer = l.Get(r, "buyer")
+				tx.Seller = l.Get(r, "seller")
+				tx.TradeType = l.Get(r, "trade_type")
+				tx.Amount, err = decimal.NewFromString(l.Get(r, coin+"_amount"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Amount : ", r[5])
+					diag.Add(SOURCE, n+1, coin+"_amount", l.Get(r, coin+"_amount"), "Error Parsing Amount")
 				}
-				tx.Traded, err = decimal.NewFromString(r[6])
+				tx.Traded, err = decimal.NewFromString(l.Get(r, coin+"_traded"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Traded : ", r[6])
+					diag.Add(SOURCE, n+1, coin+"_traded", l.Get(r, coin+"_traded"), "Error Parsing Traded")
 				}
-				tx.FeeBTC, err = decimal.NewFromString(r[7])
+				tx.FeeBTC, err = decimal.NewFromString(l.Get(r, "fee_"+coin))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing FeeBTC : ", r[7])
+					diag.Add(SOURCE, n+1, "fee_"+coin, l.Get(r, "fee_"+coin), "Error Parsing FeeBTC")
 				}
-				tx.AmountLessFee, err = decimal.NewFromString(r[8])
+				tx.AmountLessFee, err = decimal.NewFromString(l.Get(r, coin+"_amount_less_fee"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing AmountLessFee : ", r[8])
+					diag.Add(SOURCE, n+1, coin+"_amount_less_fee", l.Get(r, coin+"_amount_less_fee"), "Error Parsing AmountLessFee")
 				}
-				tx.Final, err = decimal.NewFromString(r[9])
+				tx.Final, err = decimal.NewFromString(l.Get(r, coin+"_final"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing BTC_Final : ", r[9])
+					diag.Add(SOURCE, n+1, coin+"_final", l.Get(r, coin+"_final"), "Error Parsing BTC_Final")
 				}
-				tx.FiatAmount, err = decimal.NewFromString(r[10])
+				tx.FiatAmount, err = decimal.NewFromString(l.Get(r, "fiat_amount"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing FiatAmount : ", r[10])
+					diag.Add(SOURCE, n+1, "fiat_amount", l.Get(r, "fiat_amount"), "Error Parsing FiatAmount")
 				}
-				tx.FiatFee, err = decimal.NewFromString(r[11])
+				tx.FiatFee, err = decimal.NewFromString(l.Get(r, "fiat_fee"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing FiatFee : ", r[11])
+					diag.Add(SOURCE, n+1, "fiat_fee", l.Get(r, "fiat_fee"), "Error Parsing FiatFee")
 				}
-				tx.FiatPerBTC, err = decimal.NewFromString(r[12])
+				tx.FiatPerBTC, err = decimal.NewFromString(l.Get(r, "fiat_per_"+coin))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing FiatPerBTC : ", r[12])
+					diag.Add(SOURCE, n+1, "fiat_per_"+coin, l.Get(r, "fiat_per_"+coin), "Error Parsing FiatPerBTC")
 				}
-				tx.FiatCurrency = r[13]
-				tx.ExchangeRate, err = decimal.NewFromString(r[14])
+				tx.FiatCurrency = l.Get(r, "currency")
+				tx.ExchangeRate, err = decimal.NewFromString(l.Get(r, "exchange_rate"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing ExchangeRate : ", r[14])
+					diag.Add(SOURCE, n+1, "exchange_rate", l.Get(r, "exchange_rate"), "Error Parsing ExchangeRate")
 				}
-				tx.TransactionReleasedAt, err = time.Parse("2006-01-02 15:04:05+00:00", r[15])
+				tx.TransactionReleasedAt, err = time.Parse("2006-01-02 15:04:05+00:00", l.Get(r, "transaction_released_at"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing TransactionReleasedAt : ", r[15])
+					diag.Add(SOURCE, n+1, "transaction_released_at", l.Get(r, "transaction_released_at"), "Error Parsing TransactionReleasedAt")
 				}
-				tx.OnlineProvider = r[16]
-				tx.Reference = r[17]
+				tx.OnlineProvider = l.Get(r, "online_provider")
+				tx.Reference = l.Get(r, "reference")
 				lb.CsvTXsTrade = append(lb.CsvTXsTrade, tx)
 				if tx.TransactionReleasedAt.Before(firstTimeUsed) {
 					firstTimeUsed = tx.TransactionReleasedAt
@@ -137,15 +157,7 @@ func (lb *LocalBitcoin) ParseTradeCSV(reader io.Reader, account string) (err err
 		}
 	}
 	if _, ok := lb.Sources["Local Bitcoin"]; !ok {
-		lb.Sources["Local Bitcoin"] = source.Source{
-			Crypto:        true,
-			AccountNumber: account,
-			OpeningDate:   firstTimeUsed,
-			ClosingDate:   lastTimeUsed,
-			LegalName:     "LocalBitcoins Oy",
-			Address:       "Porkkalankatu 24\n00180 Helsinki\nFinland",
-			URL:           "https://localbitcoins.com/fr",
-		}
+		lb.Sources["Local Bitcoin"] = source.New("Local Bitcoin", account, firstTimeUsed, lastTimeUsed)
 	}
 	return
 }
@@ -159,33 +171,40 @@ func (lb *LocalBitcoin) ParseTransferCSV(reader io.Reader, account string) (err
 	if err == nil {
 		alreadyAsked := []string{}
 		curr := "BTC"
-		for _, r := range records {
-			if r[0] != "TXID" {
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVTransferFormats...)
+				if err != nil {
+					return
+				}
+			}
+			if !l.IsHeader(r) {
 				tx := CsvTXTransfer{}
-				tx.Created, err = time.Parse("2006-01-02T15:04:05+00:00", r[1])
+				tx.Created, err = time.Parse("2006-01-02T15:04:05+00:00", l.Get(r, "Created"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Created : ", r[1])
+					diag.Add(SOURCE, n+1, "Created", l.Get(r, "Created"), "Error Parsing Created")
 				}
-				if r[0] != "" {
-					tx.ID = r[0]
+				if l.Get(r, "TXID") != "" {
+					tx.ID = l.Get(r, "TXID")
 				} else {
 					utils.GetUniqueID(SOURCE + tx.Created.String())
 				}
-				if r[2] != "" {
-					tx.Received, err = decimal.NewFromString(r[2])
+				if l.Get(r, "Received") != "" {
+					tx.Received, err = decimal.NewFromString(l.Get(r, "Received"))
 					if err != nil {
-						log.Println(SOURCE, "Error Parsing Received : ", r[2])
+						diag.Add(SOURCE, n+1, "Received", l.Get(r, "Received"), "Error Parsing Received")
 					}
 				}
-				if r[3] != "" {
-					tx.Sent, err = decimal.NewFromString(r[3])
+				if l.Get(r, "Sent") != "" {
+					tx.Sent, err = decimal.NewFromString(l.Get(r, "Sent"))
 					if err != nil {
-						log.Println(SOURCE, "Error Parsing Sent : ", r[3])
+						diag.Add(SOURCE, n+1, "Sent", l.Get(r, "Sent"), "Error Parsing Sent")
 					}
 				}
-				tx.Type = r[4]
-				tx.Desc = r[5]
-				tx.Notes = r[6]
+				tx.Type = l.Get(r, "TXtype")
+				tx.Desc = l.Get(r, "TXdesc")
+				tx.Notes = l.Get(r, "TXNotes")
 				lb.CsvTXsTransfer = append(lb.CsvTXsTransfer, tx)
 				if tx.Created.Before(firstTimeUsed) {
 					firstTimeUsed = tx.Created
@@ -223,15 +242,7 @@ func (lb *LocalBitcoin) ParseTransferCSV(reader io.Reader, account string) (err
 		}
 	}
 	if _, ok := lb.Sources["Local Bitcoin"]; !ok {
-		lb.Sources["Local Bitcoin"] = source.Source{
-			Crypto:        true,
-			AccountNumber: account,
-			OpeningDate:   firstTimeUsed,
-			ClosingDate:   lastTimeUsed,
-			LegalName:     "LocalBitcoins Oy",
-			Address:       "Porkkalankatu 24\n00180 Helsinki\nFinland",
-			URL:           "https://localbitcoins.com/fr",
-		}
+		lb.Sources["Local Bitcoin"] = source.New("Local Bitcoin", account, firstTimeUsed, lastTimeUsed)
 	}
 	return
 }
@@ -20,7 +20,7 @@ func Test_CSVParseTradeExemple(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			lb := New()
-			err := lb.ParseTradeCSV(strings.NewReader(tt.csv))
+			err := lb.ParseTradeCSV(strings.NewReader(tt.csv), "")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("LocalBitcoin.ParseTradeCSV() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -43,7 +43,7 @@ func Test_CSVLBParseTransferExemple(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			lb := New()
-			err := lb.ParseTransferCSV(strings.NewReader(tt.csv))
+			err := lb.ParseTransferCSV(strings.NewReader(tt.csv), "")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("LocalBitcoin.ParseTransferCSV() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -2,8 +2,12 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/fiscafacile/CryptoFiscaFacile/binance"
@@ -13,16 +17,24 @@ import (
 	"github.com/fiscafacile/CryptoFiscaFacile/blockchain"
 	"github.com/fiscafacile/CryptoFiscaFacile/blockstream"
 	"github.com/fiscafacile/CryptoFiscaFacile/btc"
+	"github.com/fiscafacile/CryptoFiscaFacile/cardano"
 	"github.com/fiscafacile/CryptoFiscaFacile/category"
 	"github.com/fiscafacile/CryptoFiscaFacile/cfg"
 	"github.com/fiscafacile/CryptoFiscaFacile/coinbase"
 	"github.com/fiscafacile/CryptoFiscaFacile/coinbasepro"
+	"github.com/fiscafacile/CryptoFiscaFacile/cointracking"
 	"github.com/fiscafacile/CryptoFiscaFacile/cryptocom"
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
+	"github.com/fiscafacile/CryptoFiscaFacile/discover"
 	"github.com/fiscafacile/CryptoFiscaFacile/etherscan"
+	"github.com/fiscafacile/CryptoFiscaFacile/forks"
 	"github.com/fiscafacile/CryptoFiscaFacile/hitbtc"
+	"github.com/fiscafacile/CryptoFiscaFacile/koinly"
 	"github.com/fiscafacile/CryptoFiscaFacile/kraken"
 	"github.com/fiscafacile/CryptoFiscaFacile/ledgerlive"
+	"github.com/fiscafacile/CryptoFiscaFacile/lint"
 	"github.com/fiscafacile/CryptoFiscaFacile/localbitcoin"
+	"github.com/fiscafacile/CryptoFiscaFacile/manual"
 	"github.com/fiscafacile/CryptoFiscaFacile/monero"
 	"github.com/fiscafacile/CryptoFiscaFacile/mycelium"
 	"github.com/fiscafacile/CryptoFiscaFacile/poloniex"
@@ -30,10 +42,27 @@ import (
 	"github.com/fiscafacile/CryptoFiscaFacile/source"
 	"github.com/fiscafacile/CryptoFiscaFacile/uphold"
 	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
+	"github.com/fiscafacile/CryptoFiscaFacile/waltio"
+	"github.com/shopspring/decimal"
 )
 
 var version string
 
+// tmpDir holds the files extracted from the ZIP and XLSX inputs, exit removes it
+var tmpDir string
+
+func exit(code int) {
+	if tmpDir != "" {
+		os.RemoveAll(tmpDir)
+	}
+	os.Exit(code)
+}
+
+func fatal(v ...interface{}) {
+	log.Print(v...)
+	exit(1)
+}
+
 func main() {
 	fmt.Println("CryptoFiscaFacile", version)
 	// Configuration
@@ -55,51 +84,208 @@ func main() {
 	if config.Tools.CoinLayer.Key != "" {
 		wallet.CoinLayerSetKey(config.Tools.CoinLayer.Key)
 	}
+	if config.Options.InputsDir != "" {
+		rep, err := discover.Scan(config.Options.InputsDir)
+		if err != nil {
+			log.Fatal(err)
+		}
+		rep.Println()
+		rep.AddTo(config)
+	}
+	tmpDir, err = discover.Expand(config)
+	if err != nil {
+		fatal(err)
+	}
 	categ := category.New()
 	if config.Options.TxsCategory != "" {
 		recordFile, err := os.Open(config.Options.TxsCategory)
 		if err != nil {
-			log.Fatal("Error opening Transactions CSV Category file:", err)
+			fatal("Error opening Transactions CSV Category file:", err)
+		}
+		diag.SetFile(config.Options.TxsCategory)
+		err = categ.ParseCSVCategory(recordFile)
+		if err != nil {
+			fatal("Error parsing Transactions CSV Category file:", err)
 		}
-		categ.ParseCSVCategory(recordFile)
 	}
 	loc, err := time.LoadLocation(config.Options.Location)
 	if err != nil {
-		log.Fatal("Error parsing Location:", err)
+		fatal("Error parsing Location:", err)
+	}
+	if config.Options.TxsOverrides != "" {
+		recordFile, err := os.Open(config.Options.TxsOverrides)
+		if err != nil {
+			fatal("Error opening Transactions YAML Overrides file:", err)
+		}
+		err = categ.ParseYAMLOverrides(recordFile)
+		recordFile.Close()
+		if err != nil {
+			fatal("Error parsing Transactions YAML Overrides file:", err)
+		}
+		err = wallet.CheckOverrides(*categ)
+		if err != nil {
+			fatal("Error parsing Transactions YAML Overrides file:", err)
+		}
+	}
+	if config.Options.TxsRules != "" {
+		recordFile, err := os.Open(config.Options.TxsRules)
+		if err != nil {
+			fatal("Error opening Transactions YAML Rules file:", err)
+		}
+		err = categ.ParseYAMLRules(recordFile, loc)
+		recordFile.Close()
+		if err != nil {
+			fatal("Error parsing Transactions YAML Rules file:", err)
+		}
+		err = wallet.CheckRules(*categ)
+		if err != nil {
+			fatal("Error parsing Transactions YAML Rules file:", err)
+		}
+	}
+	enabledForks, err := forks.Enabled(config.Options.Forks)
+	if err != nil {
+		fatal(err)
 	}
 	// Launch APIs access in go routines
+	var utxoCoins, evmChains []string
+	for coin, conf := range config.Blockchains.Others {
+		if etherscan.IsKnownChain(coin) || conf.Etherscan.URL != "" {
+			evmChains = append(evmChains, coin)
+		} else {
+			utxoCoins = append(utxoCoins, coin)
+		}
+	}
+	sort.Strings(utxoCoins)
+	sort.Strings(evmChains)
+	utxos := make(map[string]*btc.BTC)
+	esploras := make(map[string]*blockstream.Blockstream)
+	for _, coin := range utxoCoins {
+		conf := config.Blockchains.Others[coin]
+		utxo := btc.New()
+		utxo.AddListAddresses(conf.Addresses)
+		for _, file := range conf.CSV {
+			recordFile, err := os.Open(file)
+			if err != nil {
+				fatal("Error opening "+coin+" CSV Addresses file:", err)
+			}
+			diag.SetFile(file)
+			err = utxo.ParseCSVAddresses(recordFile)
+			if err != nil {
+				fatal("Error parsing "+coin+" CSV Addresses file:", err)
+			}
+		}
+		if len(utxo.Addresses) > 0 {
+			esplora, err := blockstream.NewEsplora(coin, conf.Esplora, conf.Decimals)
+			if err != nil {
+				fatal(err)
+			}
+			utxos[coin] = utxo
+			esploras[coin] = esplora
+			go esplora.GetAllTXs(utxo, *categ)
+		}
+	}
+	core := btc.New()
+	elec := btc.New()
 	btc := btc.New()
 	btc.AddListAddresses(config.Blockchains.BTC.Addresses)
+	err = btc.AddListXPubs(config.Blockchains.BTC.XPubs)
+	if err != nil {
+		fatal(err)
+	}
+	if config.Blockchains.BTC.GapLimit > 0 {
+		btc.GapLimit = config.Blockchains.BTC.GapLimit
+	}
 	for _, file := range config.Blockchains.BTC.CSV {
 		recordFile, err := os.Open(file)
 		if err != nil {
-			log.Fatal("Error opening Bitcoin CSV Addresses file:", err)
+			fatal("Error opening Bitcoin CSV Addresses file:", err)
 		}
+		diag.SetFile(file)
 		err = btc.ParseCSVAddresses(recordFile)
 		if err != nil {
-			log.Fatal("")
+			fatal("")
 		}
 	}
-	blkst := blockstream.New()
-	if len(config.Blockchains.BTC.CSV)+len(config.Blockchains.BTC.Addresses) > 0 {
+	blkst, err := blockstream.NewEsplora("BTC", config.Blockchains.BTC.Esplora, config.Blockchains.BTC.Decimals)
+	if err != nil {
+		fatal(err)
+	}
+	if len(config.Blockchains.BTC.CSV)+len(config.Blockchains.BTC.Addresses)+len(config.Blockchains.BTC.XPubs) > 0 {
 		go blkst.GetAllTXs(btc, *categ)
 	}
+	ada := cardano.New()
+	ada.AddListAddresses(config.Blockchains.ADA.Addresses)
+	for _, file := range config.Blockchains.ADA.CSV {
+		recordFile, err := os.Open(file)
+		if err != nil {
+			fatal("Error opening Cardano CSV Addresses file:", err)
+		}
+		diag.SetFile(file)
+		err = ada.ParseCSVAddresses(recordFile)
+		if err != nil {
+			fatal("Error parsing Cardano CSV Addresses file:", err)
+		}
+	}
+	if len(config.Blockchains.ADA.CSV)+len(config.Blockchains.ADA.Addresses) > 0 {
+		ada.NewAPI(config.Tools.Blockfrost.URL, config.Tools.Blockfrost.Key, config.Options.Debug)
+		go ada.GetAPITXs(*categ)
+	}
 	ethsc := etherscan.New()
 	ethsc.AddListAddresses(config.Blockchains.ETH.Addresses)
 	for _, file := range config.Blockchains.ETH.CSV {
 		recordFile, err := os.Open(file)
 		if err != nil {
-			log.Fatal("Error opening Ethereum CSV Addresses file:", err)
+			fatal("Error opening Ethereum CSV Addresses file:", err)
 		}
+		diag.SetFile(file)
 		err = ethsc.ParseCSVAddresses(recordFile)
 		if err != nil {
-			log.Fatal("")
+			fatal("")
 		}
 	}
 	if len(config.Blockchains.ETH.CSV)+len(config.Blockchains.ETH.Addresses) > 0 {
 		ethsc.NewAPI(config.Tools.EtherScan.Key, config.Options.Debug)
+		ethsc.SetTokenFilter(config.Blockchains.ETH.Tokens.Allow, config.Blockchains.ETH.Tokens.Deny, config.Blockchains.ETH.SpamFilter)
+		if config.Blockchains.ETH.RPC != "" || config.Blockchains.ETH.RPCDump != "" {
+			err = ethsc.UseRPC(config.Blockchains.ETH.RPC, config.Blockchains.ETH.RPCDump, config.Options.Debug)
+			if err != nil {
+				fatal(err)
+			}
+		}
 		go ethsc.GetAPITXs(*categ)
 	}
+	evms := make(map[string]*etherscan.Etherscan)
+	for _, chain := range evmChains {
+		conf := config.Blockchains.Others[chain]
+		evm := etherscan.New()
+		evm.AddListAddresses(conf.Addresses)
+		for _, file := range conf.CSV {
+			recordFile, err := os.Open(file)
+			if err != nil {
+				fatal("Error opening "+chain+" CSV Addresses file:", err)
+			}
+			diag.SetFile(file)
+			err = evm.ParseCSVAddresses(recordFile)
+			if err != nil {
+				fatal("Error parsing "+chain+" CSV Addresses file:", err)
+			}
+		}
+		if len(conf.CSV)+len(conf.Addresses) > 0 {
+			err = evm.NewChainAPI(chain, conf.Etherscan.URL, conf.Etherscan.Key, conf.Native, config.Options.Debug)
+			if err != nil {
+				fatal(err)
+			}
+			evm.SetTokenFilter(conf.Tokens.Allow, conf.Tokens.Deny, conf.SpamFilter)
+			if conf.RPC != "" || conf.RPCDump != "" {
+				err = evm.UseRPC(conf.RPC, conf.RPCDump, config.Options.Debug)
+				if err != nil {
+					fatal(err)
+				}
+			}
+			evms[chain] = evm
+			go evm.GetAPITXs(*categ)
+		}
+	}
 	b := binance.New()
 	if config.Exchanges.Binance.API.Key != "" && config.Exchanges.Binance.API.Secret != "" {
 		b.NewAPI(config.Exchanges.Binance.API.Key, config.Exchanges.Binance.API.Secret, config.Options.Debug)
@@ -133,356 +319,536 @@ func main() {
 		go kr.GetAPIAllTXs()
 	}
 	// Now parse local files
+	coverage := wallet.NewCoverage()
 	bc := blockchain.New()
 	if config.Blockchains.BTG.JSON != "" {
 		jsonFile, err := os.Open(config.Blockchains.BTG.JSON)
 		if err != nil {
-			log.Fatal("Error opening Bitcoin Gold JSON Transactions file:", err)
+			fatal("Error opening Bitcoin Gold JSON Transactions file:", err)
 		}
+		diag.SetFile(config.Blockchains.BTG.JSON)
 		err = bc.ParseTXsJSON(jsonFile, "BTG")
 		if err != nil {
-			log.Fatal("Error parsing Bitcoin Gold JSON Transactions file:", err)
+			fatal("Error parsing Bitcoin Gold JSON Transactions file:", err)
 		}
 	}
 	for _, file := range config.Exchanges.Binance.CSV.All {
 		recordFile, err := os.Open(file)
 		if err != nil {
-			log.Fatal("Error opening Binance CSV file:", err)
+			fatal("Error opening Binance CSV file:", err)
 		}
-		err = b.ParseCSV(recordFile, config.Options.BinanceExtended, config.Exchanges.Binance.Account)
+		diag.SetFile(file)
+		snap := b.TXsByCategory.Snapshot()
+		err = b.ParseCSV(recordFile, config.Exchanges.Binance.Account)
 		if err != nil {
-			log.Fatal("Error parsing Binance CSV file:", err)
+			fatal("Error parsing Binance CSV file:", err)
 		}
+		coverage.AddFile("Binance", config.Exchanges.Binance.Account, file, b.TXsByCategory.Since(snap))
 	}
 	bf := bitfinex.New()
 	for _, file := range config.Exchanges.Bitfinex.CSV.All {
 		recordFile, err := os.Open(file)
 		if err != nil {
-			log.Fatal("Error opening Bitfinex CSV file:", err)
+			fatal("Error opening Bitfinex CSV file:", err)
 		}
+		diag.SetFile(file)
+		snap := bf.TXsByCategory.Snapshot()
 		err = bf.ParseCSV(recordFile, config.Exchanges.Bitfinex.Account)
 		if err != nil {
-			log.Fatal("Error parsing Bitfinex CSV file:", err)
+			fatal("Error parsing Bitfinex CSV file:", err)
 		}
+		coverage.AddFile("Bitfinex", config.Exchanges.Bitfinex.Account, file, bf.TXsByCategory.Since(snap))
 	}
 	for _, file := range config.Exchanges.Bitstamp.CSV.All {
 		recordFile, err := os.Open(file)
 		if err != nil {
-			log.Fatal("Error opening Bitstamp CSV file:", err)
+			fatal("Error opening Bitstamp CSV file:", err)
 		}
+		diag.SetFile(file)
+		snap := bs.TXsByCategory.Snapshot()
 		err = bs.ParseCSV(recordFile, *categ, config.Options.Native, config.Exchanges.Bitstamp.Account)
 		if err != nil {
-			log.Fatal("Error parsing Bitstamp CSV file:", err)
+			fatal("Error parsing Bitstamp CSV file:", err)
 		}
+		coverage.AddFile("Bitstamp", config.Exchanges.Bitstamp.Account, file, bs.TXsByCategory.Since(snap))
 	}
 	for _, file := range config.Exchanges.Bittrex.CSV.All {
 		recordFile, err := os.Open(file)
 		if err != nil {
-			log.Fatal("Error opening Bittrex CSV file:", err)
+			fatal("Error opening Bittrex CSV file:", err)
 		}
+		diag.SetFile(file)
+		snap := btrx.TXsByCategory.Snapshot()
 		err = btrx.ParseCSV(recordFile, *categ, config.Exchanges.Bittrex.Account)
 		if err != nil {
-			log.Fatal("Error parsing Bittrex CSV file:", err)
+			fatal("Error parsing Bittrex CSV file:", err)
 		}
+		coverage.AddFile("Bittrex", config.Exchanges.Bittrex.Account, file, btrx.TXsByCategory.Since(snap))
 	}
 	cb := coinbase.New()
 	for _, file := range config.Exchanges.Coinbase.CSV.All {
 		recordFile, err := os.Open(file)
 		if err != nil {
-			log.Fatal("Error opening Coinbase CSV file:", err)
+			fatal("Error opening Coinbase CSV file:", err)
 		}
+		diag.SetFile(file)
+		snap := cb.TXsByCategory.Snapshot()
 		err = cb.ParseCSV(recordFile, *categ, config.Exchanges.Coinbase.Account)
 		if err != nil {
-			log.Fatal("Error parsing Coinbase CSV file:", err)
+			fatal("Error parsing Coinbase CSV file:", err)
 		}
+		coverage.AddFile("Coinbase", config.Exchanges.Coinbase.Account, file, cb.TXsByCategory.Since(snap))
 	}
 	cbp := coinbasepro.New()
 	for _, file := range config.Exchanges.CoinbasePro.CSV.Trades {
 		recordFile, err := os.Open(file)
 		if err != nil {
-			log.Fatal("Error opening Coinbase Pro Fills CSV file:", err)
+			fatal("Error opening Coinbase Pro Fills CSV file:", err)
 		}
+		diag.SetFile(file)
+		snap := cbp.TXsByCategory.Snapshot()
 		err = cbp.ParseFillsCSV(recordFile, config.Exchanges.CoinbasePro.Account)
 		if err != nil {
-			log.Fatal("Error parsing Coinbase Pro Fills CSV file:", err)
+			fatal("Error parsing Coinbase Pro Fills CSV file:", err)
 		}
+		coverage.AddFile("CoinbasePro", config.Exchanges.CoinbasePro.Account, file, cbp.TXsByCategory.Since(snap))
 	}
 	for _, file := range config.Exchanges.CoinbasePro.CSV.Transfers {
 		recordFile, err := os.Open(file)
 		if err != nil {
-			log.Fatal("Error opening Coinbase Pro Account CSV file:", err)
+			fatal("Error opening Coinbase Pro Account CSV file:", err)
 		}
+		diag.SetFile(file)
+		snap := cbp.TXsByCategory.Snapshot()
 		err = cbp.ParseAccountCSV(recordFile, config.Exchanges.CoinbasePro.Account)
 		if err != nil {
-			log.Fatal("Error parsing Coinbase Pro Account CSV file:", err)
+			fatal("Error parsing Coinbase Pro Account CSV file:", err)
 		}
+		coverage.AddFile("CoinbasePro", config.Exchanges.CoinbasePro.Account, file, cbp.TXsByCategory.Since(snap))
 	}
 	for _, file := range config.Exchanges.CdcApp.CSV.All {
 		recordFile, err := os.Open(file)
 		if err != nil {
-			log.Fatal("Error opening Crypto.com CSV file:", err)
+			fatal("Error opening Crypto.com CSV file:", err)
 		}
+		diag.SetFile(file)
+		snap := cdc.TXsByCategory.Snapshot()
 		err = cdc.ParseCSVAppCrypto(recordFile, *categ, config.Exchanges.CdcApp.Account)
 		if err != nil {
-			log.Fatal("Error parsing Crypto.com CSV file:", err)
+			fatal("Error parsing Crypto.com CSV file:", err)
 		}
+		coverage.AddFile("CdC App", config.Exchanges.CdcApp.Account, file, cdc.TXsByCategory.Since(snap))
 	}
 	if config.Exchanges.CdcEx.JSON != "" {
 		recordFile, err := os.Open(config.Exchanges.CdcEx.JSON)
 		if err != nil {
-			log.Fatal("Error opening Crypto.com Exchange ExportJS JSON file:", err)
+			fatal("Error opening Crypto.com Exchange ExportJS JSON file:", err)
 		}
+		diag.SetFile(config.Exchanges.CdcEx.JSON)
+		snap := cdc.TXsByCategory.Snapshot()
 		err = cdc.ParseJSONExchangeExportJS(recordFile, config.Exchanges.CdcEx.Account)
 		if err != nil {
-			log.Fatal("Error parsing Crypto.com Exchange ExportJS JSON file:", err)
+			fatal("Error parsing Crypto.com Exchange ExportJS JSON file:", err)
 		}
+		coverage.AddFile("CdC Exchange", config.Exchanges.CdcEx.Account, config.Exchanges.CdcEx.JSON, cdc.TXsByCategory.Since(snap))
 	}
 	for _, file := range config.Exchanges.CdcEx.CSV.Transfers {
 		recordFile, err := os.Open(file)
 		if err != nil {
-			log.Fatal("Error opening Crypto.com Exchange Deposit/Withdrawal CSV file:", err)
+			fatal("Error opening Crypto.com Exchange Deposit/Withdrawal CSV file:", err)
 		}
+		diag.SetFile(file)
+		snap := cdc.TXsByCategory.Snapshot()
 		err = cdc.ParseCSVExchangeTransfer(recordFile)
 		if err != nil {
-			log.Fatal("Error parsing Crypto.com Exchange Deposit/Withdrawal CSV file:", err)
+			fatal("Error parsing Crypto.com Exchange Deposit/Withdrawal CSV file:", err)
 		}
+		coverage.AddFile("CdC Exchange", config.Exchanges.CdcEx.Account, file, cdc.TXsByCategory.Since(snap))
 	}
 	for _, file := range config.Exchanges.CdcEx.CSV.Staking {
 		recordFile, err := os.Open(file)
 		if err != nil {
-			log.Fatal("Error opening Crypto.com Exchange Stake CSV file:", err)
+			fatal("Error opening Crypto.com Exchange Stake CSV file:", err)
 		}
+		diag.SetFile(file)
+		snap := cdc.TXsByCategory.Snapshot()
 		err = cdc.ParseCSVExchangeStake(recordFile)
 		if err != nil {
-			log.Fatal("Error parsing Crypto.com Exchange Stake CSV file:", err)
+			fatal("Error parsing Crypto.com Exchange Stake CSV file:", err)
 		}
+		coverage.AddFile("CdC Exchange", config.Exchanges.CdcEx.Account, file, cdc.TXsByCategory.Since(snap))
 	}
 	for _, file := range config.Exchanges.CdcEx.CSV.Trades {
 		recordFile, err := os.Open(file)
 		if err != nil {
-			log.Fatal("Error opening Crypto.com Exchange Spot Trade CSV file:", err)
+			fatal("Error opening Crypto.com Exchange Spot Trade CSV file:", err)
 		}
+		diag.SetFile(file)
+		snap := cdc.TXsByCategory.Snapshot()
 		err = cdc.ParseCSVExchangeSpotTrade(recordFile)
 		if err != nil {
-			log.Fatal("Error parsing Crypto.com Exchange Spot Trade CSV file:", err)
+			fatal("Error parsing Crypto.com Exchange Spot Trade CSV file:", err)
 		}
+		coverage.AddFile("CdC Exchange", config.Exchanges.CdcEx.Account, file, cdc.TXsByCategory.Since(snap))
 	}
 	for _, file := range config.Exchanges.CdcEx.CSV.Supercharger {
 		recordFile, err := os.Open(file)
 		if err != nil {
-			log.Fatal("Error opening Crypto.com Exchange Supercharger CSV file:", err)
+			fatal("Error opening Crypto.com Exchange Supercharger CSV file:", err)
 		}
+		diag.SetFile(file)
+		snap := cdc.TXsByCategory.Snapshot()
 		err = cdc.ParseCSVExchangeSupercharger(recordFile)
 		if err != nil {
-			log.Fatal("Error parsing Crypto.com Exchange Supercharger CSV file:", err)
+			fatal("Error parsing Crypto.com Exchange Supercharger CSV file:", err)
 		}
+		coverage.AddFile("CdC Exchange", config.Exchanges.CdcEx.Account, file, cdc.TXsByCategory.Since(snap))
 	}
 	for _, file := range config.Exchanges.HitBTC.CSV.Trades {
 		recordFile, err := os.Open(file)
 		if err != nil {
-			log.Fatal("Error opening HitBTC Trades CSV file:", err)
+			fatal("Error opening HitBTC Trades CSV file:", err)
 		}
+		diag.SetFile(file)
+		snap := hb.TXsByCategory.Snapshot()
 		err = hb.ParseCSVTrades(recordFile)
 		if err != nil {
-			log.Fatal("Error parsing HitBTC Trades CSV file:", err)
+			fatal("Error parsing HitBTC Trades CSV file:", err)
 		}
+		coverage.AddFile("HitBTC", config.Exchanges.HitBTC.Account, file, hb.TXsByCategory.Since(snap))
 	}
 	for _, file := range config.Exchanges.HitBTC.CSV.Transfers {
 		recordFile, err := os.Open(file)
 		if err != nil {
-			log.Fatal("Error opening HitBTC Transactions CSV file:", err)
+			fatal("Error opening HitBTC Transactions CSV file:", err)
 		}
+		diag.SetFile(file)
+		snap := hb.TXsByCategory.Snapshot()
 		err = hb.ParseCSVTransactions(recordFile)
 		if err != nil {
-			log.Fatal("Error parsing HitBTC Transactions CSV file:", err)
+			fatal("Error parsing HitBTC Transactions CSV file:", err)
 		}
+		coverage.AddFile("HitBTC", config.Exchanges.HitBTC.Account, file, hb.TXsByCategory.Since(snap))
 	}
 	for _, file := range config.Exchanges.Kraken.CSV.All {
 		recordFile, err := os.Open(file)
 		if err != nil {
-			log.Fatal("Error opening Kraken CSV file:", err)
+			fatal("Error opening Kraken CSV file:", err)
 		}
+		diag.SetFile(file)
+		snap := kr.TXsByCategory.Snapshot()
 		err = kr.ParseCSV(recordFile, *categ, config.Exchanges.Kraken.Account)
 		if err != nil {
-			log.Fatal("Error parsing Kraken CSV file:", err)
+			fatal("Error parsing Kraken CSV file:", err)
 		}
+		coverage.AddFile("Kraken", config.Exchanges.Kraken.Account, file, kr.TXsByCategory.Since(snap))
 	}
 	ll := ledgerlive.New()
 	for _, file := range config.Wallets.LedgerLive.CSV.All {
 		recordFile, err := os.Open(file)
 		if err != nil {
-			log.Fatal("Error opening LedgerLive CSV file:", err)
+			fatal("Error opening LedgerLive CSV file:", err)
 		}
+		diag.SetFile(file)
 		err = ll.ParseCSV(recordFile, *categ)
 		if err != nil {
-			log.Fatal("Error parsing LedgerLive CSV file:", err)
+			fatal("Error parsing LedgerLive CSV file:", err)
 		}
 	}
 	lb := localbitcoin.New()
 	for _, file := range config.Exchanges.LocalBitcoins.CSV.Trades {
 		recordFile, err := os.Open(file)
 		if err != nil {
-			log.Fatal("Error opening Local Bitcoin Trade CSV file:", err)
+			fatal("Error opening Local Bitcoin Trade CSV file:", err)
 		}
+		diag.SetFile(file)
+		snap := lb.TXsByCategory.Snapshot()
 		err = lb.ParseTradeCSV(recordFile, config.Exchanges.LocalBitcoins.Account)
 		if err != nil {
-			log.Fatal("Error parsing Local Bitcoin Trade CSV file:", err)
+			fatal("Error parsing Local Bitcoin Trade CSV file:", err)
 		}
+		coverage.AddFile("Local Bitcoin", config.Exchanges.LocalBitcoins.Account, file, lb.TXsByCategory.Since(snap))
 	}
 	for _, file := range config.Exchanges.LocalBitcoins.CSV.Transfers {
 		recordFile, err := os.Open(file)
 		if err != nil {
-			log.Fatal("Error opening Local Bitcoin Transfer CSV file:", err)
+			fatal("Error opening Local Bitcoin Transfer CSV file:", err)
 		}
+		diag.SetFile(file)
+		snap := lb.TXsByCategory.Snapshot()
 		err = lb.ParseTransferCSV(recordFile, config.Exchanges.LocalBitcoins.Account)
 		if err != nil {
-			log.Fatal("Error parsing Local Bitcoin Transfer CSV file:", err)
+			fatal("Error parsing Local Bitcoin Transfer CSV file:", err)
 		}
+		coverage.AddFile("Local Bitcoin", config.Exchanges.LocalBitcoins.Account, file, lb.TXsByCategory.Since(snap))
 	}
 	xmr := monero.New()
 	for _, file := range config.Wallets.Monero.CSV.All {
 		recordFile, err := os.Open(file)
 		if err != nil {
-			log.Fatal("Error opening Monero CSV file:", err)
+			fatal("Error opening Monero CSV file:", err)
 		}
+		diag.SetFile(file)
 		err = xmr.ParseCSV(recordFile, *categ)
 		if err != nil {
-			log.Fatal("Error parsing Monero CSV file:", err)
+			fatal("Error parsing Monero CSV file:", err)
+		}
+	}
+	man := manual.New()
+	for _, file := range config.Wallets.Manual.CSV.All {
+		recordFile, err := os.Open(file)
+		if err != nil {
+			fatal("Error opening Manual CSV file:", err)
+		}
+		diag.SetFile(file)
+		err = man.ParseCSV(recordFile)
+		if err != nil {
+			fatal("Error parsing Manual CSV file:", err)
+		}
+	}
+	for _, file := range config.Wallets.Manual.JSON {
+		recordFile, err := os.Open(file)
+		if err != nil {
+			fatal("Error opening Manual JSON file:", err)
+		}
+		diag.SetFile(file)
+		err = man.ParseJSON(recordFile)
+		if err != nil {
+			fatal("Error parsing Manual JSON file:", err)
+		}
+	}
+	ko := koinly.New()
+	for _, file := range config.Wallets.Koinly.CSV.All {
+		recordFile, err := os.Open(file)
+		if err != nil {
+			fatal("Error opening Koinly CSV file:", err)
+		}
+		diag.SetFile(file)
+		err = ko.ParseCSV(recordFile)
+		if err != nil {
+			fatal("Error parsing Koinly CSV file:", err)
+		}
+	}
+	ct := cointracking.New()
+	for _, file := range config.Wallets.CoinTracking.CSV.All {
+		recordFile, err := os.Open(file)
+		if err != nil {
+			fatal("Error opening CoinTracking CSV file:", err)
+		}
+		diag.SetFile(file)
+		err = ct.ParseCSV(recordFile)
+		if err != nil {
+			fatal("Error parsing CoinTracking CSV file:", err)
 		}
 	}
 	mc := mycelium.New()
 	for _, file := range config.Wallets.MyCelium.CSV.All {
 		recordFile, err := os.Open(file)
 		if err != nil {
-			log.Fatal("Error opening MyCelium CSV file:", err)
+			fatal("Error opening MyCelium CSV file:", err)
 		}
+		diag.SetFile(file)
 		err = mc.ParseCSV(recordFile)
 		if err != nil {
-			log.Fatal("Error parsing MyCelium CSV file:", err)
+			fatal("Error parsing MyCelium CSV file:", err)
+		}
+	}
+	for _, file := range config.Wallets.BitcoinCore.CSV.All {
+		recordFile, err := os.Open(file)
+		if err != nil {
+			fatal("Error opening Bitcoin Core CSV file:", err)
+		}
+		diag.SetFile(file)
+		err = core.ParseCoreCSV(recordFile, *categ)
+		if err != nil {
+			fatal("Error parsing Bitcoin Core CSV file:", err)
+		}
+	}
+	for _, file := range config.Wallets.BitcoinCore.JSON {
+		recordFile, err := os.Open(file)
+		if err != nil {
+			fatal("Error opening Bitcoin Core JSON file:", err)
+		}
+		diag.SetFile(file)
+		err = core.ParseCoreJSON(recordFile, *categ)
+		if err != nil {
+			fatal("Error parsing Bitcoin Core JSON file:", err)
+		}
+	}
+	for _, file := range config.Wallets.Electrum.CSV.All {
+		recordFile, err := os.Open(file)
+		if err != nil {
+			fatal("Error opening Electrum CSV file:", err)
+		}
+		diag.SetFile(file)
+		err = elec.ParseElectrumCSV(recordFile, *categ)
+		if err != nil {
+			fatal("Error parsing Electrum CSV file:", err)
 		}
 	}
 	pl := poloniex.New()
 	for _, file := range config.Exchanges.Poloniex.CSV.Deposits {
 		recordFile, err := os.Open(file)
 		if err != nil {
-			log.Fatal("Error opening Poloniex Deposits CSV file:", err)
+			fatal("Error opening Poloniex Deposits CSV file:", err)
 		}
+		diag.SetFile(file)
+		snap := pl.TXsByCategory.Snapshot()
 		err = pl.ParseDepositsCSV(recordFile, config.Exchanges.Poloniex.Account)
 		if err != nil {
-			log.Fatal("Error parsing Poloniex Deposits CSV file:", err)
+			fatal("Error parsing Poloniex Deposits CSV file:", err)
 		}
+		coverage.AddFile("Poloniex", config.Exchanges.Poloniex.Account, file, pl.TXsByCategory.Since(snap))
 	}
 	for _, file := range config.Exchanges.Poloniex.CSV.Distributions {
 		recordFile, err := os.Open(file)
 		if err != nil {
-			log.Fatal("Error opening Poloniex Distributions CSV file:", err)
+			fatal("Error opening Poloniex Distributions CSV file:", err)
 		}
+		diag.SetFile(file)
+		snap := pl.TXsByCategory.Snapshot()
 		err = pl.ParseDistributionsCSV(recordFile, config.Exchanges.Poloniex.Account)
 		if err != nil {
-			log.Fatal("Error parsing Poloniex Distributions CSV file:", err)
+			fatal("Error parsing Poloniex Distributions CSV file:", err)
 		}
+		coverage.AddFile("Poloniex", config.Exchanges.Poloniex.Account, file, pl.TXsByCategory.Since(snap))
 	}
 	for _, file := range config.Exchanges.Poloniex.CSV.Trades {
 		recordFile, err := os.Open(file)
 		if err != nil {
-			log.Fatal("Error opening Poloniex Trades CSV file:", err)
+			fatal("Error opening Poloniex Trades CSV file:", err)
 		}
+		diag.SetFile(file)
+		snap := pl.TXsByCategory.Snapshot()
 		err = pl.ParseTradesCSV(recordFile, *categ, config.Exchanges.Poloniex.Account)
 		if err != nil {
-			log.Fatal("Error parsing Poloniex Trades CSV file:", err)
+			fatal("Error parsing Poloniex Trades CSV file:", err)
 		}
+		coverage.AddFile("Poloniex", config.Exchanges.Poloniex.Account, file, pl.TXsByCategory.Since(snap))
 	}
 	for _, file := range config.Exchanges.Poloniex.CSV.Withdrawals {
 		recordFile, err := os.Open(file)
 		if err != nil {
-			log.Fatal("Error opening Poloniex Withdrawals CSV file:", err)
+			fatal("Error opening Poloniex Withdrawals CSV file:", err)
 		}
+		diag.SetFile(file)
+		snap := pl.TXsByCategory.Snapshot()
 		err = pl.ParseWithdrawalsCSV(recordFile, *categ, config.Exchanges.Poloniex.Account)
 		if err != nil {
-			log.Fatal("Error parsing Poloniex Withdrawals CSV file:", err)
+			fatal("Error parsing Poloniex Withdrawals CSV file:", err)
 		}
+		coverage.AddFile("Poloniex", config.Exchanges.Poloniex.Account, file, pl.TXsByCategory.Since(snap))
 	}
 	revo := revolut.New()
 	for _, file := range config.Exchanges.Revolut.CSV.All {
 		recordFile, err := os.Open(file)
 		if err != nil {
-			log.Fatal("Error opening Revolut CSV file:", err)
+			fatal("Error opening Revolut CSV file:", err)
 		}
+		diag.SetFile(file)
+		snap := revo.TXsByCategory.Snapshot()
 		err = revo.ParseCSV(recordFile, config.Exchanges.Revolut.Account)
 		if err != nil {
-			log.Fatal("Error parsing Revolut CSV file:", err)
+			fatal("Error parsing Revolut CSV file:", err)
 		}
+		coverage.AddFile("Revolut", config.Exchanges.Revolut.Account, file, revo.TXsByCategory.Since(snap))
 	}
 	uh := uphold.New()
 	for _, file := range config.Exchanges.Uphold.CSV.All {
 		recordFile, err := os.Open(file)
 		if err != nil {
-			log.Fatal("Error opening Uphold CSV file:", err)
+			fatal("Error opening Uphold CSV file:", err)
 		}
+		diag.SetFile(file)
+		snap := uh.TXsByCategory.Snapshot()
 		err = uh.ParseCSV(recordFile, *categ, config.Exchanges.Uphold.Account)
 		if err != nil {
-			log.Fatal("Error parsing Uphold CSV file:", err)
+			fatal("Error parsing Uphold CSV file:", err)
 		}
+		coverage.AddFile("Uphold", config.Exchanges.Uphold.Account, file, uh.TXsByCategory.Since(snap))
+	}
+	diag.SetFile("")
+	diag.Println()
+	if config.Options.Strict && len(diag.Problems()) > 0 {
+		fatal("Strict mode : aborting on ", len(diag.Problems()), " parsing problems")
 	}
 	// Wait for API access to finish
 	if config.Exchanges.Binance.API.Key != "" && config.Exchanges.Binance.API.Secret != "" {
 		err := b.WaitFinish(config.Exchanges.Binance.Account)
 		if err != nil {
-			log.Fatal("Error getting Binance API TXs:", err)
+			fatal("Error getting Binance API TXs:", err)
 		}
 	}
 	if config.Exchanges.Bitstamp.API.Key != "" && config.Exchanges.Bitstamp.API.Secret != "" {
 		err := bs.WaitFinish(config.Exchanges.Bitstamp.Account)
 		if err != nil {
-			log.Fatal("Error getting BiTstamp API TXs:", err)
+			fatal("Error getting BiTstamp API TXs:", err)
 		}
 	}
 	if config.Exchanges.Bittrex.API.Key != "" && config.Exchanges.Bittrex.API.Secret != "" {
 		err := btrx.WaitFinish(config.Exchanges.Bittrex.Account)
 		if err != nil {
-			log.Fatal("Error getting Bittrex API TXs:", err)
+			fatal("Error getting Bittrex API TXs:", err)
 		}
 	}
 	if config.Exchanges.CdcEx.API.Key != "" && config.Exchanges.CdcEx.API.Secret != "" {
 		err := cdc.WaitFinish(config.Exchanges.CdcEx.Account)
 		if err != nil {
-			log.Fatal("Error getting Crypto.com Exchange API TXs:", err)
+			fatal("Error getting Crypto.com Exchange API TXs:", err)
 		}
 	}
 	if config.Exchanges.HitBTC.API.Key != "" && config.Exchanges.HitBTC.API.Secret != "" {
 		err := hb.WaitFinish(config.Exchanges.HitBTC.Account)
 		if err != nil {
-			log.Fatal("Error getting HitBTC API TXs:", err)
+			fatal("Error getting HitBTC API TXs:", err)
+		}
+	}
+	if len(config.Blockchains.ADA.CSV)+len(config.Blockchains.ADA.Addresses) > 0 {
+		err := ada.WaitFinish()
+		if err != nil {
+			fatal("Error getting Cardano TXs:", err)
 		}
 	}
 	if len(config.Blockchains.ETH.CSV)+len(config.Blockchains.ETH.Addresses) > 0 {
 		err := ethsc.WaitFinish()
 		if err != nil {
-			log.Fatal("Error parsing Ethereum CSV file:", err)
+			fatal("Error parsing Ethereum CSV file:", err)
+		}
+		ethsc.PrintFilteredTokens("ETH")
+		for _, f := range enabledForks {
+			if f.Parent == "ETH" || f.IsForkOf("ETH") {
+				f.DetectFromTXs(ethsc.TXsByCategory, "Etherscan API :")
+			}
+		}
+	}
+	for _, chain := range evmChains {
+		if evm, ok := evms[chain]; ok {
+			err := evm.WaitFinish()
+			if err != nil {
+				fatal("Error getting "+chain+" Etherscan TXs:", err)
+			}
+			evm.PrintFilteredTokens(chain)
 		}
 	}
 	if config.Exchanges.Kraken.API.Key != "" && config.Exchanges.Kraken.API.Secret != "" {
 		err := kr.WaitFinish(config.Exchanges.Kraken.Account)
 		if err != nil {
-			log.Fatal("Error getting Kraken API TXs:", err)
+			fatal("Error getting Kraken API TXs:", err)
 		}
 	}
-	if len(config.Blockchains.BTC.CSV)+len(config.Blockchains.BTC.Addresses) > 0 {
+	if len(config.Blockchains.BTC.CSV)+len(config.Blockchains.BTC.Addresses)+len(config.Blockchains.BTC.XPubs) > 0 {
 		err := blkst.WaitFinish()
 		if err != nil {
-			log.Fatal("Error parsing Bitcoin CSV file:", err)
-		}
-		if config.Options.Bcd {
-			blkst.DetectBCD(btc)
-		}
-		if config.Options.Bch {
-			blkst.DetectBCH(btc)
-		}
-		if config.Options.Btg {
-			blkst.DetectBTG(btc)
+			fatal("Error parsing Bitcoin CSV file:", err)
 		}
-		if config.Options.Lbtc {
-			blkst.DetectLBTC(btc)
+		blkst.DetectForks(btc, enabledForks)
+	}
+	for _, coin := range utxoCoins {
+		if esplora, ok := esploras[coin]; ok {
+			err := esplora.WaitFinish()
+			if err != nil {
+				fatal("Error getting "+coin+" Esplora TXs:", err)
+			}
 		}
 	}
 	// Merge TXs from differents methods within same Source
@@ -550,6 +916,9 @@ func main() {
 	}
 	if config.Options.Export3916 {
 		sources := make(source.Sources)
+		// Imported histories first so that native Sources details prevail
+		sources.Add(ko.Sources)
+		sources.Add(ct.Sources)
 		sources.Add(b.Sources)
 		sources.Add(bf.Sources)
 		sources.Add(bs.Sources)
@@ -565,9 +934,42 @@ func main() {
 		sources.Add(uh.Sources)
 		err = sources.ToXlsx("3916.xlsx", loc)
 		if err != nil {
-			log.Fatal(err)
-		}
-	}
+			fatal(err)
+		}
+	}
+	// Tag every TX leg with its location
+	b.TXsByCategory.SetLocation("Binance", config.Exchanges.Binance.Account)
+	bf.TXsByCategory.SetLocation("Bitfinex", config.Exchanges.Bitfinex.Account)
+	bs.TXsByCategory.SetLocation("Bitstamp", config.Exchanges.Bitstamp.Account)
+	btrx.TXsByCategory.SetLocation("Bittrex", config.Exchanges.Bittrex.Account)
+	cb.TXsByCategory.SetLocation("Coinbase", config.Exchanges.Coinbase.Account)
+	cbp.TXsByCategory.SetLocation("CoinbasePro", config.Exchanges.CoinbasePro.Account)
+	cdc.TXsByCategory.SetLocationByNote("Crypto.com App", "CdC App", config.Exchanges.CdcApp.Account)
+	cdc.TXsByCategory.SetLocation("CdC Exchange", config.Exchanges.CdcEx.Account)
+	hb.TXsByCategory.SetLocation("HitBTC", config.Exchanges.HitBTC.Account)
+	kr.TXsByCategory.SetLocation("Kraken", config.Exchanges.Kraken.Account)
+	ll.TXsByCategory.SetLocation("LedgerLive", "")
+	lb.TXsByCategory.SetLocation("Local Bitcoin", config.Exchanges.LocalBitcoins.Account)
+	xmr.TXsByCategory.SetLocation("Monero", "")
+	man.TXsByCategory.SetLocation("Manual", "")
+	ko.TXsByCategory.SetLocation("Koinly", "")
+	ct.TXsByCategory.SetLocation("CoinTracking", "")
+	mc.TXsByCategory.SetLocation("MyCelium", "")
+	core.TXsByCategory.SetLocation("Bitcoin Core", "")
+	elec.TXsByCategory.SetLocation("Electrum", "")
+	pl.TXsByCategory.SetLocation("Poloniex", config.Exchanges.Poloniex.Account)
+	revo.TXsByCategory.SetLocation("Revolut", config.Exchanges.Revolut.Account)
+	uh.TXsByCategory.SetLocation("Uphold", config.Exchanges.Uphold.Account)
+	ada.TXsByCategory.SetLocation("Cardano", "")
+	ethsc.TXsByCategory.SetLocation("Ethereum", "")
+	for chain, evm := range evms {
+		evm.TXsByCategory.SetLocation(chain, "")
+	}
+	btc.TXsByCategory.SetLocation("Bitcoin", "")
+	for coin, utxo := range utxos {
+		utxo.TXsByCategory.SetLocation(coin, "")
+	}
+	bc.TXsByCategory.SetLocation("Bitcoin Gold", "")
 	// create Global Wallet up to Date
 	global := make(wallet.TXsByCategory)
 	global.Add(b.TXsByCategory)
@@ -582,30 +984,209 @@ func main() {
 	global.Add(ll.TXsByCategory)
 	global.Add(lb.TXsByCategory)
 	global.Add(xmr.TXsByCategory)
+	global.Add(man.TXsByCategory)
+	global.Add(ko.TXsByCategory)
+	global.Add(ct.TXsByCategory)
 	global.Add(mc.TXsByCategory)
+	global.Add(core.TXsByCategory)
+	global.Add(elec.TXsByCategory)
 	global.Add(pl.TXsByCategory)
 	global.Add(revo.TXsByCategory)
 	global.Add(uh.TXsByCategory)
+	global.Add(ada.TXsByCategory)
 	global.Add(ethsc.TXsByCategory)
+	for _, chain := range evmChains {
+		if evm, ok := evms[chain]; ok {
+			global.Add(evm.TXsByCategory)
+		}
+	}
 	global.Add(btc.TXsByCategory)
+	for _, coin := range utxoCoins {
+		if utxo, ok := utxos[coin]; ok {
+			global.Add(utxo.TXsByCategory)
+		}
+	}
 	global.Add(bc.TXsByCategory)
+	for _, file := range config.Options.TxsImport {
+		recordFile, err := os.Open(file)
+		if err != nil {
+			fatal("Error opening TXs file:", err)
+		}
+		var imported wallet.TXsByCategory
+		if strings.ToLower(filepath.Ext(file)) == ".json" {
+			imported, err = wallet.ImportTXsJSON(recordFile)
+		} else {
+			imported, err = wallet.ImportTXsCSV(recordFile)
+		}
+		recordFile.Close()
+		if err != nil {
+			fatal("Error parsing TXs file:", err)
+		}
+		global.Add(imported)
+	}
+	global.ApplyRules(*categ, config.Options.Native)
+	global.ApplyOverrides(*categ)
 	fmt.Print("Merging Deposits with Withdrawals into Transfers...")
 	global.FindTransfers(*categ)
 	fmt.Println("Finished")
+	if config.Options.ExportTXs {
+		jsonFile, err := os.Create("txs.json")
+		if err != nil {
+			fatal("Error creating txs.json:", err)
+		}
+		err = global.TXsToJSON(jsonFile)
+		jsonFile.Close()
+		if err != nil {
+			fatal("Error exporting txs.json:", err)
+		}
+		csvFile, err := os.Create("txs.csv")
+		if err != nil {
+			fatal("Error creating txs.csv:", err)
+		}
+		err = global.TXsToCSV(csvFile)
+		csvFile.Close()
+		if err != nil {
+			fatal("Error exporting txs.csv:", err)
+		}
+	}
+	if config.Options.Reconcile {
+		tolerance := decimal.New(1, -8)
+		if config.Exchanges.Binance.API.Key != "" && config.Exchanges.Binance.API.Secret != "" {
+			location := wallet.NewLocation("Binance", config.Exchanges.Binance.Account)
+			balances, err := b.GetAPIBalances()
+			if err != nil {
+				log.Println("Error getting Binance API Balances:", err)
+			} else {
+				global.Reconcile(location, balances, tolerance).Println(location)
+			}
+		}
+		if config.Exchanges.Bitstamp.API.Key != "" && config.Exchanges.Bitstamp.API.Secret != "" {
+			location := wallet.NewLocation("Bitstamp", config.Exchanges.Bitstamp.Account)
+			balances, err := bs.GetAPIBalances()
+			if err != nil {
+				log.Println("Error getting Bitstamp API Balances:", err)
+			} else {
+				global.Reconcile(location, balances, tolerance).Println(location)
+			}
+		}
+		if config.Exchanges.Bittrex.API.Key != "" && config.Exchanges.Bittrex.API.Secret != "" {
+			location := wallet.NewLocation("Bittrex", config.Exchanges.Bittrex.Account)
+			balances, err := btrx.GetAPIBalances()
+			if err != nil {
+				log.Println("Error getting Bittrex API Balances:", err)
+			} else {
+				global.Reconcile(location, balances, tolerance).Println(location)
+			}
+		}
+		if config.Exchanges.CdcEx.API.Key != "" && config.Exchanges.CdcEx.API.Secret != "" {
+			location := wallet.NewLocation("CdC Exchange", config.Exchanges.CdcEx.Account)
+			balances, err := cdc.GetAPIExchangeBalances()
+			if err != nil {
+				log.Println("Error getting Crypto.com Exchange API Balances:", err)
+			} else {
+				global.Reconcile(location, balances, tolerance).Println(location)
+			}
+		}
+		if config.Exchanges.HitBTC.API.Key != "" && config.Exchanges.HitBTC.API.Secret != "" {
+			location := wallet.NewLocation("HitBTC", config.Exchanges.HitBTC.Account)
+			balances, err := hb.GetAPIBalances()
+			if err != nil {
+				log.Println("Error getting HitBTC API Balances:", err)
+			} else {
+				global.Reconcile(location, balances, tolerance).Println(location)
+			}
+		}
+		if config.Exchanges.Kraken.API.Key != "" && config.Exchanges.Kraken.API.Secret != "" {
+			location := wallet.NewLocation("Kraken", config.Exchanges.Kraken.Account)
+			balances, err := kr.GetAPIBalances()
+			if err != nil {
+				log.Println("Error getting Kraken API Balances:", err)
+			} else {
+				global.Reconcile(location, balances, tolerance).Println(location)
+			}
+		}
+	}
 	if config.Options.ExportStock {
-		global.StockToXlsx("stock.xlsx")
+		err = global.StockToXlsx("stock.xlsx")
+		if err != nil {
+			fatal(err)
+		}
 	}
-	if config.Options.Export2086 || config.Options.Display2086 {
+	if config.Options.ExportLocations {
+		err = global.LocationsToXlsx("locations.xlsx")
+		if err != nil {
+			fatal("Error exporting locations.xlsx:", err)
+		}
+		csvFile, err := os.Create("locations.csv")
+		if err != nil {
+			fatal("Error creating locations.csv:", err)
+		}
+		err = global.LocationsToCSV(csvFile)
+		csvFile.Close()
+		if err != nil {
+			fatal("Error exporting locations.csv:", err)
+		}
+	}
+	// Third party formats need CashIn and CashOut as much as the 2086
+	if config.Options.Export2086 || config.Options.Display2086 ||
+		config.Options.ExportKoinly || config.Options.ExportCoinTracking || config.Options.ExportWaltio {
 		fmt.Print("Look for CashIn and CashOut...")
 		global.FindCashInOut(config.Options.Native)
 		fmt.Println("Finished")
 	}
+	thirdParties := []struct {
+		enabled  bool
+		filename string
+		export   func(io.Writer, wallet.TXsByCategory) error
+	}{
+		{config.Options.ExportKoinly, "koinly.csv", koinly.ExportCSV},
+		{config.Options.ExportCoinTracking, "cointracking.csv", cointracking.ExportCSV},
+		{config.Options.ExportWaltio, "waltio.csv", waltio.ExportCSV},
+	}
+	for _, tp := range thirdParties {
+		if tp.enabled {
+			csvFile, err := os.Create(tp.filename)
+			if err != nil {
+				fatal("Error creating "+tp.filename+":", err)
+			}
+			err = tp.export(csvFile, global)
+			csvFile.Close()
+			if err != nil {
+				fatal("Error exporting "+tp.filename+":", err)
+			}
+		}
+	}
 	global.SortByDate(true)
 	if config.Options.Stats {
 		global.PrintStats(config.Options.Native)
 	}
-	if config.Options.Check {
-		global.CheckConsistency(loc)
+	if config.Options.Check || config.Options.ExportLint {
+		linter := lint.New(loc)
+		linter.Add(lint.CoverageRule(coverage))
+		linter.MinSeverity = lint.ParseSeverity(config.Lint.MinSeverity)
+		for _, s := range config.Lint.Suppressions {
+			linter.Suppress(lint.Suppression{Rule: s.Rule, TX: s.TX, Source: s.Source, Location: s.Location, Reason: s.Reason})
+		}
+		issues := linter.Run(global)
+		issues.SortByRule()
+		if config.Options.Check {
+			linter.Println(issues)
+		}
+		if config.Options.ExportLint {
+			jsonFile, err := os.Create("lint.json")
+			if err != nil {
+				fatal("Error creating lint.json:", err)
+			}
+			err = issues.ToJSON(jsonFile)
+			jsonFile.Close()
+			if err != nil {
+				fatal("Error exporting lint.json:", err)
+			}
+			err = issues.ToXlsx("lint.xlsx")
+			if err != nil {
+				fatal("Error exporting lint.xlsx:", err)
+			}
+		}
 	}
 	// Debug
 	if config.Options.TxsDisplay != "" {
@@ -618,7 +1199,7 @@ func main() {
 	// Construct global wallet up to date
 	filterDate, err := time.ParseInLocation("2006-01-02T15:04:05", config.Options.Date, loc)
 	if err != nil {
-		log.Fatal("Error parsing Date:", err)
+		fatal("Error parsing Date:", err)
 	}
 	globalWallet := global.GetWallets(filterDate, false, !config.Options.Exact)
 	globalWallet.Println("Global Crypto", "")
@@ -626,7 +1207,7 @@ func main() {
 	globalWalletTotalValue, err := globalWallet.CalculateTotalValue(config.Options.Native)
 	if err != nil {
 		fmt.Println("Error")
-		log.Fatal("Error Calculating Global Wallet:", err)
+		fatal("Error Calculating Global Wallet:", err)
 	} else {
 		fmt.Println("Finished")
 		globalWalletTotalValue.Amount = globalWalletTotalValue.Amount.RoundBank(0)
@@ -642,14 +1223,17 @@ func main() {
 		err = c2086.CalculatePVMV(global, config.Options.Native, loc)
 		fmt.Println("Fini")
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
 		if config.Options.Display2086 {
 			c2086.Println(config.Options.Native)
 		}
 		if config.Options.Export2086 {
-			c2086.ToXlsx("2086.xlsx", config.Options.Native)
+			err = c2086.ToXlsx("2086.xlsx", config.Options.Native)
+			if err != nil {
+				fatal(err)
+			}
 		}
 	}
-	os.Exit(0)
+	exit(0)
 }
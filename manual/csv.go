@@ -0,0 +1,87 @@
+package manual
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
+	"github.com/fiscafacile/CryptoFiscaFacile/utils"
+	"github.com/shopspring/decimal"
+)
+
+var CSVFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"ID", "Date", "Category", "From", "FromCurrency", "To", "ToCurrency", "Fee", "FeeCurrency", "Lost", "LostCurrency", "Value", "ValueCurrency", "Location", "Note"}, Positional: true, Date: "Date", DateLayouts: []string{time.RFC3339, "2006-01-02 15:04:05"}, Amounts: []string{"From", "To", "Fee", "Lost", "Value"}},
+}
+
+func parseDate(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t, err = time.Parse("2006-01-02 15:04:05", s)
+	}
+	return t, err
+}
+
+// ParseCSV reads one TX per line, lines sharing the same ID are merged to describe TXs with several From/To/Fee/Lost
+func (man *Manual) ParseCSV(reader io.Reader) (err error) {
+	const SOURCE = "Manual CSV :"
+	csvReader := csv.NewReader(reader)
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return errors.New(SOURCE + " " + err.Error())
+	}
+	var mtxs []ManualTX
+	var lines []int
+	var l utils.CSVLayout
+	index := make(map[string]int)
+	for n, r := range records {
+		if n == 0 {
+			l, err = utils.DetectCSVLayout(SOURCE, r, CSVFormats...)
+			if err != nil {
+				return
+			}
+		}
+		if l.IsHeader(r) {
+			continue
+		}
+		id := l.Get(r, "ID")
+		i, ok := index[id]
+		if !ok || id == "" {
+			mtx := ManualTX{ID: id, Category: l.Get(r, "Category"), ValueCurrency: l.Get(r, "ValueCurrency"), Location: l.Get(r, "Location"), Note: l.Get(r, "Note")}
+			mtx.Date, err = parseDate(l.Get(r, "Date"))
+			if err != nil {
+				diag.Add(SOURCE, n+1, "Date", l.Get(r, "Date"), "Error Parsing Date")
+			}
+			if l.Get(r, "Value") != "" {
+				mtx.Value, err = decimal.NewFromString(l.Get(r, "Value"))
+				if err != nil {
+					diag.Add(SOURCE, n+1, "Value", l.Get(r, "Value"), "Error Parsing Value")
+				}
+			}
+			mtx.Items = make(map[string][]Leg)
+			mtxs = append(mtxs, mtx)
+			lines = append(lines, n+1)
+			i = len(mtxs) - 1
+			index[id] = i
+		}
+		for _, k := range []string{"From", "To", "Fee", "Lost"} {
+			if l.Get(r, k) == "" {
+				continue
+			}
+			amount, err := decimal.NewFromString(l.Get(r, k))
+			if err != nil {
+				diag.Add(SOURCE, n+1, k, l.Get(r, k), "Error Parsing "+k)
+				continue
+			}
+			mtxs[i].Items[k] = append(mtxs[i].Items[k], Leg{Code: l.Get(r, k+"Currency"), Amount: amount})
+		}
+	}
+	for i, mtx := range mtxs {
+		err = man.add(SOURCE, mtx)
+		if err != nil {
+			diag.Add(SOURCE, lines[i], "", mtx.ID, err.Error())
+		}
+	}
+	return nil
+}
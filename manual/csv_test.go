@@ -0,0 +1,91 @@
+package manual
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
+)
+
+const header = "ID,Date,Category,From,FromCurrency,To,ToCurrency,Fee,FeeCurrency,Lost,LostCurrency,Value,ValueCurrency,Location,Note\n"
+
+func Test_CSVParseExemple(t *testing.T) {
+	tests := []struct {
+		name        string
+		csv         string
+		wantProblem bool
+	}{
+		{
+			name:        "ParseCSV CashIn with Value",
+			csv:         header + "otc1,2020-05-01 12:00:00,CashIn,,,0.1,BTC,,,,,800,EUR,Cash,Achat en main propre",
+			wantProblem: false,
+		},
+		{
+			name:        "ParseCSV Exchanges on several lines",
+			csv:         header + "otc2,2020-05-02T12:00:00Z,Exchanges,1,ETH,0.02,BTC,,,,,,,,\notc2,2020-05-02T12:00:00Z,Exchanges,,,,,0.001,ETH,,,,,,",
+			wantProblem: false,
+		},
+		{
+			name:        "ParseCSV Gifts",
+			csv:         header + "gift1,2020-05-03 12:00:00,Gifts,0.01,BTC,,,,,,,,,,Anniversaire",
+			wantProblem: false,
+		},
+		{
+			name:        "ParseCSV Unknown Category",
+			csv:         header + "bad1,2020-05-03 12:00:00,Presents,0.01,BTC,,,,,,,,,,",
+			wantProblem: true,
+		},
+		{
+			name:        "ParseCSV Withdrawals with To",
+			csv:         header + "bad2,2020-05-03 12:00:00,Withdrawals,0.01,BTC,1,ETH,,,,,,,,",
+			wantProblem: true,
+		},
+		{
+			name:        "ParseCSV Negative Amount",
+			csv:         header + "bad3,2020-05-03 12:00:00,Deposits,,,-1,BTC,,,,,,,,",
+			wantProblem: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diag.Reset()
+			man := New()
+			err := man.ParseCSV(strings.NewReader(tt.csv))
+			if err != nil {
+				t.Fatalf("Manual.ParseCSV() error = %v", err)
+			}
+			if (len(diag.Problems()) > 0) != tt.wantProblem {
+				t.Errorf("Manual.ParseCSV() problems = %v, wantProblem %v", diag.Problems(), tt.wantProblem)
+			}
+		})
+	}
+}
+
+func Test_JSONParseExemple(t *testing.T) {
+	const js = `[{"id": "otc3", "date": "2020-06-01T10:00:00Z", "category": "CashOut",
+		"items": {"From": [{"code": "BTC", "amount": "0.05"}]}, "value": 450, "value_currency": "EUR", "location": "Cash"}]`
+	man := New()
+	err := man.ParseJSON(strings.NewReader(js))
+	if err != nil {
+		t.Fatalf("Manual.ParseJSON() error = %v", err)
+	}
+	co := man.TXsByCategory["CashOut"]
+	if len(co) != 1 || len(co[0].Items["To"]) != 1 || co[0].Items["To"][0].Code != "EUR" || co[0].Items["From"][0].Location != "Cash" {
+		t.Errorf("Manual.ParseJSON() CashOut = %v, want 0.05 BTC from Cash to 450 EUR", co)
+	}
+}
+
+func Test_CSVParseKeepsGoing(t *testing.T) {
+	diag.Reset()
+	man := New()
+	err := man.ParseCSV(strings.NewReader(header + "bad1,2020-05-03 12:00:00,Deposits,,,1.x,BTC,,,,,,,,\nbad2,2020-05-03 12:00:00,Presents,0.01,BTC,,,,,,,,,,\ngift1,2020-05-03 12:00:00,Gifts,0.01,BTC,,,,,,,,,,"))
+	if err != nil {
+		t.Fatalf("Manual.ParseCSV() error = %v", err)
+	}
+	if len(diag.Problems()) != 3 {
+		t.Errorf("Manual.ParseCSV() problems = %v, want unreadable To, empty Deposits and unknown Category", diag.Problems())
+	}
+	if len(man.TXsByCategory["Gifts"]) != 1 {
+		t.Errorf("Manual.ParseCSV() Gifts = %v, want the valid line", man.TXsByCategory["Gifts"])
+	}
+}
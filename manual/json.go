@@ -0,0 +1,25 @@
+package manual
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
+)
+
+func (man *Manual) ParseJSON(reader io.Reader) (err error) {
+	const SOURCE = "Manual JSON :"
+	var mtxs []ManualTX
+	err = json.NewDecoder(reader).Decode(&mtxs)
+	if err != nil {
+		return errors.New(SOURCE + " " + err.Error())
+	}
+	for _, mtx := range mtxs {
+		err = man.add(SOURCE, mtx)
+		if err != nil {
+			diag.Add(SOURCE, 0, "", mtx.ID, err.Error())
+		}
+	}
+	return nil
+}
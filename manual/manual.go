@@ -0,0 +1,61 @@
+package manual
+
+import (
+	"time"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
+	"github.com/shopspring/decimal"
+)
+
+type Leg struct {
+	Code   string          `json:"code"`
+	Amount decimal.Decimal `json:"amount"`
+}
+
+type ManualTX struct {
+	ID            string           `json:"id"`
+	Date          time.Time        `json:"date"`
+	Category      string           `json:"category"`
+	Items         map[string][]Leg `json:"items"`
+	Value         decimal.Decimal  `json:"value"`
+	ValueCurrency string           `json:"value_currency"`
+	Location      string           `json:"location"`
+	Note          string           `json:"note"`
+}
+
+type Manual struct {
+	ManualTXs     []ManualTX
+	TXsByCategory wallet.TXsByCategory
+}
+
+func New() *Manual {
+	man := &Manual{}
+	man.TXsByCategory = make(map[string]wallet.TXs)
+	return man
+}
+
+// add builds the wallet.TX, the fiat Value stands for the missing side of CashIn and CashOut
+func (man *Manual) add(src string, mtx ManualTX) (err error) {
+	t := wallet.TX{Timestamp: mtx.Date, ID: mtx.ID, Note: src + " " + mtx.Note}
+	t.Items = make(map[string]wallet.Currencies)
+	for k, legs := range mtx.Items {
+		for _, l := range legs {
+			t.Items[k] = append(t.Items[k], wallet.Currency{Code: l.Code, Amount: l.Amount, Location: mtx.Location})
+		}
+	}
+	if !mtx.Value.IsZero() {
+		value := wallet.Currency{Code: mtx.ValueCurrency, Amount: mtx.Value, Location: mtx.Location}
+		if mtx.Category == "CashIn" && len(t.Items["From"]) == 0 {
+			t.Items["From"] = append(t.Items["From"], value)
+		} else if mtx.Category == "CashOut" && len(t.Items["To"]) == 0 {
+			t.Items["To"] = append(t.Items["To"], value)
+		}
+	}
+	err = t.Validate(mtx.Category)
+	if err != nil {
+		return
+	}
+	man.ManualTXs = append(man.ManualTXs, mtx)
+	man.TXsByCategory[mtx.Category] = append(man.TXsByCategory[mtx.Category], t)
+	return
+}
@@ -3,11 +3,12 @@ package monero
 import (
 	"encoding/csv"
 	"io"
-	"log"
 	"strconv"
 	"time"
 
 	"github.com/fiscafacile/CryptoFiscaFacile/category"
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
+	"github.com/fiscafacile/CryptoFiscaFacile/utils"
 	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
 	"github.com/shopspring/decimal"
 )
@@ -26,43 +27,54 @@ type CsvTX struct {
 	PaymentId      string
 }
 
+var CSVFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"blockHeight", "epoch", "date", "direction", "amount", "atomicAmount", "fee", "txid", "label", "subaddrAccount", "paymentId"}, Positional: true, Amounts: []string{"blockHeight", "epoch", "amount", "atomicAmount", "fee"}},
+}
+
 func (xmr *Monero) ParseCSV(reader io.Reader, cat category.Category) (err error) {
 	const SOURCE = "Monero CSV :"
 	csvReader := csv.NewReader(reader)
 	records, err := csvReader.ReadAll()
 	if err == nil {
 		alreadyAsked := []string{}
-		for _, r := range records {
-			if r[0] != "blockHeight" {
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVFormats...)
+				if err != nil {
+					return
+				}
+			}
+			if !l.IsHeader(r) {
 				tx := CsvTX{}
-				tx.BlockHeight = r[0]
-				epoch, err := strconv.ParseInt(r[1], 10, 64)
+				tx.BlockHeight = l.Get(r, "blockHeight")
+				epoch, err := strconv.ParseInt(l.Get(r, "epoch"), 10, 64)
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Epoch", r[1])
+					diag.Add(SOURCE, n+1, "epoch", l.Get(r, "epoch"), "Error Parsing Epoch")
 				} else {
 					tx.Epoch = time.Unix(epoch, 0)
 				}
-				tx.Date = r[2]
-				tx.Direction = r[3]
-				tx.Amount, err = decimal.NewFromString(r[4])
+				tx.Date = l.Get(r, "date")
+				tx.Direction = l.Get(r, "direction")
+				tx.Amount, err = decimal.NewFromString(l.Get(r, "amount"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Amount", r[4])
+					diag.Add(SOURCE, n+1, "amount", l.Get(r, "amount"), "Error Parsing Amount")
 				}
-				atomic, err := strconv.ParseInt(r[5], 10, 64)
+				atomic, err := strconv.ParseInt(l.Get(r, "atomicAmount"), 10, 64)
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing AtomicAmount", r[5])
+					diag.Add(SOURCE, n+1, "atomicAmount", l.Get(r, "atomicAmount"), "Error Parsing AtomicAmount")
 				} else {
 					tx.AtomicAmount = decimal.New(atomic, -12)
 				}
-				if r[6] != "" {
-					tx.Fee, err = decimal.NewFromString(r[6])
+				if l.Get(r, "fee") != "" {
+					tx.Fee, err = decimal.NewFromString(l.Get(r, "fee"))
 					if err != nil {
-						log.Println(SOURCE, "Error Parsing Fee", r[6])
+						diag.Add(SOURCE, n+1, "fee", l.Get(r, "fee"), "Error Parsing Fee")
 					}
 				}
-				tx.TxID = r[7]
-				tx.SubaddrAccount = r[8]
-				tx.PaymentId = r[9]
+				tx.TxID = l.Get(r, "txid")
+				tx.SubaddrAccount = l.Get(r, "subaddrAccount")
+				tx.PaymentId = l.Get(r, "paymentId")
 				xmr.CsvTXs = append(xmr.CsvTXs, tx)
 			}
 		}
@@ -8,6 +8,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
+	"github.com/fiscafacile/CryptoFiscaFacile/utils"
 	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
 	"github.com/shopspring/decimal"
 )
@@ -22,26 +24,38 @@ type CsvTX struct {
 	Label       string
 }
 
+var CSVFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"Account", "Transaction ID", "Destination Address", "Timestamp", "Value", "Currency", "Transaction Label"}, Positional: true, Date: "Timestamp", DateLayouts: []string{"2006-01-02T15:04Z"}, Amounts: []string{"Value"}},
+}
+
 func (mc *MyCelium) ParseCSV(reader io.Reader) (err error) {
+	const SOURCE = "MyCelium CSV :"
 	csvReader := csv.NewReader(reader)
 	records, err := csvReader.ReadAll()
 	if err == nil {
-		for _, r := range records {
-			if r[0] != "Account" {
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVFormats...)
+				if err != nil {
+					return
+				}
+			}
+			if !l.IsHeader(r) {
 				tx := CsvTX{}
-				tx.Account = r[0]
-				tx.ID = r[1]
-				tx.DestAddress = r[2]
-				tx.Timestamp, err = time.Parse("2006-01-02T15:04Z", r[3])
+				tx.Account = l.Get(r, "Account")
+				tx.ID = l.Get(r, "Transaction ID")
+				tx.DestAddress = l.Get(r, "Destination Address")
+				tx.Timestamp, err = time.Parse("2006-01-02T15:04Z", l.Get(r, "Timestamp"))
 				if err != nil {
-					log.Println("Error Parsing Timestamp : ", r[3])
+					diag.Add(SOURCE, n+1, "Timestamp", l.Get(r, "Timestamp"), "Error Parsing Timestamp")
 				}
-				tx.Value, err = decimal.NewFromString(r[4])
+				tx.Value, err = decimal.NewFromString(l.Get(r, "Value"))
 				if err != nil {
-					log.Println("Error Parsing Value : ", r[4])
+					diag.Add(SOURCE, n+1, "Value", l.Get(r, "Value"), "Error Parsing Value")
 				}
-				tx.Currency = r[5]
-				tx.Label = r[6]
+				tx.Currency = l.Get(r, "Currency")
+				tx.Label = l.Get(r, "Transaction Label")
 				mc.CsvTXs = append(mc.CsvTXs, tx)
 				// Fill TXsByCategory
 				if tx.Value.IsPositive() {
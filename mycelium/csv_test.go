@@ -13,9 +13,19 @@ func Test_CSVParseExemple(t *testing.T) {
 	}{
 		{
 			name:    "ParseCSV",
+			csv:     "Account,Transaction ID,Destination Address,Timestamp,Value,Currency,Transaction Label\nCompte 1,563c9ae8edca798b1d13eb4f167f4a8735385ad9dcec767a1bf0377e43bf3929,16Rp4mkpFY4rgSzX7VFFbmUuJSZymqz83c,2018-11-06T23:08Z,-0.00924295,Bitcoin,",
+			wantErr: false,
+		},
+		{
+			name:    "ParseCSV Headerless",
 			csv:     "Compte 1,563c9ae8edca798b1d13eb4f167f4a8735385ad9dcec767a1bf0377e43bf3929,16Rp4mkpFY4rgSzX7VFFbmUuJSZymqz83c,2018-11-06T23:08Z,-0.00924295,Bitcoin,",
 			wantErr: false,
 		},
+		{
+			name:    "ParseCSV Unknown Layout",
+			csv:     "Account,Transaction,Address,Timestamp,Value,Currency,Label\nCompte 1,563c9ae8edca798b1d13eb4f167f4a8735385ad9dcec767a1bf0377e43bf3929,16Rp4mkpFY4rgSzX7VFFbmUuJSZymqz83c,2018-11-06T23:08Z,-0.00924295,Bitcoin,",
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -3,9 +3,9 @@ package poloniex
 import (
 	"encoding/csv"
 	"io"
-	"log"
 	"time"
 
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
 	"github.com/fiscafacile/CryptoFiscaFacile/source"
 	"github.com/fiscafacile/CryptoFiscaFacile/utils"
 	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
@@ -21,6 +21,10 @@ type csvDepositsTX struct {
 	Status   string
 }
 
+var CSVDepositsFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"Date", "Currency", "Amount", "Address", "Status"}, Positional: true, Date: "Date", DateLayouts: []string{"2006-01-02 15:04:05"}, Amounts: []string{"Amount"}},
+}
+
 func (pl *Poloniex) ParseDepositsCSV(reader io.Reader, account string) (err error) {
 	firstTimeUsed := time.Now()
 	lastTimeUsed := time.Date(2009, time.January, 1, 0, 0, 0, 0, time.UTC)
@@ -28,21 +32,28 @@ func (pl *Poloniex) ParseDepositsCSV(reader io.Reader, account string) (err erro
 	csvReader := csv.NewReader(reader)
 	records, err := csvReader.ReadAll()
 	if err == nil {
-		for _, r := range records {
-			if r[0] != "Date" {
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVDepositsFormats...)
+				if err != nil {
+					return
+				}
+			}
+			if !l.IsHeader(r) {
 				tx := csvDepositsTX{}
-				tx.Date, err = time.Parse("2006-01-02 15:04:05", r[0])
+				tx.Date, err = time.Parse("2006-01-02 15:04:05", l.Get(r, "Date"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Date", r[0])
+					diag.Add(SOURCE, n+1, "Date", l.Get(r, "Date"), "Error Parsing Date")
 				}
 				tx.ID = utils.GetUniqueID(SOURCE + tx.Date.String())
-				tx.Currency = r[1]
-				tx.Amount, err = decimal.NewFromString(r[2])
+				tx.Currency = l.Get(r, "Currency")
+				tx.Amount, err = decimal.NewFromString(l.Get(r, "Amount"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Amount", r[2])
+					diag.Add(SOURCE, n+1, "Amount", l.Get(r, "Amount"), "Error Parsing Amount")
 				}
-				tx.Address = r[3]
-				tx.Status = r[4]
+				tx.Address = l.Get(r, "Address")
+				tx.Status = l.Get(r, "Status")
 				pl.csvDepositsTXs = append(pl.csvDepositsTXs, tx)
 				if tx.Date.Before(firstTimeUsed) {
 					firstTimeUsed = tx.Date
@@ -59,15 +70,7 @@ func (pl *Poloniex) ParseDepositsCSV(reader io.Reader, account string) (err erro
 		}
 	}
 	if _, ok := pl.Sources["Poloniex"]; !ok {
-		pl.Sources["Poloniex"] = source.Source{
-			Crypto:        true,
-			AccountNumber: account,
-			OpeningDate:   firstTimeUsed,
-			ClosingDate:   lastTimeUsed,
-			LegalName:     "Polo Digital Assets Ltd",
-			Address:       "F20, 1st Floor, Eden Plaza,\nEden Island,\nSeychelles",
-			URL:           "https://poloniex.com/",
-		}
+		pl.Sources["Poloniex"] = source.New("Poloniex", account, firstTimeUsed, lastTimeUsed)
 	}
 	return
 }
@@ -3,10 +3,11 @@ package poloniex
 import (
 	"encoding/csv"
 	"io"
-	"log"
 	"time"
 
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
 	"github.com/fiscafacile/CryptoFiscaFacile/source"
+	"github.com/fiscafacile/CryptoFiscaFacile/utils"
 	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
 	"github.com/shopspring/decimal"
 )
@@ -18,6 +19,10 @@ type csvDistributionsTX struct {
 	Wallet   string          // exchange
 }
 
+var CSVDistributionsFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"date", "currency", "amount", "wallet"}, Positional: true, Date: "date", DateLayouts: []string{"2006-01-02"}, Amounts: []string{"amount"}},
+}
+
 func (pl *Poloniex) ParseDistributionsCSV(reader io.Reader, account string) (err error) {
 	firstTimeUsed := time.Now()
 	lastTimeUsed := time.Date(2009, time.January, 1, 0, 0, 0, 0, time.UTC)
@@ -25,19 +30,26 @@ func (pl *Poloniex) ParseDistributionsCSV(reader io.Reader, account string) (err
 	csvReader := csv.NewReader(reader)
 	records, err := csvReader.ReadAll()
 	if err == nil {
-		for _, r := range records {
-			if r[0] != "date" {
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVDistributionsFormats...)
+				if err != nil {
+					return
+				}
+			}
+			if !l.IsHeader(r) {
 				tx := csvDistributionsTX{}
-				tx.Date, err = time.Parse("2006-01-02", r[0])
+				tx.Date, err = time.Parse("2006-01-02", l.Get(r, "date"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Date", r[0])
+					diag.Add(SOURCE, n+1, "date", l.Get(r, "date"), "Error Parsing Date")
 				}
-				tx.Currency = r[1]
-				tx.Amount, err = decimal.NewFromString(r[2])
+				tx.Currency = l.Get(r, "currency")
+				tx.Amount, err = decimal.NewFromString(l.Get(r, "amount"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Amount", r[2])
+					diag.Add(SOURCE, n+1, "amount", l.Get(r, "amount"), "Error Parsing Amount")
 				}
-				tx.Wallet = r[3]
+				tx.Wallet = l.Get(r, "wallet")
 				pl.csvDistributionsTXs = append(pl.csvDistributionsTXs, tx)
 				if tx.Date.Before(firstTimeUsed) {
 					firstTimeUsed = tx.Date
@@ -54,15 +66,7 @@ func (pl *Poloniex) ParseDistributionsCSV(reader io.Reader, account string) (err
 		}
 	}
 	if _, ok := pl.Sources["Poloniex"]; !ok {
-		pl.Sources["Poloniex"] = source.Source{
-			Crypto:        true,
-			AccountNumber: account,
-			OpeningDate:   firstTimeUsed,
-			ClosingDate:   lastTimeUsed,
-			LegalName:     "Polo Digital Assets Ltd",
-			Address:       "F20, 1st Floor, Eden Plaza,\nEden Island,\nSeychelles",
-			URL:           "https://poloniex.com/",
-		}
+		pl.Sources["Poloniex"] = source.New("Poloniex", account, firstTimeUsed, lastTimeUsed)
 	}
 	return
 }
@@ -3,12 +3,13 @@ package poloniex
 import (
 	"encoding/csv"
 	"io"
-	"log"
 	"strings"
 	"time"
 
 	"github.com/fiscafacile/CryptoFiscaFacile/category"
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
 	"github.com/fiscafacile/CryptoFiscaFacile/source"
+	"github.com/fiscafacile/CryptoFiscaFacile/utils"
 	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
 	"github.com/shopspring/decimal"
 )
@@ -31,6 +32,10 @@ type csvTradesTX struct {
 	FeeTotal          decimal.Decimal
 }
 
+var CSVTradesFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"Date", "Market", "Category", "Type", "Price", "Amount", "Total", "Fee", "Order Number", "Base Total Less Fee", "Quote Total Less Fee", "Fee Currency", "Fee Total"}, Positional: true, Date: "Date", DateLayouts: []string{"2006-01-02 15:04:05"}, Amounts: []string{"Price", "Amount", "Total"}},
+}
+
 func (pl *Poloniex) ParseTradesCSV(reader io.Reader, cat category.Category, account string) (err error) {
 	firstTimeUsed := time.Now()
 	lastTimeUsed := time.Date(2009, time.January, 1, 0, 0, 0, 0, time.UTC)
@@ -39,45 +44,52 @@ func (pl *Poloniex) ParseTradesCSV(reader io.Reader, cat category.Category, acco
 	records, err := csvReader.ReadAll()
 	if err == nil {
 		alreadyAsked := []string{}
-		for _, r := range records {
-			if r[0] != "Date" {
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVTradesFormats...)
+				if err != nil {
+					return
+				}
+			}
+			if !l.IsHeader(r) {
 				tx := csvTradesTX{}
-				tx.Date, err = time.Parse("2006-01-02 15:04:05", r[0])
+				tx.Date, err = time.Parse("2006-01-02 15:04:05", l.Get(r, "Date"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Date", r[0])
+					diag.Add(SOURCE, n+1, "Date", l.Get(r, "Date"), "Error Parsing Date")
 				}
-				tx.Market = r[1]
-				curr := strings.Split(r[1], "/")
+				tx.Market = l.Get(r, "Market")
+				curr := strings.Split(l.Get(r, "Market"), "/")
 				tx.FirstCurrency = curr[0]
 				tx.SecondCurrency = curr[1]
-				tx.Category = r[2]
-				tx.Type = r[3]
-				tx.Price, err = decimal.NewFromString(r[4])
+				tx.Category = l.Get(r, "Category")
+				tx.Type = l.Get(r, "Type")
+				tx.Price, err = decimal.NewFromString(l.Get(r, "Price"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Price", r[4])
+					diag.Add(SOURCE, n+1, "Price", l.Get(r, "Price"), "Error Parsing Price")
 				}
-				tx.Amount, err = decimal.NewFromString(r[5])
+				tx.Amount, err = decimal.NewFromString(l.Get(r, "Amount"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Amount", r[5])
+					diag.Add(SOURCE, n+1, "Amount", l.Get(r, "Amount"), "Error Parsing Amount")
 				}
-				tx.Total, err = decimal.NewFromString(r[6])
+				tx.Total, err = decimal.NewFromString(l.Get(r, "Total"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Total", r[6])
+					diag.Add(SOURCE, n+1, "Total", l.Get(r, "Total"), "Error Parsing Total")
 				}
-				tx.Fee = r[7]
-				tx.OrderNumber = r[8]
-				tx.BaseTotalLessFee, err = decimal.NewFromString(r[9])
+				tx.Fee = l.Get(r, "Fee")
+				tx.OrderNumber = l.Get(r, "Order Number")
+				tx.BaseTotalLessFee, err = decimal.NewFromString(l.Get(r, "Base Total Less Fee"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing BaseTotalLessFee", r[9])
+					diag.Add(SOURCE, n+1, "Base Total Less Fee", l.Get(r, "Base Total Less Fee"), "Error Parsing BaseTotalLessFee")
 				}
-				tx.QuoteTotalLessFee, err = decimal.NewFromString(r[10])
+				tx.QuoteTotalLessFee, err = decimal.NewFromString(l.Get(r, "Quote Total Less Fee"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing QuoteTotalLessFee", r[10])
+					diag.Add(SOURCE, n+1, "Quote Total Less Fee", l.Get(r, "Quote Total Less Fee"), "Error Parsing QuoteTotalLessFee")
 				}
-				tx.FeeCurrency = r[11]
-				tx.FeeTotal, err = decimal.NewFromString(r[12])
+				tx.FeeCurrency = l.Get(r, "Fee Currency")
+				tx.FeeTotal, err = decimal.NewFromString(l.Get(r, "Fee Total"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing FeeTotal", r[12])
+					diag.Add(SOURCE, n+1, "Fee Total", l.Get(r, "Fee Total"), "Error Parsing FeeTotal")
 				}
 				pl.csvTradesTXs = append(pl.csvTradesTXs, tx)
 				if tx.Date.Before(firstTimeUsed) {
@@ -110,15 +122,7 @@ func (pl *Poloniex) ParseTradesCSV(reader io.Reader, cat category.Category, acco
 		}
 	}
 	if _, ok := pl.Sources["Poloniex"]; !ok {
-		pl.Sources["Poloniex"] = source.Source{
-			Crypto:        true,
-			AccountNumber: account,
-			OpeningDate:   firstTimeUsed,
-			ClosingDate:   lastTimeUsed,
-			LegalName:     "Polo Digital Assets Ltd",
-			Address:       "F20, 1st Floor, Eden Plaza,\nEden Island,\nSeychelles",
-			URL:           "https://poloniex.com/",
-		}
+		pl.Sources["Poloniex"] = source.New("Poloniex", account, firstTimeUsed, lastTimeUsed)
 	}
 	return
 }
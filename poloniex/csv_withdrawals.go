@@ -5,11 +5,12 @@ import (
 	"encoding/csv"
 	"encoding/hex"
 	"io"
-	"log"
 	"time"
 
 	"github.com/fiscafacile/CryptoFiscaFacile/category"
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
 	"github.com/fiscafacile/CryptoFiscaFacile/source"
+	"github.com/fiscafacile/CryptoFiscaFacile/utils"
 	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
 	"github.com/shopspring/decimal"
 )
@@ -25,6 +26,10 @@ type csvWithdrawalsTX struct {
 	Status      string
 }
 
+var CSVWithdrawalsFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"Date", "Currency", "Amount", "Fee Deducted", "Amount - Fee", "Address", "Status"}, Positional: true, Date: "Date", DateLayouts: []string{"2006-01-02 15:04:05"}, Amounts: []string{"Amount", "Fee Deducted", "Amount - Fee"}},
+}
+
 func (pl *Poloniex) ParseWithdrawalsCSV(reader io.Reader, cat category.Category, account string) (err error) {
 	firstTimeUsed := time.Now()
 	lastTimeUsed := time.Date(2009, time.January, 1, 0, 0, 0, 0, time.UTC)
@@ -32,28 +37,35 @@ func (pl *Poloniex) ParseWithdrawalsCSV(reader io.Reader, cat category.Category,
 	csvReader := csv.NewReader(reader)
 	records, err := csvReader.ReadAll()
 	if err == nil {
-		for _, r := range records {
-			if r[0] != "Date" {
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVWithdrawalsFormats...)
+				if err != nil {
+					return
+				}
+			}
+			if !l.IsHeader(r) {
 				tx := csvWithdrawalsTX{}
-				tx.Date, err = time.Parse("2006-01-02 15:04:05", r[0])
+				tx.Date, err = time.Parse("2006-01-02 15:04:05", l.Get(r, "Date"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Date", r[0])
+					diag.Add(SOURCE, n+1, "Date", l.Get(r, "Date"), "Error Parsing Date")
 				}
-				tx.Currency = r[1]
-				tx.Amount, err = decimal.NewFromString(r[2])
+				tx.Currency = l.Get(r, "Currency")
+				tx.Amount, err = decimal.NewFromString(l.Get(r, "Amount"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Amount", r[2])
+					diag.Add(SOURCE, n+1, "Amount", l.Get(r, "Amount"), "Error Parsing Amount")
 				}
-				tx.FeeDeducted, err = decimal.NewFromString(r[3])
+				tx.FeeDeducted, err = decimal.NewFromString(l.Get(r, "Fee Deducted"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing FeeDeducted", r[3])
+					diag.Add(SOURCE, n+1, "Fee Deducted", l.Get(r, "Fee Deducted"), "Error Parsing FeeDeducted")
 				}
-				tx.AmountFee, err = decimal.NewFromString(r[4])
+				tx.AmountFee, err = decimal.NewFromString(l.Get(r, "Amount - Fee"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing AmountFee", r[4])
+					diag.Add(SOURCE, n+1, "Amount - Fee", l.Get(r, "Amount - Fee"), "Error Parsing AmountFee")
 				}
-				tx.Address = r[5]
-				tx.Status = r[6]
+				tx.Address = l.Get(r, "Address")
+				tx.Status = l.Get(r, "Status")
 				hash := sha256.Sum256([]byte(SOURCE + tx.Date.String()))
 				tx.ID = hex.EncodeToString(hash[:])
 				pl.csvWithdrawalsTXs = append(pl.csvWithdrawalsTXs, tx)
@@ -79,15 +91,7 @@ func (pl *Poloniex) ParseWithdrawalsCSV(reader io.Reader, cat category.Category,
 		}
 	}
 	if _, ok := pl.Sources["Poloniex"]; !ok {
-		pl.Sources["Poloniex"] = source.Source{
-			Crypto:        true,
-			AccountNumber: account,
-			OpeningDate:   firstTimeUsed,
-			ClosingDate:   lastTimeUsed,
-			LegalName:     "Polo Digital Assets Ltd",
-			Address:       "F20, 1st Floor, Eden Plaza,\nEden Island,\nSeychelles",
-			URL:           "https://poloniex.com/",
-		}
+		pl.Sources["Poloniex"] = source.New("Poloniex", account, firstTimeUsed, lastTimeUsed)
 	}
 	return
 }
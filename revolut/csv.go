@@ -5,10 +5,10 @@ import (
 	"encoding/csv"
 	"io"
 	"io/ioutil"
-	"log"
 	"strings"
 	"time"
 
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
 	"github.com/fiscafacile/CryptoFiscaFacile/source"
 	"github.com/fiscafacile/CryptoFiscaFacile/utils"
 	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
@@ -29,6 +29,13 @@ type CsvTX struct {
 	Notes       string
 }
 
+// the crypto of the account is part of the column names
+func CSVFormats(curr string) []utils.CSVFormat {
+	return []utils.CSVFormat{
+		{Version: "original", Columns: []string{"Completed Date", "Description", "Paid Out (" + curr + ")", "Paid In (" + curr + ")", "Exchange Out", "Exchange In", "Balance (" + curr + ")", "Category", "Notes"}},
+	}
+}
+
 func (revo *Revolut) ParseCSV(reader io.Reader, account string) (err error) {
 	firstTimeUsed := time.Now()
 	lastTimeUsed := time.Date(2009, time.January, 1, 0, 0, 0, 0, time.UTC)
@@ -41,55 +48,63 @@ func (revo *Revolut) ParseCSV(reader io.Reader, account string) (err error) {
 	if err == nil {
 		alreadyAsked := []string{}
 		var curr string
-		for _, r := range records {
-			if r[0] == "Completed Date" {
-				curr = strings.Split(r[2], "(")[1]
-				curr = strings.Split(curr, ")")[0]
-			} else {
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				if len(r) > 2 && strings.Contains(r[2], "(") {
+					curr = strings.Split(r[2], "(")[1]
+					curr = strings.Split(curr, ")")[0]
+				}
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVFormats(curr)...)
+				if err != nil {
+					return
+				}
+			}
+			if !l.IsHeader(r) {
 				tx := CsvTX{}
-				tx.Timestamp, err = time.Parse("2 Jan 2006", f2e(r[0]))
+				tx.Timestamp, err = time.Parse("2 Jan 2006", f2e(l.Get(r, "Completed Date")))
 				if err != nil {
-					tx.Timestamp, err = time.Parse("Jan 2,2006", r[0])
+					tx.Timestamp, err = time.Parse("Jan 2,2006", l.Get(r, "Completed Date"))
 					if err != nil {
-						log.Println(SOURCE, "Error Parsing Timestamp :", r[0])
+						diag.Add(SOURCE, n+1, "Completed Date", l.Get(r, "Completed Date"), "Error Parsing Timestamp")
 					}
 				}
 				tx.ID = utils.GetUniqueID(SOURCE + tx.Timestamp.String())
-				tx.Description = strings.ReplaceAll(r[1], "\u00a0", "")
+				tx.Description = strings.ReplaceAll(l.Get(r, "Description"), "\u00a0", "")
 				fields := strings.Split(tx.Description, " ")
 				for i := 0; i < len(fields); i++ {
 					if strings.Contains(fields[i], "€") {
 						tx.Rate, err = decimal.NewFromString(strings.ReplaceAll(fields[i], "€", ""))
 						if err != nil {
-							log.Println(SOURCE, "Error Parsing Rate :", strings.ReplaceAll(fields[i], "€", ""))
+							diag.Add(SOURCE, n+1, "Description", strings.ReplaceAll(fields[i], "€", ""), "Error Parsing Rate")
 						}
 						break
 					}
 				}
-				if r[2] != "" {
-					tx.PaidOut, err = decimal.NewFromString(r[2])
+				if l.Get(r, "Paid Out ("+curr+")") != "" {
+					tx.PaidOut, err = decimal.NewFromString(l.Get(r, "Paid Out ("+curr+")"))
 					if err != nil {
-						log.Println(SOURCE, "Error Parsing PaidOut :", r[2])
+						diag.Add(SOURCE, n+1, "Paid Out ("+curr+")", l.Get(r, "Paid Out ("+curr+")"), "Error Parsing PaidOut")
 					}
 				} else {
-					tx.PaidIn, err = decimal.NewFromString(r[3])
+					tx.PaidIn, err = decimal.NewFromString(l.Get(r, "Paid In ("+curr+")"))
 					if err != nil {
-						log.Println(SOURCE, "Error Parsing PaidIn :", r[3])
+						diag.Add(SOURCE, n+1, "Paid In ("+curr+")", l.Get(r, "Paid In ("+curr+")"), "Error Parsing PaidIn")
 					}
 				}
-				s := strings.Split(r[4], " ")
+				s := strings.Split(l.Get(r, "Exchange Out"), " ")
 				tx.ExchangeOut.Code = s[0]
 				tx.ExchangeOut.Amount, err = decimal.NewFromString(s[1])
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing ExchangeOut.Amount :", s[1])
+					diag.Add(SOURCE, n+1, "Exchange Out", s[1], "Error Parsing ExchangeOut.Amount")
 				}
-				tx.ExchangeIn = r[5]
-				tx.Balance, err = decimal.NewFromString(r[6])
+				tx.ExchangeIn = l.Get(r, "Exchange In")
+				tx.Balance, err = decimal.NewFromString(l.Get(r, "Balance ("+curr+")"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Balance :", r[6])
+					diag.Add(SOURCE, n+1, "Balance ("+curr+")", l.Get(r, "Balance ("+curr+")"), "Error Parsing Balance")
 				}
-				tx.Category = r[7]
-				tx.Notes = r[8]
+				tx.Category = l.Get(r, "Category")
+				tx.Notes = l.Get(r, "Notes")
 				revo.CsvTXs = append(revo.CsvTXs, tx)
 				if tx.Timestamp.Before(firstTimeUsed) {
 					firstTimeUsed = tx.Timestamp
@@ -116,15 +131,7 @@ func (revo *Revolut) ParseCSV(reader io.Reader, account string) (err error) {
 			}
 		}
 	}
-	revo.Sources["Revolut"] = source.Source{
-		Crypto:        true,
-		AccountNumber: account,
-		OpeningDate:   firstTimeUsed,
-		ClosingDate:   lastTimeUsed,
-		LegalName:     "Revolut Limited",
-		Address:       "4th Floor, 7 Westferry Circus\nE14 4HD Londres, Royaume-Uni",
-		URL:           "https://www.revolut.com",
-	}
+	revo.Sources["Revolut"] = source.New("Revolut", account, firstTimeUsed, lastTimeUsed)
 	return
 }
 
@@ -25,7 +25,7 @@ func Test_CSVParseExemple(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			revo := New()
-			err := revo.ParseCSV(strings.NewReader(tt.csv))
+			err := revo.ParseCSV(strings.NewReader(tt.csv), "")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Revolut.ParseCSV() error = %v, wantErr %v", err, tt.wantErr)
 			}
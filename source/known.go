@@ -0,0 +1,158 @@
+package source
+
+import (
+	"strings"
+	"time"
+)
+
+// Known gives the legal details of the supported platforms, keyed by the name used in Sources and Locations
+var Known = map[string]Source{
+	"Binance": {
+		Crypto:    true,
+		LegalName: "Binance Europe Services Limited",
+		Address:   "LEVEL G (OFFICE 1/1235), QUANTUM HOUSE,75 ABATE RIGORD STREET, TA' XBIEXXBX 1120\nMalta",
+		URL:       "https://www.binance.com/fr",
+	},
+	"Bitfinex": {
+		Crypto:    true,
+		LegalName: "Bitfinex",
+		Address:   "1308 Bank of America Tower, 13/F\n12 Harcourt Road, Central\nHong Kong",
+		URL:       "https://www.bitfinex.com",
+	},
+	"Bitstamp": {
+		Crypto:    true,
+		LegalName: "Bitstamp Ltd",
+		Address:   "5 New Street Square,\nLondon EC4A 3TW,\nRoyaume-Uni",
+		URL:       "https://bitstamp.com",
+	},
+	"Bittrex": {
+		Crypto:    true,
+		LegalName: "Bittrex International GmbH",
+		Address:   "Dr. Grass-Strasse 12, 9490 Vaduz,\nPrincipality of Liechtenstein",
+		URL:       "https://global.bittrex.com",
+	},
+	"CdC App": {
+		Crypto:    true,
+		LegalName: "MCO Malta DAX Limited",
+		Address:   "Level 7, Spinola Park, Triq Mikiel Ang Borg,\nSt Julian's SPK 1000,\nMalte",
+		URL:       "https://crypto.com/app",
+	},
+	"CdC Exchange": {
+		Crypto:    true,
+		LegalName: "MCO Malta DAX Limited",
+		Address:   "Level 7, Spinola Park, Triq Mikiel Ang Borg,\nSt Julian's SPK 1000,\nMalte",
+		URL:       "https://crypto.com/exchange",
+	},
+	"CdC MCO Card GB": {
+		Crypto:    false,
+		LegalName: "MCO Malta DAX Limited The Currency Cloud)",
+		Address:   "12 Steward Street, The Steward Building, London, E1 6FQ, Royaume-Uni",
+		URL:       "https://crypto.com/cards",
+	},
+	"CdC MCO Card LT": {
+		Crypto:    false,
+		LegalName: "MCO Malta DAX Limited (Transactive Systems UAB)",
+		Address:   "Jogailos St 9, Vilnius, 01103, Lithuania",
+		URL:       "https://crypto.com/cards",
+	},
+	"Coinbase": {
+		Crypto:    true,
+		LegalName: "Coinbase Europe Limited",
+		Address:   "70 Sir John Rogerson’s Quay,\nDublin D02 R296\nIrlande",
+		URL:       "https://www.coinbase.com",
+	},
+	"CoinbasePro": {
+		Crypto:    true,
+		LegalName: "Coinbase Europe Limited",
+		Address:   "70 Sir John Rogerson’s Quay,\nDublin D02 R296\nIrlande",
+		URL:       "https://pro.coinbase.com",
+	},
+	"HitBTC": {
+		Crypto:    true,
+		LegalName: "Hit Tech Solutions Development Ltd.",
+		Address:   "Suite 15, Oliaji Trade Centre, Francis Rachel Street,\nVictoria, Mahe,\nSeychelles",
+		URL:       "https://hitbtc.com",
+	},
+	"Kraken": {
+		Crypto:    true,
+		LegalName: "Payward Ltd.",
+		Address:   "6th Floor,\nOne London Wall,\nLondon, EC2Y 5EB,\nRoyaume-Uni",
+		URL:       "https://www.kraken.com",
+	},
+	"Local Bitcoin": {
+		Crypto:    true,
+		LegalName: "LocalBitcoins Oy",
+		Address:   "Porkkalankatu 24\n00180 Helsinki\nFinland",
+		URL:       "https://localbitcoins.com/fr",
+	},
+	"Poloniex": {
+		Crypto:    true,
+		LegalName: "Polo Digital Assets Ltd",
+		Address:   "F20, 1st Floor, Eden Plaza,\nEden Island,\nSeychelles",
+		URL:       "https://poloniex.com/",
+	},
+	"Revolut": {
+		Crypto:    true,
+		LegalName: "Revolut Limited",
+		Address:   "4th Floor, 7 Westferry Circus\nE14 4HD Londres, Royaume-Uni",
+		URL:       "https://www.revolut.com",
+	},
+	"Uphold": {
+		Crypto:    true,
+		LegalName: "Uphold Europe Limited",
+		Address:   "Suite A, 6 Honduras Street, London, England, EC1Y 0TH\nRoyaume-Uni",
+		URL:       "https://uphold.com",
+	},
+}
+
+var aliases = map[string]string{
+	"cryptocom":         "CdC App",
+	"cryptocomapp":      "CdC App",
+	"cryptocomexchange": "CdC Exchange",
+	"coinbasepro":       "CoinbasePro",
+	"gdax":              "CoinbasePro",
+	"localbitcoins":     "Local Bitcoin",
+	"localbitcoin":      "Local Bitcoin",
+}
+
+// Lookup finds a Known platform from the name given by another tool, ignoring case, spaces and punctuation
+func Lookup(name string) (string, bool) {
+	simplify := strings.NewReplacer(" ", "", ".", "", "-", "", "_", "")
+	simple := strings.ToLower(simplify.Replace(name))
+	if k, ok := aliases[simple]; ok {
+		return k, true
+	}
+	for k := range Known {
+		if strings.ToLower(simplify.Replace(k)) == simple {
+			return k, true
+		}
+	}
+	return name, false
+}
+
+// New returns the Known platform name with the account details
+func New(name, account string, opening, closing time.Time) Source {
+	s := Known[name]
+	s.AccountNumber = account
+	s.OpeningDate = opening
+	s.ClosingDate = closing
+	return s
+}
+
+// AddUsage creates or widens the Source of a Known platform to cover date
+func (ss Sources) AddUsage(name, account string, date time.Time) {
+	if _, ok := Known[name]; !ok {
+		return
+	}
+	if s, ok := ss[name]; ok {
+		if date.Before(s.OpeningDate) {
+			s.OpeningDate = date
+		}
+		if date.After(s.ClosingDate) {
+			s.ClosingDate = date
+		}
+		ss[name] = s
+		return
+	}
+	ss[name] = New(name, account, date, date)
+}
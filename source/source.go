@@ -22,8 +22,17 @@ type Source struct {
 
 type Sources map[string]Source
 
+// Add merges srcs, the details of srcs prevail but the dates cover both usages like AddUsage
 func (ss Sources) Add(srcs Sources) {
 	for k, v := range srcs {
+		if s, ok := ss[k]; ok {
+			if !s.OpeningDate.IsZero() && (v.OpeningDate.IsZero() || s.OpeningDate.Before(v.OpeningDate)) {
+				v.OpeningDate = s.OpeningDate
+			}
+			if s.ClosingDate.After(v.ClosingDate) {
+				v.ClosingDate = s.ClosingDate
+			}
+		}
 		ss[k] = v
 	}
 }
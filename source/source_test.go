@@ -0,0 +1,23 @@
+package source
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSources_Add(t *testing.T) {
+	koinly := make(Sources)
+	koinly.AddUsage("Binance", "", time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC))
+	koinly.AddUsage("Binance", "", time.Date(2019, time.June, 1, 0, 0, 0, 0, time.UTC))
+	native := Sources{"Binance": New("Binance", "123", time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC))}
+	sources := make(Sources)
+	sources.Add(koinly)
+	sources.Add(native)
+	b := sources["Binance"]
+	if b.AccountNumber != "123" {
+		t.Errorf("Add() AccountNumber = %v, want native details", b.AccountNumber)
+	}
+	if !b.OpeningDate.Equal(time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC)) || !b.ClosingDate.Equal(time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Add() dates = %v %v, want 2018-01-01 to 2021-03-01", b.OpeningDate, b.ClosingDate)
+	}
+}
@@ -3,11 +3,12 @@ package uphold
 import (
 	"encoding/csv"
 	"io"
-	"log"
 	"time"
 
 	"github.com/fiscafacile/CryptoFiscaFacile/category"
+	"github.com/fiscafacile/CryptoFiscaFacile/diag"
 	"github.com/fiscafacile/CryptoFiscaFacile/source"
+	"github.com/fiscafacile/CryptoFiscaFacile/utils"
 	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
 	"github.com/shopspring/decimal"
 )
@@ -27,6 +28,10 @@ type CsvTX struct {
 	Type                string
 }
 
+var CSVFormats = []utils.CSVFormat{
+	{Version: "original", Columns: []string{"Date", "Destination", "Destination Amount", "Destination Currency", "Fee Amount", "Fee Currency", "Id", "Origin", "Origin Amount", "Origin Currency", "Status", "Type"}, Positional: true, Date: "Date", DateLayouts: []string{"Mon Jan 02 2006 15:04:05 GMT-0700"}, Amounts: []string{"Destination Amount", "Fee Amount", "Origin Amount"}},
+}
+
 func (uh *Uphold) ParseCSV(reader io.Reader, cat category.Category, account string) (err error) {
 	firstTimeUsed := time.Now()
 	lastTimeUsed := time.Date(2009, time.January, 1, 0, 0, 0, 0, time.UTC)
@@ -35,35 +40,42 @@ func (uh *Uphold) ParseCSV(reader io.Reader, cat category.Category, account stri
 	records, err := csvReader.ReadAll()
 	if err == nil {
 		alreadyAsked := []string{}
-		for _, r := range records {
-			if r[0] != "Date" {
+		var l utils.CSVLayout
+		for n, r := range records {
+			if n == 0 {
+				l, err = utils.DetectCSVLayout(SOURCE, r, CSVFormats...)
+				if err != nil {
+					return
+				}
+			}
+			if !l.IsHeader(r) {
 				tx := CsvTX{}
-				tx.Date, err = time.Parse("Mon Jan 02 2006 15:04:05 GMT-0700", r[0])
+				tx.Date, err = time.Parse("Mon Jan 02 2006 15:04:05 GMT-0700", l.Get(r, "Date"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing Date :", r[0])
+					diag.Add(SOURCE, n+1, "Date", l.Get(r, "Date"), "Error Parsing Date")
 				}
-				tx.Destination = r[1]
-				tx.DestinationAmount, err = decimal.NewFromString(r[2])
+				tx.Destination = l.Get(r, "Destination")
+				tx.DestinationAmount, err = decimal.NewFromString(l.Get(r, "Destination Amount"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing DestinationAmount :", r[2])
+					diag.Add(SOURCE, n+1, "Destination Amount", l.Get(r, "Destination Amount"), "Error Parsing DestinationAmount")
 				}
-				tx.DestinationCurrency = r[3]
-				if r[4] != "" {
-					tx.FeeAmount, err = decimal.NewFromString(r[4])
+				tx.DestinationCurrency = l.Get(r, "Destination Currency")
+				if l.Get(r, "Fee Amount") != "" {
+					tx.FeeAmount, err = decimal.NewFromString(l.Get(r, "Fee Amount"))
 					if err != nil {
-						log.Println(SOURCE, "Error Parsing FeeAmount :", r[4])
+						diag.Add(SOURCE, n+1, "Fee Amount", l.Get(r, "Fee Amount"), "Error Parsing FeeAmount")
 					}
 				}
-				tx.FeeCurrency = r[5]
-				tx.ID = r[6]
-				tx.Origin = r[7]
-				tx.OriginAmount, err = decimal.NewFromString(r[8])
+				tx.FeeCurrency = l.Get(r, "Fee Currency")
+				tx.ID = l.Get(r, "Id")
+				tx.Origin = l.Get(r, "Origin")
+				tx.OriginAmount, err = decimal.NewFromString(l.Get(r, "Origin Amount"))
 				if err != nil {
-					log.Println(SOURCE, "Error Parsing OriginAmount :", r[8])
+					diag.Add(SOURCE, n+1, "Origin Amount", l.Get(r, "Origin Amount"), "Error Parsing OriginAmount")
 				}
-				tx.OriginCurrency = r[9]
-				tx.Status = r[10]
-				tx.Type = r[11]
+				tx.OriginCurrency = l.Get(r, "Origin Currency")
+				tx.Status = l.Get(r, "Status")
+				tx.Type = l.Get(r, "Type")
 				uh.CsvTXs = append(uh.CsvTXs, tx)
 				if tx.Date.Before(firstTimeUsed) {
 					firstTimeUsed = tx.Date
@@ -116,14 +128,6 @@ func (uh *Uphold) ParseCSV(reader io.Reader, cat category.Category, account stri
 			}
 		}
 	}
-	uh.Sources["Uphold"] = source.Source{
-		Crypto:        true,
-		AccountNumber: account,
-		OpeningDate:   firstTimeUsed,
-		ClosingDate:   lastTimeUsed,
-		LegalName:     "Uphold Europe Limited",
-		Address:       "Suite A, 6 Honduras Street, London, England, EC1Y 0TH\nRoyaume-Uni",
-		URL:           "https://uphold.com",
-	}
+	uh.Sources["Uphold"] = source.New("Uphold", account, firstTimeUsed, lastTimeUsed)
 	return
 }
@@ -1,6 +1,7 @@
 package uphold
 
 import (
+	"github.com/fiscafacile/CryptoFiscaFacile/category"
 	"strings"
 	"testing"
 )
@@ -25,7 +26,7 @@ func Test_CSVParseExemple(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			uh := New()
-			err := uh.ParseCSV(strings.NewReader(tt.csv))
+			err := uh.ParseCSV(strings.NewReader(tt.csv), *category.New(), "")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Uphold.ParseCSV() error = %v, wantErr %v", err, tt.wantErr)
 			}
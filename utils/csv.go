@@ -0,0 +1,161 @@
+package utils
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type CSVFormat struct {
+	Version string
+	Columns []string
+	// Positional is the layout of the exports without header line, used when the first line is data :
+	// its Date column parses with one of DateLayouts and its Amounts columns are empty or decimals
+	Positional  bool
+	Date        string
+	DateLayouts []string
+	Amounts     []string
+}
+
+type CSVLayout struct {
+	Version string
+	first   string
+	index   map[string]int
+}
+
+func normalizeHeader(h string) string {
+	return strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))
+}
+
+// DetectCSVLayout maps the columns of header by name and picks the most specific format that they satisfy,
+// a repeated column name is available as "Name#2", "Name#3"...
+// When header has no known column name and parses as data, it is the first line of a file using the Positional format,
+// which IsHeader then tells apart.
+func DetectCSVLayout(src string, header []string, formats ...CSVFormat) (l CSVLayout, err error) {
+	l.index = make(map[string]int)
+	for i, h := range header {
+		name := normalizeHeader(h)
+		if i == 0 {
+			l.first = name
+		}
+		key := name
+		for n := 2; ; n++ {
+			if _, ok := l.index[key]; !ok {
+				break
+			}
+			key = name + "#" + strconv.Itoa(n)
+		}
+		l.index[key] = i
+	}
+	best := -1
+	for i, f := range formats {
+		match := true
+		for _, c := range f.Columns {
+			if _, ok := l.index[c]; !ok {
+				match = false
+				break
+			}
+		}
+		if match && (best < 0 || len(f.Columns) > len(formats[best].Columns)) {
+			best = i
+		}
+	}
+	if best < 0 {
+		if p, ok := positionalLayout(header, formats); ok {
+			return p, nil
+		}
+		var expected []string
+		for _, f := range formats {
+			expected = append(expected, f.Version+" ("+strings.Join(f.Columns, ",")+")")
+		}
+		return l, errors.New(src + " Unknown CSV layout " + strings.Join(header, ",") + ", expected " + strings.Join(expected, " or "))
+	}
+	l.Version = formats[best].Version
+	return
+}
+
+func positionalLayout(row []string, formats []CSVFormat) (l CSVLayout, ok bool) {
+	for _, f := range formats {
+		for _, c := range f.Columns {
+			if i := strings.LastIndex(c, "#"); i > 0 {
+				c = c[:i]
+			}
+			for _, h := range row {
+				if normalizeHeader(h) == c {
+					return l, false
+				}
+			}
+		}
+	}
+	for _, f := range formats {
+		if f.Positional && len(row) >= len(f.Columns) && f.isData(row) {
+			l.Version = f.Version
+			l.first = f.Columns[0]
+			l.index = make(map[string]int)
+			for i, c := range f.Columns {
+				l.index[c] = i
+			}
+			return l, true
+		}
+	}
+	return l, false
+}
+
+func (f CSVFormat) isData(row []string) bool {
+	for i, c := range f.Columns {
+		if c == f.Date {
+			parsed := false
+			for _, layout := range f.DateLayouts {
+				if _, err := time.Parse(layout, row[i]); err == nil {
+					parsed = true
+					break
+				}
+			}
+			if !parsed {
+				return false
+			}
+		}
+		for _, a := range f.Amounts {
+			if c == a && row[i] != "" {
+				if _, err := decimal.NewFromString(row[i]); err != nil {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// Get returns the value of the named column, or an empty string if the layout does not have it
+func (l CSVLayout) Get(r []string, name string) string {
+	if i, ok := l.index[name]; ok && i < len(r) {
+		return r[i]
+	}
+	return ""
+}
+
+func (l CSVLayout) Has(name string) bool {
+	_, ok := l.index[name]
+	return ok
+}
+
+// Alias makes a column renamed between versions available under name, from the first of others in the header
+func (l CSVLayout) Alias(name string, others ...string) {
+	if _, ok := l.index[name]; ok {
+		return
+	}
+	for _, o := range others {
+		if i, ok := l.index[o]; ok {
+			l.index[name] = i
+			return
+		}
+	}
+}
+
+// IsHeader detects header lines repeated when several exports are concatenated
+func (l CSVLayout) IsHeader(r []string) bool {
+	return len(r) > 0 && normalizeHeader(r[0]) == l.first
+}
@@ -0,0 +1,138 @@
+package utils_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/binance"
+	"github.com/fiscafacile/CryptoFiscaFacile/bitfinex"
+	"github.com/fiscafacile/CryptoFiscaFacile/bittrex"
+	"github.com/fiscafacile/CryptoFiscaFacile/category"
+	"github.com/fiscafacile/CryptoFiscaFacile/cryptocom"
+	"github.com/fiscafacile/CryptoFiscaFacile/kraken"
+	"github.com/fiscafacile/CryptoFiscaFacile/ledgerlive"
+	"github.com/fiscafacile/CryptoFiscaFacile/localbitcoin"
+	"github.com/fiscafacile/CryptoFiscaFacile/manual"
+	"github.com/fiscafacile/CryptoFiscaFacile/uphold"
+	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
+)
+
+func Test_CSVHeaderless(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		line   string
+		parse  func(csv string) (wallet.TXsByCategory, error)
+	}{
+		{
+			name:   "Binance",
+			header: "UTC_Time,Account,Operation,Coin,Change,Remark",
+			line:   "2020-04-13 09:33:17,Spot,Withdraw,ETH,-0.75597933,Withdraw fee is included",
+			parse: func(csv string) (wallet.TXsByCategory, error) {
+				b := binance.New()
+				err := b.ParseCSV(strings.NewReader(csv), "")
+				return b.TXsByCategory, err
+			},
+		},
+		{
+			name:   "Bitfinex",
+			header: "#,DESCRIPTION,CURRENCY,AMOUNT,BALANCE,DATE,WALLET",
+			line:   "2809474008,Crypto Withdrawal fee on wallet exchange,BTC,-0.0004,0,01-05-20 18:41:57,exchange",
+			parse: func(csv string) (wallet.TXsByCategory, error) {
+				bf := bitfinex.New()
+				err := bf.ParseCSV(strings.NewReader(csv), "")
+				return bf.TXsByCategory, err
+			},
+		},
+		{
+			name:   "Bittrex",
+			header: "Uuid,Exchange,TimeStamp,OrderType,Limit,Quantity,QuantityRemaining,Commission,Price,PricePerUnit,IsConditional,Condition,ConditionTarget,ImmediateOrCancel,Closed,TimeInForceTypeId,TimeInForce",
+			line:   "40a1adf3-e43d-4e34-8bc6-5d1f8g2c3z6d,BTC-GBYTE,12/22/2017 9:10:21 AM,LIMIT_BUY,0.03700010,6.32828762,0.00000000,0.00058503,0.23401959,0.03697992,False,,0.00000000,False,12/22/2017 9:10:23 AM,0,",
+			parse: func(csv string) (wallet.TXsByCategory, error) {
+				btrx := bittrex.New()
+				err := btrx.ParseCSV(strings.NewReader(csv), *category.New(), "")
+				return btrx.TXsByCategory, err
+			},
+		},
+		{
+			name:   "CryptoCom App",
+			header: "Timestamp (UTC),Transaction Description,Currency,Amount,To Currency,To Amount,Native Currency,Native Amount,Native Amount (in USD),Transaction Kind",
+			line:   "2020-12-31 15:43:19,Card Cashback,CRO,26.96195063,,,EUR,1.27,1.5174771149,referral_card_cashback",
+			parse: func(csv string) (wallet.TXsByCategory, error) {
+				cdc := cryptocom.New()
+				err := cdc.ParseCSVAppCrypto(strings.NewReader(csv), *category.New(), "")
+				return cdc.TXsByCategory, err
+			},
+		},
+		{
+			name:   "Kraken",
+			header: "txid,refid,time,type,subtype,aclass,asset,amount,fee,balance",
+			line:   "LYXXX-XXXXXX-XXXL5X,QCXXXXX-PNXXXX-PBXXXX,2018-01-05 11:36:20,deposit,,currency,ZEUR,10.0000,0.0000,10.0000",
+			parse: func(csv string) (wallet.TXsByCategory, error) {
+				kr := kraken.New()
+				err := kr.ParseCSV(strings.NewReader(csv), *category.New(), "")
+				return kr.TXsByCategory, err
+			},
+		},
+		{
+			name:   "LedgerLive",
+			header: "Operation Date,Currency Ticker,Operation Type,Operation Amount,Operation Fees,Operation Hash,Account Name,Account xpub",
+			line:   "2019-12-02T09:51:31.000Z,BTC,IN,2.8949079,0.0001922,f978fc4c94e054fa473ac4099f584bd9c0c58ade49f1a0c5941fad3a180e54e6,Bitcoin,xpub6DCB4S5L5Mp4Whnd6waASfGnXDLZXqBGRTRZ45QHqXgreimLRiYyen6HYuCqxgZDwCAW5AE1DgTy5RKSsYdhFGcueUSNH9vbTvWZTmEkh2Z",
+			parse: func(csv string) (wallet.TXsByCategory, error) {
+				ll := ledgerlive.New()
+				err := ll.ParseCSV(strings.NewReader(csv), *category.New())
+				return ll.TXsByCategory, err
+			},
+		},
+		{
+			name:   "LocalBitcoin Transfer",
+			header: "TXID,Created,Received,Sent,TXtype,TXdesc,TXNotes",
+			line:   ",2019-11-29T07:29:43+00:00,,1.76351515,Send to address,32F5pyzpge5KEi3CNZV5z9kE8d9ciqkm8k,",
+			parse: func(csv string) (wallet.TXsByCategory, error) {
+				lb := localbitcoin.New()
+				err := lb.ParseTransferCSV(strings.NewReader(csv), "")
+				return lb.TXsByCategory, err
+			},
+		},
+		{
+			name:   "Manual",
+			header: "ID,Date,Category,From,FromCurrency,To,ToCurrency,Fee,FeeCurrency,Lost,LostCurrency,Value,ValueCurrency,Location,Note",
+			line:   "otc1,2020-05-01 12:00:00,CashIn,,,0.1,BTC,,,,,800,EUR,Cash,Achat en main propre",
+			parse: func(csv string) (wallet.TXsByCategory, error) {
+				man := manual.New()
+				err := man.ParseCSV(strings.NewReader(csv))
+				return man.TXsByCategory, err
+			},
+		},
+		{
+			name:   "Uphold",
+			header: "Date,Destination,Destination Amount,Destination Currency,Fee Amount,Fee Currency,Id,Origin,Origin Amount,Origin Currency,Status,Type",
+			line:   "Fri Apr 09 2021 23:58:41 GMT+0000,uphold,21.375,BAT,,,f0fc27a7-a0af-4a1a-8d42-24795a27f8fe,uphold,21.375,BAT,completed,in",
+			parse: func(csv string) (wallet.TXsByCategory, error) {
+				uh := uphold.New()
+				err := uh.ParseCSV(strings.NewReader(csv), *category.New(), "")
+				return uh.TXsByCategory, err
+			},
+		},
+	}
+	for _, tt := range tests {
+		for variant, csv := range map[string]string{
+			"header":     tt.header + "\n" + tt.line,
+			"headerless": tt.line,
+		} {
+			t.Run(tt.name+" "+variant, func(t *testing.T) {
+				txs, err := tt.parse(csv)
+				if err != nil {
+					t.Fatalf("%s ParseCSV() error = %v", tt.name, err)
+				}
+				count := 0
+				for _, t := range txs {
+					count += len(t)
+				}
+				if count != 1 {
+					t.Errorf("%s ParseCSV() = %v, want 1 TX", tt.name, txs)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetectCSVLayout(t *testing.T) {
+	formats := []CSVFormat{
+		{Version: "original", Columns: []string{"UTC_Time", "Coin", "Change", "Remark"}},
+		{Version: "extended", Columns: []string{"UTC_Time", "Coin", "Change", "Fee", "Remark"}},
+	}
+	l, err := DetectCSVLayout("Test CSV :", []string{"\ufeffUTC_Time", "Remark", "Fee", "Coin", "Change"}, formats...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Version != "extended" {
+		t.Errorf("DetectCSVLayout() Version = %v, want extended", l.Version)
+	}
+	r := []string{"2021-01-01 00:00:00", "note", "0.1", "BTC", "-1"}
+	if l.Get(r, "Coin") != "BTC" || l.Get(r, "Fee") != "0.1" || l.Get(r, "Missing") != "" {
+		t.Errorf("CSVLayout.Get() = %v %v, want BTC 0.1", l.Get(r, "Coin"), l.Get(r, "Fee"))
+	}
+	if !l.IsHeader([]string{"UTC_Time", "Remark"}) || l.IsHeader(r) {
+		t.Errorf("CSVLayout.IsHeader() wrong")
+	}
+	l, err = DetectCSVLayout("Test CSV :", []string{"Type", "Cur.", "Cur."}, CSVFormat{Version: "dup", Columns: []string{"Cur.", "Cur.#2"}})
+	if err != nil || l.Get([]string{"a", "b", "c"}, "Cur.#2") != "c" {
+		t.Errorf("DetectCSVLayout() duplicates = %v, %v", l, err)
+	}
+	l, _ = DetectCSVLayout("Test CSV :", []string{"Date (UTC)", "Tag"}, CSVFormat{Version: "utc", Columns: []string{"Date (UTC)"}})
+	l.Alias("Date", "Date (UTC)")
+	l.Alias("Label", "Label", "Tag")
+	if l.Get([]string{"d", "t"}, "Date") != "d" || l.Get([]string{"d", "t"}, "Label") != "t" {
+		t.Errorf("CSVLayout.Alias() wrong")
+	}
+	_, err = DetectCSVLayout("Test CSV :", []string{"Date", "Amount"}, formats...)
+	if err == nil {
+		t.Errorf("DetectCSVLayout() should fail on unknown layout")
+	}
+}
+
+func TestDetectCSVLayoutPositional(t *testing.T) {
+	formats := []CSVFormat{
+		{Version: "original", Columns: []string{"txid", "time", "amount"}, Positional: true, Date: "time", DateLayouts: []string{"2006-01-02"}, Amounts: []string{"amount"}},
+		{Version: "extended", Columns: []string{"txid", "time", "amount", "fee"}},
+	}
+	r := []string{"T1", "2021-01-01", "1.5"}
+	l, err := DetectCSVLayout("Test CSV :", r, formats...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Version != "original" || l.IsHeader(r) || l.Get(r, "amount") != "1.5" {
+		t.Errorf("DetectCSVLayout() headerless = %v, want original by position", l)
+	}
+	if !l.IsHeader([]string{"txid", "time", "amount"}) {
+		t.Errorf("CSVLayout.IsHeader() should detect a concatenated header")
+	}
+	_, err = DetectCSVLayout("Test CSV :", []string{"txid", "date", "amount"}, formats...)
+	if err == nil {
+		t.Errorf("DetectCSVLayout() should fail on a renamed header")
+	}
+	_, err = DetectCSVLayout("Test CSV :", []string{"T1", "2021-01-01"}, formats...)
+	if err == nil {
+		t.Errorf("DetectCSVLayout() should fail on a short headerless line")
+	}
+	_, err = DetectCSVLayout("Test CSV :", []string{"T1", "01/01/2021", "1.5"}, formats...)
+	if err == nil {
+		t.Errorf("DetectCSVLayout() should fail on a headerless line with another date format")
+	}
+	binance := []CSVFormat{
+		{Version: "original", Columns: []string{"UTC_Time", "Account", "Operation", "Coin", "Change", "Remark"}, Positional: true, Date: "UTC_Time", DateLayouts: []string{"2006-01-02 15:04:05"}, Amounts: []string{"Change"}},
+	}
+	_, err = DetectCSVLayout("Test CSV :", []string{"Date(UTC)", "Wallet", "Type", "Asset", "Amount", "Note"}, binance...)
+	if err == nil || !strings.Contains(err.Error(), "Unknown CSV layout") {
+		t.Errorf("DetectCSVLayout() should fail on an unknown header, got %v", err)
+	}
+}
@@ -0,0 +1,294 @@
+package wallet
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const archiveVersion = 1
+
+type archiveCurrency struct {
+	Code     string          `json:"code"`
+	Amount   decimal.Decimal `json:"amount"`
+	Location string          `json:"location,omitempty"`
+}
+
+type archiveNft struct {
+	ID       string `json:"id"`
+	Name     string `json:"name,omitempty"`
+	Symbol   string `json:"symbol,omitempty"`
+	Quantity string `json:"quantity,omitempty"`
+}
+
+type archiveTX struct {
+	ID         string                       `json:"id"`
+	Timestamp  time.Time                    `json:"timestamp"`
+	Source     string                       `json:"source,omitempty"`
+	Provenance string                       `json:"provenance,omitempty"`
+	Category   string                       `json:"category"`
+	Items      map[string][]archiveCurrency `json:"items,omitempty"`
+	Nfts       map[string][]archiveNft      `json:"nfts,omitempty"`
+	Note       string                       `json:"note"`
+}
+
+type archive struct {
+	Version int         `json:"version"`
+	TXs     []archiveTX `json:"txs"`
+}
+
+// sortKinds puts From, To, Fee and Lost first so that exports are stable
+func sortKinds(keys []string) []string {
+	order := map[string]int{"From": 0, "To": 1, "Fee": 2, "Lost": 3}
+	sort.Slice(keys, func(i, j int) bool {
+		oi, iok := order[keys[i]]
+		oj, jok := order[keys[j]]
+		if iok && jok {
+			return oi < oj
+		} else if iok != jok {
+			return iok
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+func (txs TXsByCategory) toArchive() (a archive) {
+	a.Version = archiveVersion
+	cats := make([]string, 0, len(txs))
+	for k := range txs {
+		cats = append(cats, k)
+	}
+	sort.Strings(cats)
+	for _, cat := range cats {
+		for _, tx := range txs[cat] {
+			at := archiveTX{ID: tx.ID, Timestamp: tx.Timestamp, Source: tx.Source, Provenance: tx.Provenance(), Category: cat, Note: tx.Note}
+			for k := range tx.Items {
+				if at.Items == nil {
+					at.Items = make(map[string][]archiveCurrency)
+				}
+				at.Items[k] = []archiveCurrency{}
+				for _, c := range tx.Items[k] {
+					at.Items[k] = append(at.Items[k], archiveCurrency{Code: c.Code, Amount: c.Amount, Location: c.Location})
+				}
+			}
+			for k := range tx.Nfts {
+				if at.Nfts == nil {
+					at.Nfts = make(map[string][]archiveNft)
+				}
+				at.Nfts[k] = []archiveNft{}
+				for _, n := range tx.Nfts[k] {
+					an := archiveNft{ID: n.ID, Name: n.Name, Symbol: n.Symbol}
+					if !n.Quantity.IsZero() {
+						an.Quantity = n.Quantity.String()
+					}
+					at.Nfts[k] = append(at.Nfts[k], an)
+				}
+			}
+			a.TXs = append(a.TXs, at)
+		}
+	}
+	return
+}
+
+// Provenance is the start of the Note, where each parser writes its name and kind like "Kraken CSV"
+func (tx TX) Provenance() string {
+	return strings.TrimSpace(strings.SplitN(tx.Note, ":", 2)[0])
+}
+
+func (at archiveTX) toTX() (tx TX, err error) {
+	tx = TX{Timestamp: at.Timestamp, ID: at.ID, Source: at.Source, Note: at.Note}
+	tx.Items = make(map[string]Currencies)
+	for k, v := range at.Items {
+		tx.Items[k] = nil
+		for _, c := range v {
+			tx.Items[k] = append(tx.Items[k], Currency{Code: c.Code, Amount: c.Amount, Location: c.Location})
+		}
+	}
+	if len(at.Nfts) > 0 {
+		tx.Nfts = make(map[string]Nfts)
+		for k, v := range at.Nfts {
+			for _, n := range v {
+				nft := Nft{ID: n.ID, Name: n.Name, Symbol: n.Symbol}
+				if n.Quantity != "" {
+					nft.Quantity, err = decimal.NewFromString(n.Quantity)
+					if err != nil {
+						return tx, errors.New("Error Parsing NFT Quantity " + n.Quantity)
+					}
+				}
+				tx.Nfts[k] = append(tx.Nfts[k], nft)
+			}
+		}
+	}
+	return
+}
+
+// TXsToJSON dumps every normalized TX so that ImportTXsJSON can reproduce the same reports
+func (txs TXsByCategory) TXsToJSON(writer io.Writer) error {
+	enc := json.NewEncoder(writer)
+	enc.SetIndent("", "  ")
+	return enc.Encode(txs.toArchive())
+}
+
+func ImportTXsJSON(reader io.Reader) (txs TXsByCategory, err error) {
+	const SOURCE = "TXs JSON :"
+	var a archive
+	err = json.NewDecoder(reader).Decode(&a)
+	if err != nil {
+		return nil, errors.New(SOURCE + " " + err.Error())
+	}
+	if a.Version != archiveVersion {
+		return nil, errors.New(SOURCE + " Unsupported version " + strconv.Itoa(a.Version))
+	}
+	txs = make(TXsByCategory)
+	for _, at := range a.TXs {
+		tx, err := at.toTX()
+		if err == nil {
+			err = tx.Validate(at.Category)
+		}
+		if err != nil {
+			return nil, errors.New(SOURCE + " TX " + at.ID + " " + err.Error())
+		}
+		txs[at.Category] = append(txs[at.Category], tx)
+	}
+	return
+}
+
+var ArchiveCSVHeader = []string{"Seq", "ID", "Timestamp", "Source", "Provenance", "Category", "Kind", "Code", "Amount", "Location", "NftID", "NftName", "NftSymbol", "Note"}
+
+// TXsToCSV writes one line per leg or NFT, the lines of a TX are consecutive and share the same Seq
+func (txs TXsByCategory) TXsToCSV(writer io.Writer) error {
+	w := csv.NewWriter(writer)
+	err := w.Write(ArchiveCSVHeader)
+	if err != nil {
+		return err
+	}
+	for seq, at := range txs.toArchive().TXs {
+		base := []string{strconv.Itoa(seq + 1), at.ID, at.Timestamp.Format(time.RFC3339Nano), at.Source, at.Provenance, at.Category}
+		lines := 0
+		var kinds []string
+		for k := range at.Items {
+			kinds = append(kinds, k)
+		}
+		for _, k := range sortKinds(kinds) {
+			if len(at.Items[k]) == 0 {
+				err = w.Write(append(append([]string{}, base...), k, "", "", "", "", "", "", at.Note))
+				if err != nil {
+					return err
+				}
+				lines += 1
+			}
+			for _, c := range at.Items[k] {
+				err = w.Write(append(append([]string{}, base...), k, c.Code, c.Amount.String(), c.Location, "", "", "", at.Note))
+				if err != nil {
+					return err
+				}
+				lines += 1
+			}
+		}
+		kinds = nil
+		for k := range at.Nfts {
+			kinds = append(kinds, k)
+		}
+		for _, k := range sortKinds(kinds) {
+			for _, n := range at.Nfts[k] {
+				err = w.Write(append(append([]string{}, base...), k, "", n.Quantity, "", n.ID, n.Name, n.Symbol, at.Note))
+				if err != nil {
+					return err
+				}
+				lines += 1
+			}
+		}
+		if lines == 0 {
+			err = w.Write(append(append([]string{}, base...), "", "", "", "", "", "", "", at.Note))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func ImportTXsCSV(reader io.Reader) (txs TXsByCategory, err error) {
+	const SOURCE = "TXs CSV :"
+	csvReader := csv.NewReader(reader)
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, errors.New(SOURCE + " " + err.Error())
+	}
+	txs = make(TXsByCategory)
+	var current *archiveTX
+	seq := ""
+	flush := func() error {
+		if current != nil {
+			tx, err := current.toTX()
+			if err == nil {
+				err = tx.Validate(current.Category)
+			}
+			if err != nil {
+				return errors.New(SOURCE + " TX " + current.ID + " " + err.Error())
+			}
+			txs[current.Category] = append(txs[current.Category], tx)
+		}
+		return nil
+	}
+	for n, r := range records {
+		if r[0] == "Seq" && r[1] == "ID" {
+			continue
+		}
+		line := SOURCE + " line " + strconv.Itoa(n+1)
+		if len(r) != len(ArchiveCSVHeader) {
+			return nil, errors.New(line + " expected " + strconv.Itoa(len(ArchiveCSVHeader)) + " fields")
+		}
+		if r[0] == "" {
+			return nil, errors.New(line + " Missing Seq")
+		}
+		if current == nil || seq != r[0] {
+			err = flush()
+			if err != nil {
+				return nil, err
+			}
+			ts, err := time.Parse(time.RFC3339Nano, r[2])
+			if err != nil {
+				return nil, errors.New(line + " Error Parsing Timestamp " + r[2])
+			}
+			seq = r[0]
+			current = &archiveTX{ID: r[1], Timestamp: ts, Source: r[3], Provenance: r[4], Category: r[5], Note: r[13]}
+		}
+		kind := r[6]
+		if r[10] != "" {
+			if current.Nfts == nil {
+				current.Nfts = make(map[string][]archiveNft)
+			}
+			current.Nfts[kind] = append(current.Nfts[kind], archiveNft{ID: r[10], Name: r[11], Symbol: r[12], Quantity: r[8]})
+		} else if kind != "" {
+			if current.Items == nil {
+				current.Items = make(map[string][]archiveCurrency)
+			}
+			if r[7] == "" {
+				if _, ok := current.Items[kind]; !ok {
+					current.Items[kind] = []archiveCurrency{}
+				}
+				continue
+			}
+			amount, err := decimal.NewFromString(r[8])
+			if err != nil {
+				return nil, errors.New(line + " Error Parsing Amount " + r[8])
+			}
+			current.Items[kind] = append(current.Items[kind], archiveCurrency{Code: r[7], Amount: amount, Location: r[9]})
+		}
+	}
+	err = flush()
+	if err != nil {
+		return nil, err
+	}
+	return
+}
@@ -0,0 +1,154 @@
+package wallet
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestWallet_TXsRoundTrip(t *testing.T) {
+	paris, _ := time.LoadLocation("Europe/Paris")
+	txs := make(TXsByCategory)
+	txs["Exchanges"] = TXs{
+		TX{
+			Timestamp: time.Date(2020, time.March, 1, 10, 0, 0, 123, paris),
+			ID:        "e1",
+			Source:    "Kraken",
+			Note:      "Kraken CSV : e1, \"quoted\"",
+			Items: map[string]Currencies{
+				"From": {Currency{Code: "EUR", Amount: decimal.NewFromInt(100), Location: "Kraken"}},
+				"To":   {Currency{Code: "BTC", Amount: decimal.RequireFromString("0.012345678901"), Location: "Kraken"}},
+				"Fee":  {},
+			},
+		},
+	}
+	txs["AirDrops"] = TXs{
+		TX{
+			Timestamp: time.Date(2021, time.May, 1, 0, 0, 0, 0, time.UTC),
+			ID:        "a1",
+			Items:     map[string]Currencies{"To": {Currency{Code: "UNI", Amount: decimal.NewFromInt(400)}}},
+			Nfts:      map[string]Nfts{"To": {Nft{ID: "42", Name: "Kitty", Symbol: "CK"}, Nft{ID: "7", Name: "Sword", Symbol: "GAME", Quantity: decimal.NewFromInt(3)}}},
+		},
+	}
+	txs["NFTs"] = TXs{
+		TX{
+			Timestamp: time.Date(2021, time.May, 2, 0, 0, 0, 0, time.UTC),
+			ID:        "n1",
+			Source:    "Ethereum",
+			Items:     map[string]Currencies{"Fee": {Currency{Code: "ETH", Amount: decimal.New(2, -3)}}},
+			Nfts:      map[string]Nfts{"From": {Nft{ID: "7", Name: "Punk", Symbol: "PUNK"}, Nft{ID: "8", Name: "Sword", Symbol: "GAME", Quantity: decimal.NewFromInt(2)}}},
+		},
+	}
+	// wallets write a zero Fee on received TXs
+	txs["Deposits"] = TXs{
+		TX{
+			Timestamp: time.Date(2021, time.May, 3, 0, 0, 0, 0, time.UTC),
+			ID:        "d1",
+			Source:    "Bitcoin Core",
+			Items: map[string]Currencies{
+				"To":  {Currency{Code: "BTC", Amount: decimal.New(5, -1)}},
+				"Fee": {Currency{Code: "BTC", Amount: decimal.Zero}},
+			},
+		},
+	}
+	// the same fee charged twice at the same time
+	txs["Fees"] = TXs{
+		TX{
+			Timestamp: time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC),
+			ID:        "f1",
+			Note:      "Kraken CSV : f1",
+			Items:     map[string]Currencies{"Fee": {Currency{Code: "BTC", Amount: decimal.New(1, -4)}}},
+		},
+		TX{
+			Timestamp: time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC),
+			ID:        "f1",
+			Note:      "Kraken CSV : f1",
+			Items:     map[string]Currencies{"Fee": {Currency{Code: "BTC", Amount: decimal.New(1, -4)}}},
+		},
+	}
+	check := func(name string, got TXsByCategory) {
+		for cat, v := range txs {
+			if len(got[cat]) != len(v) {
+				t.Fatalf("%s %s = %v, want %v", name, cat, got[cat], v)
+			}
+			for i, tx := range v {
+				g := got[cat][i]
+				if g.ID != tx.ID || g.Source != tx.Source || g.Note != tx.Note ||
+					!g.Timestamp.Equal(tx.Timestamp) || g.Timestamp.Format(time.RFC3339Nano) != tx.Timestamp.Format(time.RFC3339Nano) ||
+					!reflect.DeepEqual(g.Nfts, tx.Nfts) || len(g.Items) != len(tx.Items) {
+					t.Errorf("%s %s = %+v, want %+v", name, cat, g, tx)
+				}
+				for k, cs := range tx.Items {
+					if len(g.Items[k]) != len(cs) {
+						t.Errorf("%s %s %s = %v, want %v", name, cat, k, g.Items[k], cs)
+						continue
+					}
+					for j, c := range cs {
+						if g.Items[k][j].Code != c.Code || !g.Items[k][j].Amount.Equal(c.Amount) || g.Items[k][j].Location != c.Location {
+							t.Errorf("%s %s %s = %v, want %v", name, cat, k, g.Items[k], cs)
+						}
+					}
+				}
+			}
+		}
+	}
+	var js bytes.Buffer
+	if err := txs.TXsToJSON(&js); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ImportTXsJSON(&js)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("JSON", got)
+	for _, at := range txs.toArchive().TXs {
+		if at.ID == "e1" && at.Provenance != "Kraken CSV" {
+			t.Errorf("toArchive() Provenance = %v, want Kraken CSV", at.Provenance)
+		}
+	}
+	var cs bytes.Buffer
+	if err := txs.TXsToCSV(&cs); err != nil {
+		t.Fatal(err)
+	}
+	got, err = ImportTXsCSV(&cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("CSV", got)
+}
+
+func TestWallet_ImportTXsValidate(t *testing.T) {
+	txs := make(TXsByCategory)
+	txs["Exchanges"] = TXs{
+		TX{
+			Timestamp: time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC),
+			ID:        "e1",
+			Items:     map[string]Currencies{"To": {Currency{Code: "BTC", Amount: decimal.NewFromInt(1)}}},
+		},
+	}
+	var js bytes.Buffer
+	if err := txs.TXsToJSON(&js); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ImportTXsJSON(&js); err == nil {
+		t.Error("ImportTXsJSON() error = nil, want Exchanges without From rejected")
+	}
+	var cs bytes.Buffer
+	if err := txs.TXsToCSV(&cs); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ImportTXsCSV(&cs); err == nil {
+		t.Error("ImportTXsCSV() error = nil, want Exchanges without From rejected")
+	}
+}
+
+func TestWallet_ImportTXsNftQuantity(t *testing.T) {
+	const js = `{"version":1,"txs":[{"id":"a1","timestamp":"2021-05-01T00:00:00Z","category":"AirDrops","items":{"To":[{"code":"UNI","amount":"1"}]},"nfts":{"To":[{"id":"7","quantity":"x"}]},"note":""}]}`
+	if _, err := ImportTXsJSON(strings.NewReader(js)); err == nil {
+		t.Error("ImportTXsJSON() error = nil, want NFT Quantity error")
+	}
+}
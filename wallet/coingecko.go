@@ -11,6 +11,8 @@ import (
 	"github.com/superoo7/go-gecko/v3"
 )
 
+var coinGeckoURL = "https://api.coingecko.com/api/v3"
+
 type CoinGeckoAPI struct {
 	httpClient *http.Client
 	client     *coingecko.Client
@@ -107,3 +109,35 @@ func (api *CoinGeckoAPI) GetExchangeRates(date time.Time, coin string) (rates Ex
 	}
 	return rates, nil
 }
+
+// CoinGeckoHasContract tells if CoinGecko lists the token contract on platform (ethereum, polygon-pos...),
+// err is set when CoinGecko did not give an answer, not when the token is unknown
+func CoinGeckoHasContract(platform, contract string) (known bool, err error) {
+	db, err := scribble.New("./Cache", nil)
+	if err != nil {
+		return false, err
+	}
+	contract = strings.ToLower(contract)
+	err = db.Read("CoinGecko/contracts/"+platform, contract, &known)
+	if err == nil {
+		return known, nil
+	}
+	client := &http.Client{Timeout: time.Second * 10}
+	for try := 0; ; try++ {
+		resp, err := client.Get(coinGeckoURL + "/coins/" + platform + "/contract/" + contract)
+		if err != nil {
+			return false, err
+		}
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusTooManyRequests && try == 0 {
+			time.Sleep(time.Minute)
+			continue
+		}
+		if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+			return false, errors.New("CoinGecko API replied " + resp.Status + " for " + contract)
+		}
+		known = resp.StatusCode == http.StatusOK
+		db.Write("CoinGecko/contracts/"+platform, contract, known)
+		return known, nil
+	}
+}
@@ -0,0 +1,42 @@
+package wallet
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestCoinGeckoHasContract(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/coins/ethereum/contract/0xuni":
+			w.Write([]byte(`{"id":"uniswap","symbol":"uni"}`))
+		case "/coins/ethereum/contract/0xflaky":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"coin not found"}`))
+		}
+	}))
+	defer srv.Close()
+	url := coinGeckoURL
+	coinGeckoURL = srv.URL
+	defer func() { coinGeckoURL = url }()
+	wd, _ := os.Getwd()
+	os.Chdir(t.TempDir())
+	defer os.Chdir(wd)
+	for contract, want := range map[string]bool{"0xUNI": true, "0xjunk": false} {
+		known, err := CoinGeckoHasContract("ethereum", contract)
+		if err != nil || known != want {
+			t.Errorf("CoinGeckoHasContract(%s) = %v, %v, want %v", contract, known, err, want)
+		}
+	}
+	if _, err := CoinGeckoHasContract("ethereum", "0xflaky"); err == nil {
+		t.Errorf("CoinGeckoHasContract() should report a CoinGecko failure")
+	}
+	srv.Close()
+	if known, err := CoinGeckoHasContract("ethereum", "0xjunk"); err != nil || known {
+		t.Errorf("CoinGeckoHasContract() should answer unknown tokens from the Cache")
+	}
+}
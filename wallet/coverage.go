@@ -0,0 +1,200 @@
+package wallet
+
+import (
+	"sort"
+	"time"
+)
+
+type Granularity int
+
+const (
+	Monthly   Granularity = 1
+	Quarterly Granularity = 3
+	Yearly    Granularity = 12
+)
+
+// ExportGranularities lists how often each Source delivers its statements
+var ExportGranularities = map[string]Granularity{
+	"Binance":       Quarterly,
+	"Bitfinex":      Yearly,
+	"Bitstamp":      Quarterly,
+	"Bittrex":       Yearly,
+	"CdC App":       Yearly,
+	"CdC Exchange":  Yearly,
+	"Coinbase":      Yearly,
+	"CoinbasePro":   Yearly,
+	"HitBTC":        Yearly,
+	"Kraken":        Yearly,
+	"Local Bitcoin": Yearly,
+	"Poloniex":      Yearly,
+	"Revolut":       Yearly,
+	"Uphold":        Yearly,
+}
+
+type FileSpan struct {
+	File  string
+	First time.Time
+	Last  time.Time
+}
+
+type LocationCoverage struct {
+	Source      string
+	Granularity Granularity
+	Files       []FileSpan
+}
+
+type Coverage map[string]*LocationCoverage
+
+type CoverageGap struct {
+	Location string
+	Start    time.Time
+	End      time.Time
+	Activity int
+	Held     []string
+}
+
+type CoverageGaps []CoverageGap
+
+type Snapshot map[string]int
+
+func (txs TXsByCategory) Snapshot() (snap Snapshot) {
+	snap = make(Snapshot)
+	for k, v := range txs {
+		snap[k] = len(v)
+	}
+	return
+}
+
+// Since returns TXs appended after the Snapshot was taken
+func (txs TXsByCategory) Since(snap Snapshot) (newTXs TXs) {
+	for k, v := range txs {
+		if len(v) > snap[k] {
+			newTXs = append(newTXs, v[snap[k]:]...)
+		}
+	}
+	return
+}
+
+func NewCoverage() Coverage {
+	return make(Coverage)
+}
+
+func (cov Coverage) AddFile(src, account, file string, txs TXs) {
+	granularity, ok := ExportGranularities[src]
+	if !ok || len(txs) == 0 {
+		return
+	}
+	location := NewLocation(src, account)
+	if _, ok := cov[location]; !ok {
+		cov[location] = &LocationCoverage{Source: src, Granularity: granularity}
+	}
+	span := FileSpan{File: file, First: txs[0].Timestamp, Last: txs[0].Timestamp}
+	for _, tx := range txs {
+		if tx.Timestamp.Before(span.First) {
+			span.First = tx.Timestamp
+		}
+		if tx.Timestamp.After(span.Last) {
+			span.Last = tx.Timestamp
+		}
+	}
+	cov[location].Files = append(cov[location].Files, span)
+}
+
+func (g Granularity) periodStart(t time.Time) time.Time {
+	t = t.UTC()
+	month := time.Month((int(t.Month())-1)/int(g)*int(g) + 1)
+	return time.Date(t.Year(), month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func (g Granularity) next(t time.Time) time.Time {
+	return t.AddDate(0, int(g), 0)
+}
+
+// FindGaps lists the periods between the first and the last TX of each location that no file covers,
+// when the location holds funds or moves them during this period
+func (cov Coverage) FindGaps(txs TXsByCategory) (gaps CoverageGaps) {
+	locations := make([]string, 0, len(cov))
+	for location := range cov {
+		locations = append(locations, location)
+	}
+	sort.Strings(locations)
+	for _, location := range locations {
+		lc := cov[location]
+		covered := make(map[time.Time]bool)
+		var first, last time.Time
+		for _, fs := range lc.Files {
+			for p := lc.Granularity.periodStart(fs.First); !p.After(fs.Last); p = lc.Granularity.next(p) {
+				covered[p] = true
+				if first.IsZero() || p.Before(first) {
+					first = p
+				}
+				if p.After(last) {
+					last = p
+				}
+			}
+		}
+		// other inputs may show the location was used before the first or after the last statement
+		if txFirst, txLast, ok := txs.locationSpan(location); ok {
+			if p := lc.Granularity.periodStart(txFirst); p.Before(first) {
+				first = p
+			}
+			if p := lc.Granularity.periodStart(txLast); p.After(last) {
+				last = p
+			}
+		}
+		var gap *CoverageGap
+		for p := first; !p.After(last); p = lc.Granularity.next(p) {
+			if !covered[p] {
+				if gap == nil {
+					gap = &CoverageGap{Location: location, Start: p}
+				}
+				gap.End = lc.Granularity.next(p).Add(-time.Second)
+			} else if gap != nil {
+				if txs.fillGap(gap) {
+					gaps = append(gaps, *gap)
+				}
+				gap = nil
+			}
+		}
+		if gap != nil && txs.fillGap(gap) {
+			gaps = append(gaps, *gap)
+		}
+	}
+	return
+}
+
+func (txs TXsByCategory) locationSpan(location string) (first, last time.Time, ok bool) {
+	for _, v := range txs {
+		for _, tx := range v {
+			if _, found := tx.GetLocationBalances(false, true)[location]; !found {
+				continue
+			}
+			if !ok || tx.Timestamp.Before(first) {
+				first = tx.Timestamp
+			}
+			if !ok || tx.Timestamp.After(last) {
+				last = tx.Timestamp
+			}
+			ok = true
+		}
+	}
+	return
+}
+
+func (txs TXsByCategory) fillGap(gap *CoverageGap) bool {
+	for _, v := range txs {
+		for _, tx := range v {
+			if !tx.Timestamp.Before(gap.Start) && !tx.Timestamp.After(gap.End) {
+				if _, ok := tx.GetLocationBalances(false, true)[gap.Location]; ok {
+					gap.Activity += 1
+				}
+			}
+		}
+	}
+	w := txs.GetLocationsWallets(gap.Start, false, true)[gap.Location]
+	for k := range w.Currencies {
+		gap.Held = append(gap.Held, k)
+	}
+	sort.Strings(gap.Held)
+	return gap.Activity > 0 || len(gap.Held) > 0
+}
@@ -0,0 +1,71 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestWallet_CoverageFindGaps(t *testing.T) {
+	q1 := TXs{
+		TX{
+			Timestamp: time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC),
+			Items:     map[string]Currencies{"To": {Currency{Code: "BTC", Amount: decimal.NewFromInt(1)}}},
+		},
+	}
+	q3 := TXs{
+		TX{
+			Timestamp: time.Date(2020, time.August, 1, 0, 0, 0, 0, time.UTC),
+			Items:     map[string]Currencies{"From": {Currency{Code: "BTC", Amount: decimal.NewFromInt(1)}}},
+		},
+	}
+	txs := make(TXsByCategory)
+	snap := txs.Snapshot()
+	txs["Deposits"] = append(txs["Deposits"], q1...)
+	cov := NewCoverage()
+	cov.AddFile("Binance", "", "2020_Q1.csv", txs.Since(snap))
+	snap = txs.Snapshot()
+	txs["Withdrawals"] = append(txs["Withdrawals"], q3...)
+	cov.AddFile("Binance", "", "2020_Q3.csv", txs.Since(snap))
+	txs.SetLocation("Binance", "")
+	gaps := cov.FindGaps(txs)
+	if len(gaps) != 1 {
+		t.Fatalf("FindGaps() = %v, want 1 gap", gaps)
+	}
+	if !gaps[0].Start.Equal(time.Date(2020, time.April, 1, 0, 0, 0, 0, time.UTC)) ||
+		gaps[0].End.Format("2006-01-02") != "2020-06-30" {
+		t.Errorf("FindGaps() = %+v, want Q2 2020", gaps[0])
+	}
+	if len(gaps[0].Held) != 1 || gaps[0].Held[0] != "BTC" {
+		t.Errorf("FindGaps() Held = %v, want BTC", gaps[0].Held)
+	}
+}
+
+func TestWallet_CoverageFindGapsAfterLastFile(t *testing.T) {
+	txs := make(TXsByCategory)
+	snap := txs.Snapshot()
+	txs["Deposits"] = append(txs["Deposits"], TX{
+		Timestamp: time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC),
+		Items:     map[string]Currencies{"To": {Currency{Code: "BTC", Amount: decimal.NewFromInt(1)}}},
+	})
+	cov := NewCoverage()
+	cov.AddFile("Binance", "", "2020_Q1.csv", txs.Since(snap))
+	txs.SetLocation("Binance", "")
+	// a wallet export shows a transfer to Binance after its last statement
+	txs["Transfers"] = append(txs["Transfers"], TX{
+		Timestamp: time.Date(2020, time.November, 1, 0, 0, 0, 0, time.UTC),
+		Items: map[string]Currencies{
+			"From": {Currency{Code: "BTC", Amount: decimal.NewFromInt(1), Location: "Ledger"}},
+			"To":   {Currency{Code: "BTC", Amount: decimal.NewFromInt(1), Location: "Binance"}},
+		},
+	})
+	gaps := cov.FindGaps(txs)
+	if len(gaps) != 1 {
+		t.Fatalf("FindGaps() = %v, want 1 gap", gaps)
+	}
+	if !gaps[0].Start.Equal(time.Date(2020, time.April, 1, 0, 0, 0, 0, time.UTC)) ||
+		gaps[0].End.Format("2006-01-02") != "2020-12-31" {
+		t.Errorf("FindGaps() = %+v, want Q2 to Q4 2020", gaps[0])
+	}
+}
@@ -0,0 +1,39 @@
+package wallet
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// ExportAmount splits an optional leg into the amount and currency columns of third party formats
+func ExportAmount(c *Currency) (string, string) {
+	if c == nil {
+		return "", ""
+	}
+	return c.Amount.String(), c.Code
+}
+
+// RowsToCSV writes every TX sorted by date with its Category set, rows turns one TX into the lines of the format
+func (txs TXsByCategory) RowsToCSV(writer io.Writer, header []string, rows func(TX) [][]string) error {
+	w := csv.NewWriter(writer)
+	err := w.Write(header)
+	if err != nil {
+		return err
+	}
+	var allTXs TXs
+	for cat, list := range txs {
+		for _, tx := range list {
+			tx.Category = cat
+			allTXs = append(allTXs, tx)
+		}
+	}
+	allTXs.SortByDate(true)
+	for _, tx := range allTXs {
+		err = w.WriteAll(rows(tx))
+		if err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,263 @@
+package wallet
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+	"github.com/shopspring/decimal"
+)
+
+const UnknownLocation = "Unknown"
+
+type LocationBalance struct {
+	Timestamp time.Time
+	ID        string
+	Category  string
+	Code      string
+	Change    decimal.Decimal
+	Balance   decimal.Decimal
+	Note      string
+}
+
+type LocationBalances []LocationBalance
+
+func NewLocation(src, account string) string {
+	if account == "" {
+		return src
+	}
+	return src + " (" + account + ")"
+}
+
+func (tx TX) GetLocationBalances(includeFiat, includeFee bool) (lcs map[string]WalletCurrencies) {
+	lcs = make(map[string]WalletCurrencies)
+	for k, i := range tx.Items {
+		for _, c := range i {
+			if c.Code != "" &&
+				(includeFiat || !c.IsFiat()) {
+				location := c.Location
+				if location == "" {
+					location = UnknownLocation
+				}
+				if _, ok := lcs[location]; !ok {
+					lcs[location] = make(WalletCurrencies)
+				}
+				if (k == "Fee" && includeFee) || k == "From" || k == "Lost" {
+					lcs[location][c.Code] = lcs[location][c.Code].Sub(c.Amount)
+				} else if k == "To" {
+					lcs[location][c.Code] = lcs[location][c.Code].Add(c.Amount)
+				}
+			}
+		}
+	}
+	return
+}
+
+func (txs TXsByCategory) SetLocation(src, account string) {
+	txs.SetLocationByNote("", src, account)
+}
+
+// SetLocationByNote tags only TXs whose Note starts with notePrefix, for Sources sharing the same TXsByCategory
+func (txs TXsByCategory) SetLocationByNote(notePrefix, src, account string) {
+	location := NewLocation(src, account)
+	for k := range txs {
+		for i := range txs[k] {
+			if !strings.HasPrefix(txs[k][i].Note, notePrefix) {
+				continue
+			}
+			if txs[k][i].Source == "" {
+				txs[k][i].Source = src
+			}
+			for _, items := range txs[k][i].Items {
+				for j := range items {
+					if items[j].Location == "" {
+						items[j].Location = location
+					}
+				}
+			}
+		}
+	}
+}
+
+func (txs TXsByCategory) GetLocations() (locations []string) {
+	for _, v := range txs {
+		for _, tx := range v {
+			for location := range tx.GetLocationBalances(true, true) {
+				found := false
+				for _, l := range locations {
+					if l == location {
+						found = true
+						break
+					}
+				}
+				if !found {
+					locations = append(locations, location)
+				}
+			}
+		}
+	}
+	sort.Strings(locations)
+	return
+}
+
+func (txs TXsByCategory) GetLocationsWallets(date time.Time, includeFiat bool, rounding bool) (lw map[string]Wallets) {
+	lw = make(map[string]Wallets)
+	for _, v := range txs {
+		for _, tx := range v.Before(date) {
+			for location, wc := range tx.GetLocationBalances(includeFiat, true) {
+				w, ok := lw[location]
+				if !ok {
+					w.Date = date
+					w.Currencies = make(WalletCurrencies)
+					lw[location] = w
+				}
+				w.Currencies.Add(wc)
+			}
+		}
+	}
+	for _, w := range lw {
+		w.Round(rounding)
+	}
+	return
+}
+
+func (txs TXsByCategory) GetLocationsTimeline(includeFiat bool) (timeline map[string]LocationBalances) {
+	timeline = make(map[string]LocationBalances)
+	var allTXs TXs
+	for cat, list := range txs {
+		for _, tx := range list {
+			tx.Category = cat
+			allTXs = append(allTXs, tx)
+		}
+	}
+	// TXs at the same time are ordered by Category then ID, and their legs by Location then Code, so the timeline is stable between runs
+	sort.SliceStable(allTXs, func(i, j int) bool {
+		if !allTXs[i].Timestamp.Equal(allTXs[j].Timestamp) {
+			return allTXs[i].Timestamp.Before(allTXs[j].Timestamp)
+		}
+		if allTXs[i].Category != allTXs[j].Category {
+			return allTXs[i].Category < allTXs[j].Category
+		}
+		return allTXs[i].ID < allTXs[j].ID
+	})
+	balances := make(map[string]WalletCurrencies)
+	for _, tx := range allTXs {
+		lcs := tx.GetLocationBalances(includeFiat, true)
+		locations := make([]string, 0, len(lcs))
+		for location := range lcs {
+			locations = append(locations, location)
+		}
+		sort.Strings(locations)
+		for _, location := range locations {
+			if _, ok := balances[location]; !ok {
+				balances[location] = make(WalletCurrencies)
+			}
+			codes := make([]string, 0, len(lcs[location]))
+			for code := range lcs[location] {
+				codes = append(codes, code)
+			}
+			sort.Strings(codes)
+			for _, code := range codes {
+				change := lcs[location][code]
+				if change.IsZero() {
+					continue
+				}
+				balances[location][code] = balances[location][code].Add(change)
+				timeline[location] = append(timeline[location], LocationBalance{
+					Timestamp: tx.Timestamp,
+					ID:        tx.ID,
+					Category:  tx.Category,
+					Code:      code,
+					Change:    change,
+					Balance:   balances[location][code],
+					Note:      tx.Note,
+				})
+			}
+		}
+	}
+	return
+}
+
+func (txs TXsByCategory) LocationsToXlsx(filename string) error {
+	sanitize := strings.NewReplacer(
+		":", " ",
+		"/", " ",
+		"\\", " ",
+		"?", " ",
+		"*", " ",
+		"[", "(",
+		"]", ")",
+	)
+	f := excelize.NewFile()
+	timeline := txs.GetLocationsTimeline(false)
+	for _, location := range txs.GetLocations() {
+		if len(timeline[location]) == 0 {
+			continue
+		}
+		sheet := sanitize.Replace(location)
+		if len(sheet) > 31 {
+			sheet = sheet[:31]
+		}
+		f.NewSheet(sheet)
+		f.SetCellValue(sheet, "A1", "Date (UTC)")
+		f.SetCellValue(sheet, "B1", "Type d'opération")
+		f.SetCellValue(sheet, "C1", "Devise")
+		f.SetCellValue(sheet, "D1", "Entrée")
+		f.SetCellValue(sheet, "E1", "Sortie")
+		f.SetCellValue(sheet, "F1", "Balance")
+		f.SetCellValue(sheet, "G1", "Note")
+		for i, lb := range timeline[location] {
+			row := strconv.Itoa(i + 2)
+			f.SetCellValue(sheet, "A"+row, lb.Timestamp.UTC().Format("02/01/2006 15:04:05"))
+			f.SetCellValue(sheet, "B"+row, categoryLabel(lb.Category))
+			f.SetCellValue(sheet, "C"+row, lb.Code)
+			if lb.Change.IsPositive() {
+				in, _ := lb.Change.Float64()
+				f.SetCellValue(sheet, "D"+row, in)
+			} else {
+				out, _ := lb.Change.Neg().Float64()
+				f.SetCellValue(sheet, "E"+row, out)
+			}
+			bal, _ := lb.Balance.Float64()
+			f.SetCellValue(sheet, "F"+row, bal)
+			f.SetCellValue(sheet, "G"+row, lb.Note)
+		}
+		f.SetColWidth(sheet, "A", "A", 18)
+		f.SetColWidth(sheet, "B", "B", 16)
+		f.SetColWidth(sheet, "G", "G", 50)
+	}
+	f.DeleteSheet("Sheet1")
+	return f.SaveAs(filename)
+}
+
+func (txs TXsByCategory) LocationsToCSV(writer io.Writer) error {
+	csvWriter := csv.NewWriter(writer)
+	err := csvWriter.Write([]string{"Location", "Date", "Category", "Currency", "Change", "Balance", "ID", "Note"})
+	if err != nil {
+		return err
+	}
+	timeline := txs.GetLocationsTimeline(false)
+	for _, location := range txs.GetLocations() {
+		for _, lb := range timeline[location] {
+			err = csvWriter.Write([]string{
+				location,
+				lb.Timestamp.UTC().Format(time.RFC3339),
+				lb.Category,
+				lb.Code,
+				lb.Change.String(),
+				lb.Balance.String(),
+				lb.ID,
+				lb.Note,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
@@ -0,0 +1,69 @@
+package wallet
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestWallet_GetLocationsTimeline(t *testing.T) {
+	txs := make(TXsByCategory)
+	txs["Deposits"] = TXs{
+		TX{
+			Timestamp: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Items:     map[string]Currencies{"To": {Currency{Code: "BTC", Amount: decimal.NewFromInt(2)}}},
+		},
+	}
+	txs.SetLocation("Kraken", "")
+	transfers := make(TXsByCategory)
+	transfers["Transfers"] = TXs{
+		TX{
+			Timestamp: time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC),
+			Items: map[string]Currencies{
+				"From": {Currency{Code: "BTC", Amount: decimal.NewFromInt(1), Location: "Kraken"}},
+				"To":   {Currency{Code: "BTC", Amount: decimal.NewFromInt(1), Location: "Ledger"}},
+			},
+		},
+	}
+	txs.Add(transfers)
+	timeline := txs.GetLocationsTimeline(false)
+	if len(timeline["Kraken"]) != 2 {
+		t.Fatalf("GetLocationsTimeline() Kraken = %v, want 2 entries", timeline["Kraken"])
+	}
+	if !timeline["Kraken"][1].Balance.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("GetLocationsTimeline() Kraken balance = %v, want 1", timeline["Kraken"][1].Balance)
+	}
+	if len(timeline["Ledger"]) != 1 || !timeline["Ledger"][0].Balance.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("GetLocationsTimeline() Ledger = %v, want balance 1", timeline["Ledger"])
+	}
+	lw := txs.GetLocationsWallets(time.Date(2020, time.January, 15, 0, 0, 0, 0, time.UTC), false, false)
+	if !lw["Kraken"].Currencies["BTC"].Equal(decimal.NewFromInt(2)) {
+		t.Errorf("GetLocationsWallets() Kraken = %v, want 2 BTC", lw["Kraken"].Currencies)
+	}
+	if _, ok := lw["Ledger"]; ok {
+		t.Errorf("GetLocationsWallets() Ledger should not exist before transfer")
+	}
+}
+
+func TestWallet_GetLocationsTimelineSameTime(t *testing.T) {
+	date := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	deposit := func(id string) TX {
+		return TX{Timestamp: date, ID: id, Items: map[string]Currencies{"To": {Currency{Code: "BTC", Amount: decimal.NewFromInt(1)}}}}
+	}
+	txs := make(TXsByCategory)
+	txs["Interests"] = TXs{deposit("b")}
+	txs["Deposits"] = TXs{deposit("c"), deposit("a")}
+	txs.SetLocation("Kraken", "")
+	for i := 0; i < 10; i++ {
+		timeline := txs.GetLocationsTimeline(false)
+		var got []string
+		for _, lb := range timeline["Kraken"] {
+			got = append(got, lb.Category+" "+lb.ID)
+		}
+		if strings.Join(got, ",") != "Deposits a,Deposits c,Interests b" {
+			t.Fatalf("GetLocationsTimeline() Kraken = %v, want sorted by Category then ID", got)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package wallet
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type NegativeExcursion struct {
+	Location    string
+	Code        string
+	Start       time.Time
+	StartID     string
+	End         time.Time
+	Magnitude   decimal.Decimal
+	MissingFrom time.Time
+	MissingTo   time.Time
+}
+
+type NegativeExcursions []NegativeExcursion
+
+// FindNegativeBalances replays each location chronologically and reports every period where a balance dips below -tolerance
+func (txs TXsByCategory) FindNegativeBalances(tolerance decimal.Decimal) (nes NegativeExcursions) {
+	timeline := txs.GetLocationsTimeline(false)
+	for _, location := range txs.GetLocations() {
+		lastSeen := make(map[string]time.Time)
+		current := make(map[string]int)
+		for _, lb := range timeline[location] {
+			i, inExcursion := current[lb.Code]
+			if lb.Balance.LessThan(tolerance.Neg()) {
+				if !inExcursion {
+					nes = append(nes, NegativeExcursion{
+						Location:    location,
+						Code:        lb.Code,
+						Start:       lb.Timestamp,
+						StartID:     lb.ID,
+						Magnitude:   lb.Balance.Neg(),
+						MissingFrom: lastSeen[lb.Code],
+						MissingTo:   lb.Timestamp,
+					})
+					current[lb.Code] = len(nes) - 1
+				} else if lb.Balance.Neg().GreaterThan(nes[i].Magnitude) {
+					nes[i].Magnitude = lb.Balance.Neg()
+				}
+			} else if inExcursion {
+				nes[i].End = lb.Timestamp
+				delete(current, lb.Code)
+			}
+			lastSeen[lb.Code] = lb.Timestamp
+		}
+	}
+	sort.SliceStable(nes, func(i, j int) bool {
+		if nes[i].Location != nes[j].Location {
+			return nes[i].Location < nes[j].Location
+		}
+		if nes[i].Code != nes[j].Code {
+			return nes[i].Code < nes[j].Code
+		}
+		return nes[i].Start.Before(nes[j].Start)
+	})
+	return
+}
@@ -0,0 +1,45 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestWallet_FindNegativeBalances(t *testing.T) {
+	txs := make(TXsByCategory)
+	txs["Deposits"] = TXs{
+		TX{
+			Timestamp: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Items:     map[string]Currencies{"To": {Currency{Code: "BTC", Amount: decimal.NewFromInt(1)}}},
+		},
+		TX{
+			Timestamp: time.Date(2020, time.April, 1, 0, 0, 0, 0, time.UTC),
+			Items:     map[string]Currencies{"To": {Currency{Code: "BTC", Amount: decimal.NewFromInt(3)}}},
+		},
+	}
+	txs["Withdrawals"] = TXs{
+		TX{
+			ID:        "wit1",
+			Timestamp: time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC),
+			Items:     map[string]Currencies{"From": {Currency{Code: "BTC", Amount: decimal.NewFromInt(2)}}},
+		},
+	}
+	txs.SetLocation("Binance", "")
+	nes := txs.FindNegativeBalances(decimal.Zero)
+	if len(nes) != 1 {
+		t.Fatalf("FindNegativeBalances() = %v, want 1 excursion", nes)
+	}
+	ne := nes[0]
+	if ne.Location != "Binance" || ne.Code != "BTC" || ne.StartID != "wit1" {
+		t.Errorf("FindNegativeBalances() = %+v, wrong excursion", ne)
+	}
+	if !ne.Magnitude.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("FindNegativeBalances() Magnitude = %v, want 1", ne.Magnitude)
+	}
+	if !ne.MissingFrom.Equal(time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)) ||
+		!ne.End.Equal(time.Date(2020, time.April, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("FindNegativeBalances() = %+v, wrong dates", ne)
+	}
+}
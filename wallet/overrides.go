@@ -0,0 +1,137 @@
+package wallet
+
+import (
+	"errors"
+	"log"
+	"strconv"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/category"
+)
+
+func (tx TX) firstLocation() string {
+	for _, k := range []string{"From", "To", "Fee", "Lost"} {
+		for _, c := range tx.Items[k] {
+			if c.Location != "" {
+				return c.Location
+			}
+		}
+	}
+	return ""
+}
+
+func overrideItems(items map[string]Currencies, legs map[string][]category.Leg, location string) {
+	for k, v := range legs {
+		var cs Currencies
+		for _, l := range v {
+			cs = append(cs, Currency{Code: l.Code, Amount: l.Amount, Location: location})
+		}
+		if len(cs) == 0 {
+			delete(items, k)
+		} else {
+			items[k] = cs
+		}
+	}
+}
+
+// CheckOverrides rejects the overrides that move a TX to an unknown category
+func CheckOverrides(cat category.Category) error {
+	const SOURCE = "TXs Overrides :"
+	for id, ovs := range cat.Overrides() {
+		for _, o := range ovs {
+			if o.Category != "" && !IsCategory(o.Category) {
+				return errors.New(SOURCE + " Override " + id + " Unknown Category " + o.Category)
+			}
+			for _, p := range o.Split {
+				if p.Category != "" && !IsCategory(p.Category) {
+					return errors.New(SOURCE + " Override " + id + " Unknown Category " + p.Category)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyOverrides edits, splits, moves or deletes the TXs listed in the overrides file, whatever Source produced them.
+// An override without source is ignored when its ID is used by several Sources.
+// It runs before FindTransfers, so the generated Transfers are overridden through their Deposit and Withdrawal.
+func (txs TXsByCategory) ApplyOverrides(cat category.Category) {
+	const SOURCE = "TXs Overrides :"
+	overrides := cat.Overrides()
+	if len(overrides) == 0 {
+		return
+	}
+	sources := make(map[string]map[string]bool)
+	for _, v := range txs {
+		for _, tx := range v {
+			if _, ok := overrides[tx.ID]; ok {
+				if sources[tx.ID] == nil {
+					sources[tx.ID] = make(map[string]bool)
+				}
+				sources[tx.ID][tx.Source] = true
+			}
+		}
+	}
+	found := make(map[txKey]bool)
+	moved := make(TXsByCategory)
+	for k, v := range txs {
+		var kept TXs
+		for _, tx := range v {
+			o, ok := cat.GetOverride(tx.Source, tx.ID)
+			if !ok || (o.Source == "" && len(sources[tx.ID]) > 1) {
+				kept = append(kept, tx)
+				continue
+			}
+			found[txKey{o.Source, o.ID}] = true
+			if o.Delete {
+				continue
+			}
+			location := tx.firstLocation()
+			items := make(map[string]Currencies)
+			for ik, iv := range tx.Items {
+				items[ik] = append(Currencies{}, iv...)
+			}
+			tx.Items = items
+			overrideItems(tx.Items, o.Items, location)
+			if o.Note != "" {
+				tx.Note += " " + o.Note
+			}
+			categ := k
+			if o.Category != "" {
+				categ = o.Category
+			}
+			if len(o.Split) > 0 {
+				for i, p := range o.Split {
+					t := TX{Timestamp: tx.Timestamp, ID: tx.ID + "-" + strconv.Itoa(i+1), Source: tx.Source, Note: tx.Note}
+					if p.Note != "" {
+						t.Note += " " + p.Note
+					}
+					t.Items = make(map[string]Currencies)
+					overrideItems(t.Items, p.Items, location)
+					partCateg := categ
+					if p.Category != "" {
+						partCateg = p.Category
+					}
+					moved[partCateg] = append(moved[partCateg], t)
+				}
+			} else if categ == k {
+				kept = append(kept, tx)
+			} else {
+				moved[categ] = append(moved[categ], tx)
+			}
+		}
+		txs[k] = kept
+	}
+	txs.Add(moved)
+	for id, ovs := range overrides {
+		for _, o := range ovs {
+			if found[txKey{o.Source, id}] || o.Unlink {
+				continue
+			}
+			if o.Source == "" && len(sources[id]) > 1 {
+				log.Println(SOURCE, "TX", id, "found in several Sources, source needed")
+			} else {
+				log.Println(SOURCE, "TX not found", o.Source, id)
+			}
+		}
+	}
+}
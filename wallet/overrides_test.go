@@ -0,0 +1,111 @@
+package wallet
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/category"
+	"github.com/shopspring/decimal"
+)
+
+func TestWallet_ApplyOverrides(t *testing.T) {
+	const overrides = `
+overrides:
+  - id: d1
+    delete: yes
+  - id: w1
+    category: Gifts
+    items:
+      From:
+        - code: BTC
+          amount: "0.4"
+      Fee: []
+  - id: w2
+    split:
+      - category: CashOut
+        items:
+          From: [{code: ETH, amount: "1"}]
+          To: [{code: EUR, amount: "300"}]
+      - items:
+          From: [{code: ETH, amount: "2"}]
+  - id: w3
+    unlink: yes
+  - id: x1
+    source: Kraken
+    delete: yes
+  - id: x2
+    delete: yes
+`
+	cat := category.New()
+	if err := cat.ParseYAMLOverrides(strings.NewReader(overrides)); err != nil {
+		t.Fatal(err)
+	}
+	date := time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC)
+	txs := make(TXsByCategory)
+	txs["Deposits"] = TXs{
+		TX{Timestamp: date, ID: "d1", Items: map[string]Currencies{"To": {Currency{Code: "BTC", Amount: decimal.NewFromInt(1)}}}},
+		TX{Timestamp: date, ID: "d3", Note: "B : d3", Items: map[string]Currencies{"To": {Currency{Code: "LTC", Amount: decimal.NewFromInt(1)}}}},
+	}
+	txs["Minings"] = TXs{
+		TX{Timestamp: date, ID: "x1", Source: "Kraken", Items: map[string]Currencies{"To": {Currency{Code: "DOT", Amount: decimal.NewFromInt(1)}}}},
+		TX{Timestamp: date, ID: "x1", Source: "Polkadot", Items: map[string]Currencies{"To": {Currency{Code: "DOT", Amount: decimal.NewFromInt(1)}}}},
+		TX{Timestamp: date, ID: "x2", Source: "Kraken", Items: map[string]Currencies{"To": {Currency{Code: "DOT", Amount: decimal.NewFromInt(1)}}}},
+		TX{Timestamp: date, ID: "x2", Source: "Polkadot", Items: map[string]Currencies{"To": {Currency{Code: "DOT", Amount: decimal.NewFromInt(1)}}}},
+	}
+	txs["Withdrawals"] = TXs{
+		TX{Timestamp: date, ID: "w1", Items: map[string]Currencies{
+			"From": {Currency{Code: "BTC", Amount: decimal.NewFromInt(1), Location: "Kraken"}},
+			"Fee":  {Currency{Code: "BTC", Amount: decimal.New(1, -3), Location: "Kraken"}},
+		}},
+		TX{Timestamp: date, ID: "w2", Items: map[string]Currencies{"From": {Currency{Code: "ETH", Amount: decimal.NewFromInt(3)}}}},
+		TX{Timestamp: date, ID: "w3", Note: "A : w3", Items: map[string]Currencies{"From": {Currency{Code: "LTC", Amount: decimal.NewFromInt(1)}}}},
+	}
+	txs.ApplyOverrides(*cat)
+	if len(txs["Deposits"]) != 1 {
+		t.Errorf("ApplyOverrides() Deposits = %v, want d1 deleted", txs["Deposits"])
+	}
+	if len(txs["Minings"]) != 3 || txs["Minings"][0].Source != "Polkadot" {
+		t.Errorf("ApplyOverrides() Minings = %v, want only x1 from Kraken deleted", txs["Minings"])
+	}
+	if len(txs["Gifts"]) != 1 {
+		t.Fatalf("ApplyOverrides() Gifts = %v, want w1", txs["Gifts"])
+	}
+	g := txs["Gifts"][0]
+	if _, ok := g.Items["Fee"]; ok || !g.Items["From"][0].Amount.Equal(decimal.New(4, -1)) || g.Items["From"][0].Location != "Kraken" {
+		t.Errorf("ApplyOverrides() w1 = %v, want 0.4 BTC from Kraken without Fee", g)
+	}
+	if len(txs["CashOut"]) != 1 || txs["CashOut"][0].ID != "w2-1" {
+		t.Errorf("ApplyOverrides() CashOut = %v, want w2-1", txs["CashOut"])
+	}
+	if len(txs["Withdrawals"]) != 2 {
+		t.Errorf("ApplyOverrides() Withdrawals = %v, want w2-2 and w3", txs["Withdrawals"])
+	}
+	txs.FindTransfers(*cat)
+	if len(txs["Transfers"]) != 0 {
+		t.Errorf("FindTransfers() Transfers = %v, want w3 unlinked", txs["Transfers"])
+	}
+}
+
+func TestCheckOverrides(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides string
+		wantErr   bool
+	}{
+		{name: "known", overrides: "overrides:\n  - id: a\n    category: Gifts\n", wantErr: false},
+		{name: "unknown", overrides: "overrides:\n  - id: a\n    category: Gift\n", wantErr: true},
+		{name: "unknown split", overrides: "overrides:\n  - id: a\n    split:\n      - category: Cashout\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cat := category.New()
+			if err := cat.ParseYAMLOverrides(strings.NewReader(tt.overrides)); err != nil {
+				t.Fatal(err)
+			}
+			if err := CheckOverrides(*cat); (err != nil) != tt.wantErr {
+				t.Errorf("CheckOverrides() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package wallet
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type Discrepancy struct {
+	Location   string
+	Code       string
+	Reported   decimal.Decimal
+	Computed   decimal.Decimal
+	Difference decimal.Decimal
+}
+
+type Discrepancies []Discrepancy
+
+// Reconcile compares the balances reported live by a Source with the ones rebuilt from its TXs
+func (txs TXsByCategory) Reconcile(location string, reported WalletCurrencies, tolerance decimal.Decimal) (ds Discrepancies) {
+	computed := txs.GetLocationsWallets(time.Now(), false, false)[location].Currencies
+	codes := make(map[string]bool)
+	for k := range reported {
+		c := Currency{Code: k}
+		if !c.IsFiat() {
+			codes[k] = true
+		}
+	}
+	for k := range computed {
+		codes[k] = true
+	}
+	for k := range codes {
+		diff := reported[k].Sub(computed[k])
+		if diff.Abs().GreaterThan(tolerance) {
+			ds = append(ds, Discrepancy{
+				Location:   location,
+				Code:       k,
+				Reported:   reported[k],
+				Computed:   computed[k],
+				Difference: diff,
+			})
+		}
+	}
+	sort.Slice(ds, func(i, j int) bool {
+		return ds[i].Code < ds[j].Code
+	})
+	return
+}
+
+func (ds Discrepancies) Println(location string) {
+	fmt.Println("--------------------------------------------------------")
+	fmt.Println("| Reconciliation of " + location + " with its live balances")
+	fmt.Println("--------------------------------------------------------")
+	if len(ds) == 0 {
+		fmt.Println("No discrepancy found")
+		return
+	}
+	for _, d := range ds {
+		fmt.Println(d.Code, ": reported", d.Reported, "computed", d.Computed, "difference", d.Difference)
+	}
+}
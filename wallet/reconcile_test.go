@@ -0,0 +1,34 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestWallet_Reconcile(t *testing.T) {
+	txs := make(TXsByCategory)
+	txs["Deposits"] = TXs{
+		TX{
+			Timestamp: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Items: map[string]Currencies{"To": {
+				Currency{Code: "BTC", Amount: decimal.NewFromInt(1)},
+				Currency{Code: "ETH", Amount: decimal.NewFromInt(5)},
+			}},
+		},
+	}
+	txs.SetLocation("Kraken", "")
+	reported := WalletCurrencies{
+		"BTC": decimal.NewFromInt(1),
+		"ETH": decimal.NewFromInt(3),
+		"EUR": decimal.NewFromInt(100),
+	}
+	ds := txs.Reconcile("Kraken", reported, decimal.New(1, -8))
+	if len(ds) != 1 {
+		t.Fatalf("Reconcile() = %v, want 1 discrepancy", ds)
+	}
+	if ds[0].Code != "ETH" || !ds[0].Difference.Equal(decimal.NewFromInt(-2)) {
+		t.Errorf("Reconcile() = %+v, want ETH difference -2", ds[0])
+	}
+}
@@ -0,0 +1,175 @@
+package wallet
+
+import (
+	"errors"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/category"
+	"github.com/shopspring/decimal"
+)
+
+type txKey struct {
+	Source string
+	ID     string
+}
+
+// CheckRules rejects the rules that match or move to an unknown category
+func CheckRules(cat category.Category) error {
+	const SOURCE = "TXs Rules :"
+	for _, r := range cat.Rules() {
+		if r.FromCateg != "" && !IsCategory(r.FromCateg) {
+			return errors.New(SOURCE + " Rule " + r.Name + " Unknown Category " + r.FromCateg)
+		}
+		if r.Category != "" && !IsCategory(r.Category) {
+			return errors.New(SOURCE + " Rule " + r.Name + " Unknown Category " + r.Category)
+		}
+	}
+	return nil
+}
+
+// findFeeTX looks for the fee TX in the Source of the TX first, then in the only other Source that has this ID
+func findFeeTX(byID map[string]map[string]TX, tx TX, id string) (TX, bool) {
+	if f, ok := byID[id][tx.Source]; ok && id != tx.ID {
+		return f, true
+	}
+	var found []TX
+	for src, f := range byID[id] {
+		if src != tx.Source {
+			found = append(found, f)
+		}
+	}
+	if len(found) == 1 {
+		return found[0], true
+	}
+	return TX{}, false
+}
+
+func matchRule(rules []category.Rule, categ string, tx TX) (category.Rule, bool) {
+	for _, r := range rules {
+		if !r.Matches(tx.Source, categ, tx.Note, tx.Timestamp) {
+			continue
+		}
+		for _, k := range []string{"From", "To"} {
+			for _, c := range tx.Items[k] {
+				if r.MatchesAsset(c.Code, c.Amount) {
+					return r, true
+				}
+			}
+		}
+	}
+	return category.Rule{}, false
+}
+
+// ApplyRules evaluates the YAML rules on every TX whatever its Source, only the first matching rule is applied
+func (txs TXsByCategory) ApplyRules(cat category.Category, native string) {
+	const SOURCE = "TXs Rules :"
+	rules := cat.Rules()
+	if len(rules) == 0 {
+		return
+	}
+	byID := make(map[string]map[string]TX)
+	for _, v := range txs {
+		for _, tx := range v {
+			if tx.ID != "" {
+				if byID[tx.ID] == nil {
+					byID[tx.ID] = make(map[string]TX)
+				}
+				byID[tx.ID][tx.Source] = tx
+			}
+		}
+	}
+	keys := make([]string, 0, len(txs))
+	for k := range txs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	feeTXs := make(map[txKey]bool)
+	moved := make(TXsByCategory)
+	for _, k := range keys {
+		var kept TXs
+		for _, tx := range txs[k] {
+			r, ok := matchRule(rules, k, tx)
+			if !ok {
+				kept = append(kept, tx)
+				continue
+			}
+			categ := k
+			if r.Category != "" {
+				categ = r.Category
+			}
+			// the fee TXs are only linked once the TX is sure to be recategorized
+			if err := tx.addCounterpart(r, categ, native); err != nil {
+				log.Println(SOURCE, "Rule", r.Name, "Error getting rate for", tx.ID, "rule not applied")
+				kept = append(kept, tx)
+				continue
+			}
+			if r.Description != "" && !strings.HasSuffix(tx.Note, " "+r.Description) {
+				// a re-imported archive already has it
+				tx.Note += " " + r.Description
+			}
+			for _, id := range r.Fees {
+				if f, ok := findFeeTX(byID, tx, id); ok {
+					tx.Items["Fee"] = append(tx.Items["Fee"], f.Items["From"]...)
+					tx.Items["Fee"] = append(tx.Items["Fee"], f.Items["Fee"]...)
+					feeTXs[txKey{f.Source, f.ID}] = true
+				} else {
+					log.Println(SOURCE, "Rule", r.Name, "Fee TX not found", id)
+				}
+			}
+			if categ == k {
+				kept = append(kept, tx)
+			} else {
+				moved[categ] = append(moved[categ], tx)
+			}
+		}
+		txs[k] = kept
+	}
+	txs.Add(moved)
+	if len(feeTXs) > 0 {
+		for k, v := range txs {
+			var kept TXs
+			for _, tx := range v {
+				if !feeTXs[txKey{tx.Source, tx.ID}] {
+					kept = append(kept, tx)
+				}
+			}
+			txs[k] = kept
+		}
+	}
+}
+
+// exchangeRate is replaced in tests to avoid calling the rates APIs
+var exchangeRate = func(c Currency, date time.Time, native string) (decimal.Decimal, error) {
+	return c.GetExchangeRate(date, native)
+}
+
+// addCounterpart adds the fiat value of CashIn, CashOut and Exchanges that have only one side
+func (tx *TX) addCounterpart(r category.Rule, categ, native string) error {
+	var side, other string
+	if (categ == "CashOut" || categ == "Exchanges") && len(tx.Items["From"]) > 0 && len(tx.Items["To"]) == 0 {
+		side, other = "To", "From"
+	} else if categ == "CashIn" && len(tx.Items["To"]) > 0 && len(tx.Items["From"]) == 0 {
+		side, other = "From", "To"
+	} else {
+		return nil
+	}
+	c := Currency{Code: r.Currency, Amount: r.Value, Location: tx.Items[other][0].Location}
+	if c.Amount.IsZero() {
+		if categ == "Exchanges" {
+			return nil
+		}
+		rate, err := exchangeRate(tx.Items[other][0], tx.Timestamp, native)
+		if err != nil {
+			return err
+		}
+		c.Code = native
+		c.Amount = tx.Items[other][0].Amount.Mul(rate)
+	} else if c.Code == "" {
+		c.Code = native
+	}
+	tx.Items[side] = append(tx.Items[side], c)
+	return nil
+}
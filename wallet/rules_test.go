@@ -0,0 +1,180 @@
+package wallet
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/category"
+	"github.com/shopspring/decimal"
+)
+
+func TestWallet_ApplyRules(t *testing.T) {
+	const rules = `
+rules:
+  - name: Paiement carte
+    match:
+      source: Kraken
+      category: Withdrawals
+      note: "^Kraken CSV"
+      asset: BTC
+      max-amount: 0.5
+      after: 2020-01-01
+    category: CashOut
+    description: achat boutique
+    value: 1500
+    currency: EUR
+    fees: [f1]
+`
+	cat := category.New()
+	if err := cat.ParseYAMLRules(strings.NewReader(rules), time.UTC); err != nil {
+		t.Fatal(err)
+	}
+	txs := make(TXsByCategory)
+	txs["Withdrawals"] = TXs{
+		TX{
+			Timestamp: time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC),
+			ID:        "w1",
+			Source:    "Kraken",
+			Note:      "Kraken CSV : w1",
+			Items:     map[string]Currencies{"From": {Currency{Code: "BTC", Amount: decimal.New(2, -1)}}},
+		},
+		TX{
+			Timestamp: time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC),
+			ID:        "w2",
+			Source:    "Kraken",
+			Note:      "Kraken CSV : w2",
+			Items:     map[string]Currencies{"From": {Currency{Code: "BTC", Amount: decimal.NewFromInt(1)}}},
+		},
+	}
+	txs["Fees"] = TXs{
+		TX{
+			Timestamp: time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC),
+			ID:        "f1",
+			Source:    "Kraken",
+			Items:     map[string]Currencies{"Fee": {Currency{Code: "BTC", Amount: decimal.New(1, -4)}}},
+		},
+	}
+	txs["Deposits"] = TXs{
+		TX{
+			Timestamp: time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC),
+			ID:        "f1",
+			Source:    "Bitcoin",
+			Items:     map[string]Currencies{"To": {Currency{Code: "BTC", Amount: decimal.New(1, -1)}}},
+		},
+	}
+	txs.ApplyRules(*cat, "EUR")
+	if len(txs["Deposits"]) != 1 {
+		t.Errorf("ApplyRules() Deposits = %v, want f1 from another Source kept", txs["Deposits"])
+	}
+	if len(txs["Withdrawals"]) != 1 || txs["Withdrawals"][0].ID != "w2" {
+		t.Fatalf("ApplyRules() Withdrawals = %v, want only w2", txs["Withdrawals"])
+	}
+	if len(txs["Fees"]) != 0 {
+		t.Errorf("ApplyRules() Fees = %v, want f1 linked", txs["Fees"])
+	}
+	if len(txs["CashOut"]) != 1 {
+		t.Fatalf("ApplyRules() CashOut = %v, want w1", txs["CashOut"])
+	}
+	co := txs["CashOut"][0]
+	if len(co.Items["To"]) != 1 || co.Items["To"][0].Code != "EUR" || !co.Items["To"][0].Amount.Equal(decimal.NewFromInt(1500)) {
+		t.Errorf("ApplyRules() CashOut To = %v, want 1500 EUR", co.Items["To"])
+	}
+	if len(co.Items["Fee"]) != 1 || !strings.HasSuffix(co.Note, "achat boutique") {
+		t.Errorf("ApplyRules() CashOut = %v, want linked fee and description", co)
+	}
+}
+
+func TestCheckRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   string
+		wantErr bool
+	}{
+		{name: "known", rules: "rules:\n  - match:\n      category: Withdrawals\n    category: CashOut\n", wantErr: false},
+		{name: "unknown target", rules: "rules:\n  - category: Cashout\n", wantErr: true},
+		{name: "unknown match", rules: "rules:\n  - match:\n      category: Withdrawal\n    category: CashOut\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cat := category.New()
+			if err := cat.ParseYAMLRules(strings.NewReader(tt.rules), time.UTC); err != nil {
+				t.Fatal(err)
+			}
+			if err := CheckRules(*cat); (err != nil) != tt.wantErr {
+				t.Errorf("CheckRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWallet_ApplyRulesRateError(t *testing.T) {
+	const rules = `
+rules:
+  - name: Vente
+    match:
+      category: Withdrawals
+    category: CashOut
+    description: vente
+    fees: [f1]
+`
+	cat := category.New()
+	if err := cat.ParseYAMLRules(strings.NewReader(rules), time.UTC); err != nil {
+		t.Fatal(err)
+	}
+	defer func(f func(Currency, time.Time, string) (decimal.Decimal, error)) { exchangeRate = f }(exchangeRate)
+	exchangeRate = func(Currency, time.Time, string) (decimal.Decimal, error) {
+		return decimal.Zero, errors.New("no rate")
+	}
+	txs := make(TXsByCategory)
+	txs["Withdrawals"] = TXs{
+		TX{
+			Timestamp: time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC),
+			ID:        "w1",
+			Items:     map[string]Currencies{"From": {Currency{Code: "BTC", Amount: decimal.New(2, -1)}}},
+		},
+	}
+	txs["Fees"] = TXs{
+		TX{
+			Timestamp: time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC),
+			ID:        "f1",
+			Items:     map[string]Currencies{"Fee": {Currency{Code: "BTC", Amount: decimal.New(1, -4)}}},
+		},
+	}
+	txs.ApplyRules(*cat, "EUR")
+	if len(txs["CashOut"]) != 0 || len(txs["Withdrawals"]) != 1 {
+		t.Fatalf("ApplyRules() = %v, want w1 left in Withdrawals", txs)
+	}
+	if len(txs["Fees"]) != 1 || len(txs["Withdrawals"][0].Items["Fee"]) != 0 {
+		t.Errorf("ApplyRules() = %v, want f1 not linked", txs)
+	}
+}
+
+func TestWallet_ApplyRulesTwice(t *testing.T) {
+	const rules = `
+rules:
+  - match:
+      source: Kraken
+    description: compte joint
+`
+	cat := category.New()
+	if err := cat.ParseYAMLRules(strings.NewReader(rules), time.UTC); err != nil {
+		t.Fatal(err)
+	}
+	txs := make(TXsByCategory)
+	txs["Deposits"] = TXs{
+		TX{
+			Timestamp: time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC),
+			ID:        "d1",
+			Source:    "Kraken",
+			Note:      "Kraken CSV : d1",
+			Items:     map[string]Currencies{"To": {Currency{Code: "BTC", Amount: decimal.NewFromInt(1)}}},
+		},
+	}
+	txs.ApplyRules(*cat, "EUR")
+	txs.ApplyRules(*cat, "EUR")
+	if txs["Deposits"][0].Note != "Kraken CSV : d1 compte joint" {
+		t.Errorf("ApplyRules() Note = %v, want description once", txs["Deposits"][0].Note)
+	}
+}
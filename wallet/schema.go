@@ -0,0 +1,94 @@
+package wallet
+
+import (
+	"errors"
+)
+
+var Categories = []string{
+	"AirDrops",
+	"CashIn",
+	"CashOut",
+	"CommercialRebates",
+	"Deposits",
+	"Exchanges",
+	"Fees",
+	"Forks",
+	"Gifts",
+	"Interests",
+	"Minings",
+	"NFTs",
+	"Referrals",
+	"Transfers",
+	"Withdrawals",
+}
+
+func IsCategory(categ string) bool {
+	for _, c := range Categories {
+		if c == categ {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks that the TX could have been produced by any Source for this category
+func (tx TX) Validate(categ string) error {
+	if !IsCategory(categ) {
+		return errors.New("Unknown Category " + categ)
+	}
+	if tx.Timestamp.IsZero() {
+		return errors.New("Missing Timestamp")
+	}
+	for k, i := range tx.Items {
+		if k != "From" && k != "To" && k != "Fee" && k != "Lost" {
+			return errors.New("Unknown Item " + k)
+		}
+		for _, c := range i {
+			if c.Code == "" {
+				return errors.New("Missing Currency in " + k)
+			}
+			if c.Amount.IsNegative() {
+				return errors.New("Amount must not be negative in " + k + " " + c.Code)
+			}
+		}
+	}
+	for k, n := range tx.Nfts {
+		if k != "From" && k != "To" {
+			return errors.New("Unknown NFT Item " + k)
+		}
+		for _, nft := range n {
+			if nft.ID == "" {
+				return errors.New("Missing NFT ID in " + k)
+			}
+		}
+	}
+	hasFrom := len(tx.Items["From"]) > 0
+	hasTo := len(tx.Items["To"]) > 0
+	switch categ {
+	case "Exchanges", "CashIn", "CashOut", "Transfers":
+		if !hasFrom || !hasTo {
+			return errors.New(categ + " need From and To")
+		}
+	case "Deposits", "AirDrops", "CommercialRebates", "Forks", "Interests", "Minings", "Referrals":
+		if hasFrom || !hasTo {
+			return errors.New(categ + " need To without From")
+		}
+	case "Withdrawals":
+		if (!hasFrom && len(tx.Items["Lost"]) == 0) || hasTo {
+			return errors.New(categ + " need From or Lost without To")
+		}
+	case "Gifts":
+		if hasFrom == hasTo {
+			return errors.New(categ + " need either From or To")
+		}
+	case "NFTs":
+		if len(tx.Nfts["From"])+len(tx.Nfts["To"]) == 0 {
+			return errors.New(categ + " need From or To NFTs")
+		}
+	case "Fees":
+		if hasTo || (!hasFrom && len(tx.Items["Fee"]) == 0) {
+			return errors.New(categ + " need From or Fee without To")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestTX_Validate(t *testing.T) {
+	date := time.Date(2021, time.May, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		categ   string
+		tx      TX
+		wantErr bool
+	}{
+		{
+			name:  "Deposits with zero Fee",
+			categ: "Deposits",
+			tx: TX{Timestamp: date, Items: map[string]Currencies{
+				"To":  {Currency{Code: "BTC", Amount: decimal.NewFromInt(1)}},
+				"Fee": {Currency{Code: "BTC", Amount: decimal.Zero}},
+			}},
+			wantErr: false,
+		},
+		{
+			name:    "Deposits with negative To",
+			categ:   "Deposits",
+			tx:      TX{Timestamp: date, Items: map[string]Currencies{"To": {Currency{Code: "BTC", Amount: decimal.NewFromInt(-1)}}}},
+			wantErr: true,
+		},
+		{
+			name:  "NFTs received with Fee",
+			categ: "NFTs",
+			tx: TX{Timestamp: date, Items: map[string]Currencies{"Fee": {Currency{Code: "ETH", Amount: decimal.New(1, -3)}}},
+				Nfts: map[string]Nfts{"To": {Nft{ID: "7", Name: "Punk", Symbol: "PUNK"}}}},
+			wantErr: false,
+		},
+		{
+			name:    "NFTs without NFT",
+			categ:   "NFTs",
+			tx:      TX{Timestamp: date, Items: map[string]Currencies{"Fee": {Currency{Code: "ETH", Amount: decimal.New(1, -3)}}}},
+			wantErr: true,
+		},
+		{
+			name:    "NFTs without ID",
+			categ:   "NFTs",
+			tx:      TX{Timestamp: date, Nfts: map[string]Nfts{"From": {Nft{Name: "Punk"}}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.tx.Validate(tt.categ); (err != nil) != tt.wantErr {
+				t.Errorf("TX.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
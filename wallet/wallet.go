@@ -17,16 +17,18 @@ import (
 )
 
 type Nft struct {
-	ID     string
-	Name   string
-	Symbol string
+	ID       string
+	Name     string
+	Symbol   string
+	Quantity decimal.Decimal // only for ERC1155, zero for unique tokens
 }
 
 type Nfts []Nft
 
 type Currency struct {
-	Code   string
-	Amount decimal.Decimal
+	Code     string
+	Amount   decimal.Decimal
+	Location string
 }
 
 type Currencies []Currency
@@ -85,11 +87,20 @@ func (c *Currency) Println(filter string) {
 	}
 }
 
+// Symbol returns the Code without its chain prefix (BSC:USDT gives USDT)
+func (c Currency) Symbol() string {
+	if i := strings.Index(c.Code, ":"); i >= 0 {
+		return c.Code[i+1:]
+	}
+	return c.Code
+}
+
 func (c Currency) GetExchangeRate(date time.Time, to string) (rate decimal.Decimal, err error) {
+	symbol := c.Symbol()
 	if !c.IsFiat() {
 		gecko, err := NewCoinGeckoAPI()
 		if err == nil {
-			ratesCG, err := gecko.GetExchangeRates(date, c.Code)
+			ratesCG, err := gecko.GetExchangeRates(date, symbol)
 			if err == nil {
 				for _, r := range ratesCG.Rates {
 					if r.Quote == to && !r.Rate.IsZero() {
@@ -103,8 +114,8 @@ func (c Currency) GetExchangeRate(date time.Time, to string) (rate decimal.Decim
 	ratesCL, err := layer.GetExchangeRates(date, to)
 	if err == nil {
 		for k, v := range ratesCL.Rates {
-			if k == c.Code && v != 0 {
-				return decimal.NewFromFloat(ratesCL.Rates[c.Code]), nil
+			if k == symbol && v != 0 {
+				return decimal.NewFromFloat(v), nil
 			}
 		}
 	}
@@ -112,7 +123,7 @@ func (c Currency) GetExchangeRate(date time.Time, to string) (rate decimal.Decim
 	rates, err := api.GetExchangeRates(date, to)
 	if err == nil {
 		for _, r := range rates.Rates {
-			if r.Quote == c.Code && !r.Rate.IsZero() {
+			if r.Quote == symbol && !r.Rate.IsZero() {
 				return r.Rate, nil
 			}
 		}
@@ -230,7 +241,11 @@ func (tx TX) Println(filter string) (printed bool) {
 	for k, v := range tx.Nfts {
 		toPrint += fmt.Sprintln("NFT", k, ":")
 		for _, i := range v {
-			toPrint += fmt.Sprintln("  ", i.Name, i.Symbol, i.ID)
+			if i.Quantity.IsZero() {
+				toPrint += fmt.Sprintln("  ", i.Name, i.Symbol, i.ID)
+			} else {
+				toPrint += fmt.Sprintln("  ", i.Quantity.String(), "x", i.Name, i.Symbol, i.ID)
+			}
 		}
 	}
 	toPrint += fmt.Sprintln("Note :", tx.Note)
@@ -419,26 +434,29 @@ func (txs TXsByCategory) GetCoinsList(includeFiat bool) (coins []string) {
 	return
 }
 
-func (txs TXsByCategory) StockToXlsx(filename string) {
+func categoryLabel(cat string) string {
+	if cat == "Withdrawals" {
+		return "Retrait"
+	} else if cat == "Deposits" {
+		return "Dépot"
+	} else if cat == "Exchanges" {
+		return "Echange"
+	} else if cat == "Fees" {
+		return "Frais"
+	} else if cat == "Gifts" {
+		return "Don"
+	} else if cat == "Transfers" {
+		return "Transfert"
+	}
+	return cat
+}
+
+func (txs TXsByCategory) StockToXlsx(filename string) error {
 	f := excelize.NewFile()
 	var allTXs TXs
 	for cat, list := range txs {
 		for _, tx := range list {
-			if cat == "Withdrawals" {
-				tx.Category = "Retrait"
-			} else if cat == "Deposits" {
-				tx.Category = "Dépot"
-			} else if cat == "Exchanges" {
-				tx.Category = "Echange"
-			} else if cat == "Fees" {
-				tx.Category = "Frais"
-			} else if cat == "Gifts" {
-				tx.Category = "Don"
-			} else if cat == "Transfers" {
-				tx.Category = "Transfert"
-			} else {
-				tx.Category = cat
-			}
+			tx.Category = categoryLabel(cat)
 			allTXs = append(allTXs, tx)
 		}
 	}
@@ -492,9 +510,7 @@ func (txs TXsByCategory) StockToXlsx(filename string) {
 		f.SetColWidth(coin, "F", "F", 50)
 	}
 	f.DeleteSheet("Sheet1")
-	if err := f.SaveAs(filename); err != nil {
-		log.Fatal(err)
-	}
+	return f.SaveAs(filename)
 }
 
 func (txs TXsByCategory) GetWallets(date time.Time, includeFiat bool, rounding bool) (w Wallets) {
@@ -537,10 +553,10 @@ func (txs TXsByCategory) FindTransfers(cat category.Category) TXsByCategory {
 	txs["Deposits"].SortByDate(true)
 	txs["Withdrawals"].SortByDate(true)
 	for di, depTX := range txs["Deposits"] {
-		if !depTX.used && len(depTX.Items["To"]) > 0 {
+		if !depTX.used && len(depTX.Items["To"]) > 0 && !cat.IsTxUnlinked(depTX.Source, depTX.ID) {
 			depIsTransfer, forcedWitID := cat.IsTxTransfer(depTX.ID)
 			for wi, witTX := range txs["Withdrawals"] {
-				if !witTX.used && len(witTX.Items["From"]) > 0 {
+				if !witTX.used && len(witTX.Items["From"]) > 0 && !cat.IsTxUnlinked(witTX.Source, witTX.ID) {
 					witIsTransfer, forcedDepID := cat.IsTxTransfer(witTX.ID)
 					if depTX.Items["To"][0].Code == witTX.Items["From"][0].Code &&
 						depTX.SimilarDate(similarTimeDelta, witTX.Timestamp) &&
@@ -699,67 +715,3 @@ func (txs TXsByCategory) PrintStats(native string) {
 		fmt.Println(k, ":", len(txs[k]), "TXs")
 	}
 }
-
-func (txs TXsByCategory) CheckConsistency(loc *time.Location) {
-	fmt.Println("--------------------------------------------------------")
-	fmt.Println("| List of Unjustified Withdrawals (after 2019 Jan 1st) |")
-	for _, tx := range txs["Withdrawals"] {
-		if tx.Timestamp.After(time.Date(2018, time.December, 31, 23, 59, 59, 999, loc)) &&
-			len(tx.Items["From"]) > 0 {
-			fmt.Println("--------------------------------------------------------")
-			tx.Println("")
-		}
-	}
-	fmt.Println("--------------------------------------------------------")
-	fmt.Println("| List of Non-Zero balance Transfers                   |")
-	for _, tx := range txs["Transfers"] {
-		txcs := tx.GetBalances(false, false)
-		for _, v := range txcs {
-			if !v.IsZero() {
-				fmt.Println("--------------------------------------------------------")
-				tx.Println("")
-			}
-		}
-	}
-	fmt.Println("--------------------------------------------------------")
-	fmt.Println("| List of Negative Amounts TXs                         |")
-	for _, v := range txs {
-		for _, tx := range v {
-			for _, i := range tx.Items {
-				for _, c := range i {
-					if c.Amount.IsNegative() {
-						fmt.Println("--------------------------------------------------------")
-						tx.Println("")
-					}
-				}
-			}
-		}
-	}
-	for _, cat := range []string{"Deposits", "AirDrops", "CommercialRebates", "Interests", "Minings", "Referrals"} {
-		fmt.Println("--------------------------------------------------------")
-		fmt.Println("| List of " + cat + " with some From" + strings.Repeat(" ", 30-len(cat)) + "|")
-		txsCat := txs[cat]
-		if cat == "CommercialRebates" {
-			txsCat = txsCat.ApplyFromReversal()
-		}
-		for _, tx := range txsCat {
-			for k := range tx.Items {
-				if k == "From" {
-					fmt.Println("--------------------------------------------------------")
-					tx.Println("")
-				}
-			}
-		}
-	}
-	fmt.Println("--------------------------------------------------------")
-	fmt.Println("| List of Withdrawals with some To                     |")
-	for _, tx := range txs["Withdrawals"] {
-		for k := range tx.Items {
-			if k == "To" {
-				fmt.Println("--------------------------------------------------------")
-				tx.Println("")
-			}
-		}
-	}
-	fmt.Println("--------------------------------------------------------")
-}
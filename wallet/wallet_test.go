@@ -55,3 +55,22 @@ func TestWallet_TXsSortByDate(t *testing.T) {
 		})
 	}
 }
+
+func TestCurrency_Symbol(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{code: "ETH", want: "ETH"},
+		{code: "BSC:USDT", want: "USDT"},
+		{code: "POLYGON:WETH", want: "WETH"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			c := Currency{Code: tt.code}
+			if got := c.Symbol(); got != tt.want {
+				t.Errorf("Currency.Symbol() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package waltio
+
+import (
+	"io"
+
+	"github.com/fiscafacile/CryptoFiscaFacile/wallet"
+)
+
+var types = map[string]string{
+	"AirDrops":          "Airdrop",
+	"CashIn":            "Achat",
+	"CashOut":           "Vente",
+	"CommercialRebates": "Bonus",
+	"Deposits":          "Dépôt",
+	"Exchanges":         "Echange",
+	"Fees":              "Frais",
+	"Forks":             "Fork",
+	"Gifts":             "Don",
+	"Interests":         "Intérêts",
+	"Minings":           "Minage",
+	"Referrals":         "Bonus",
+	"Withdrawals":       "Retrait",
+}
+
+func row(tx wallet.TX, kind string, received, sent, fee *wallet.Currency) []string {
+	ra, rc := wallet.ExportAmount(received)
+	sa, sc := wallet.ExportAmount(sent)
+	fa, fc := wallet.ExportAmount(fee)
+	platform := ""
+	for _, c := range []*wallet.Currency{sent, received, fee} {
+		if c != nil && c.Location != "" {
+			platform = c.Location
+			break
+		}
+	}
+	return []string{tx.Timestamp.UTC().Format("02/01/2006 15:04:05"), "UTC", kind, ra, rc, sa, sc, fa, fc, platform, tx.Note, tx.ID}
+}
+
+func rows(tx wallet.TX) (lines [][]string) {
+	fees := tx.Items["Fee"]
+	var fee *wallet.Currency
+	if len(fees) > 0 {
+		fee = &fees[0]
+		fees = fees[1:]
+	}
+	if tx.Category == "Transfers" {
+		for i := range tx.Items["From"] {
+			lines = append(lines, row(tx, "Retrait", nil, &tx.Items["From"][i], fee))
+			fee = nil
+		}
+		for i := range tx.Items["To"] {
+			lines = append(lines, row(tx, "Dépôt", &tx.Items["To"][i], nil, nil))
+		}
+	} else {
+		n := len(tx.Items["From"])
+		if len(tx.Items["To"]) > n {
+			n = len(tx.Items["To"])
+		}
+		for i := 0; i < n; i++ {
+			var received, sent *wallet.Currency
+			if i < len(tx.Items["From"]) {
+				sent = &tx.Items["From"][i]
+			}
+			if i < len(tx.Items["To"]) {
+				received = &tx.Items["To"][i]
+			}
+			lines = append(lines, row(tx, types[tx.Category], received, sent, fee))
+			fee = nil
+		}
+	}
+	if fee != nil {
+		fees = append([]wallet.Currency{*fee}, fees...)
+	}
+	for i := range fees {
+		lines = append(lines, row(tx, "Frais", nil, nil, &fees[i]))
+	}
+	for i := range tx.Items["Lost"] {
+		lines = append(lines, row(tx, "Perte", nil, &tx.Items["Lost"][i], nil))
+	}
+	return
+}
+
+// ExportCSV writes the columns of the Waltio import template
+func ExportCSV(writer io.Writer, txs wallet.TXsByCategory) error {
+	return txs.RowsToCSV(writer, []string{"Date", "Fuseau horaire", "Type", "Montant reçu", "Monnaie reçue", "Montant envoyé", "Monnaie envoyée", "Frais", "Monnaie des frais", "Plateforme", "Description", "ID"}, rows)
+}